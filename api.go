@@ -15,9 +15,66 @@ type TerraformStationService interface {
 	TFInit(ctx context.Context, input *TFCommandInput) (*TFCommandResult, error)
 	TFValidate(ctx context.Context, input *TFCommandInput) (*TFCommandResult, error)
 	TFState(ctx context.Context, input *TFCommandInput) (*TFStateInfo, error)
+
+	// Streaming execution
+	TFCommandStream(ctx context.Context, input *TFCommandInput, handler OutputHandler) (*TFCommandResult, error)
+	TFSubscribeOutput(ctx context.Context, input *TFSubscribeInput, handler OutputHandler) error
+	TFSubscribeProgress(ctx context.Context, input *TFSubscribeInput, handler ProgressHandler) error
+	TFCancelOperation(ctx context.Context, input *TFOperationInput) (*TFOperation, error)
+
+	// Plan approval
+	TFApprovePlan(ctx context.Context, input *TFPlanReviewInput) (*TFPlanApprovalStatus, error)
+	TFRejectPlan(ctx context.Context, input *TFPlanReviewInput) (*TFPlanApprovalStatus, error)
+	TFGetPlanApproval(ctx context.Context, input *TFPlanApprovalInput) (*TFPlanApprovalStatus, error)
+
+	// Asynchronous jobs
+	TFSubmitJob(ctx context.Context, input *TFSubmitJobInput) (*TFJob, error)
+	TFGetJob(ctx context.Context, input *TFJobInput) (*TFJob, error)
+	TFListJobs(ctx context.Context, input *TFListJobsInput) (*TFJobList, error)
+	TFGetJobOutput(ctx context.Context, input *TFJobOutputInput) (*TFJobOutput, error)
+	TFCancelJob(ctx context.Context, input *TFJobInput) (*TFJob, error)
+
+	// Working directory locks
+	TFListLocks(ctx context.Context, input *TFListLocksInput) (*TFLockList, error)
+	TFForceUnlock(ctx context.Context, input *TFForceUnlockInput) (*TFLock, error)
+
+	// State version history
+	TFListStateVersions(ctx context.Context, input *TFStateVersionsInput) (*TFStateVersionList, error)
+	TFDiffStateVersions(ctx context.Context, input *TFStateDiffInput) (*TFStateDiff, error)
+	TFRollbackState(ctx context.Context, input *TFStateRollbackInput) (*TFStateVersion, error)
+
+	// Drift detection
+	TFDetectDrift(ctx context.Context, input *TFDriftInput) (*TFDriftReport, error)
+	TFListDriftReports(ctx context.Context, input *TFListDriftReportsInput) (*TFDriftReportList, error)
+	TFGetDriftReport(ctx context.Context, input *TFDriftReportInput) (*TFDriftReport, error)
+
+	// Workspaces. Commands run in the workspace named by TFCommandInput.Workspace, or the selected one.
+	TFListWorkspaces(ctx context.Context, input *TFWorkspaceInput) (*TFWorkspaceList, error)
+	TFShowWorkspace(ctx context.Context, input *TFWorkspaceInput) (*TFWorkspace, error)
+	TFNewWorkspace(ctx context.Context, input *TFWorkspaceInput) (*TFWorkspace, error)
+	TFSelectWorkspace(ctx context.Context, input *TFWorkspaceInput) (*TFWorkspace, error)
+	TFDeleteWorkspace(ctx context.Context, input *TFWorkspaceInput) (*TFWorkspace, error)
+
+	// Secrets, kept encrypted with the master key and referenced by name from TFCommandInput
+	TFPutSecret(ctx context.Context, input *TFPutSecretInput) (*TFSecret, error)
+	TFListSecrets(ctx context.Context, input *TFListSecretsInput) (*TFSecretList, error)
+	TFDeleteSecret(ctx context.Context, input *TFSecretInput) (*TFSecret, error)
+
+	// HTTP state backend. Lock conflicts return the current holder alongside a LOCKED error.
+	GetBackendState(ctx context.Context, name string) ([]byte, error)
+	PutBackendState(ctx context.Context, name, lockID string, data []byte) error
+	DeleteBackendState(ctx context.Context, name, lockID string) error
+	LockBackendState(ctx context.Context, name string, info *StateLockInfo) (*StateLockInfo, error)
+	UnlockBackendState(ctx context.Context, name string, info *StateLockInfo) (*StateLockInfo, error)
 	
 	// Utility methods
 	GetConfig() *Config
 	SetWorkingDirectory(dir string) error
 	ValidateWorkingDirectory(dir string) error
 }
+
+// OutputHandler receives output chunks from a streaming command in sequence order
+type OutputHandler func(chunk *TFOutputChunk) error
+
+// ProgressHandler receives the progress events of a command in sequence order
+type ProgressHandler func(event *TFProgressEvent) error
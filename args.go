@@ -0,0 +1,425 @@
+package TerraformStation
+
+import (
+	"sort"
+	"strings"
+)
+
+// flagSet lists the flags a subcommand accepts: bools are switches such as -json, which may also be
+// written -json=false, and values are flags that take a value such as -target=ADDRESS
+type flagSet struct {
+	bools  []string
+	values []string
+}
+
+// with returns the union of the flag sets
+func (f flagSet) with(others ...flagSet) flagSet {
+	union := flagSet{
+		bools:  append([]string(nil), f.bools...),
+		values: append([]string(nil), f.values...),
+	}
+	for _, other := range others {
+		union.bools = append(union.bools, other.bools...)
+		union.values = append(union.values, other.values...)
+	}
+	return union
+}
+
+// kind reports whether a flag is accepted and whether it takes a value
+func (f flagSet) kind(name string) (accepted, takesValue bool) {
+	for _, value := range f.values {
+		if value == name {
+			return true, true
+		}
+	}
+	for _, b := range f.bools {
+		if b == name {
+			return true, false
+		}
+	}
+	return false, false
+}
+
+// Flags shared by several subcommands
+var (
+	uiFlags       = flagSet{bools: []string{"-no-color", "-compact-warnings", "-concise", "-json"}}
+	inputFlags    = flagSet{bools: []string{"-input"}}
+	lockFlags     = flagSet{bools: []string{"-lock"}, values: []string{"-lock-timeout"}}
+	variableFlags = flagSet{values: []string{"-var", "-var-file"}}
+	// planningFlags choose what a plan covers, so they cannot be combined with a saved plan
+	planningFlags = flagSet{
+		bools:  []string{"-destroy", "-refresh-only", "-refresh"},
+		values: []string{"-target", "-exclude", "-replace"},
+	}
+	legacyStateFlags = flagSet{values: []string{"-state", "-state-out", "-backup"}}
+	remoteFlags      = flagSet{bools: []string{"-ignore-remote-version"}}
+)
+
+// CommandBuilder builds the command line of one OpenTofu subcommand. It knows which flags the
+// subcommand accepts and how the fields of a TFCommandInput map onto it, and rejects inputs
+// OpenTofu would refuse with a usage error.
+type CommandBuilder struct {
+	name  string
+	flags flagSet
+	// variables is set if Variables are passed as -var flags
+	variables bool
+	// stateFlag is set if StateFile is passed as -state, statePath if it is passed as the path argument
+	stateFlag bool
+	statePath bool
+	// planFile is set if PlanFile is passed as the last argument
+	planFile bool
+	// minArgs and maxArgs bound the positional arguments, with a negative maxArgs meaning no bound
+	minArgs, maxArgs int
+	// subcommands holds the builders of commands such as `state mv`, selected by the first argument
+	subcommands map[string]*CommandBuilder
+	// conflicts lists pairs of flags that cannot be used together
+	conflicts [][2]string
+}
+
+// commandBuilders holds the builder of each OpenTofu command the station can run
+var commandBuilders = map[string]*CommandBuilder{
+	"init": {
+		name: "init",
+		flags: flagSet{
+			bools:  []string{"-backend", "-force-copy", "-get", "-reconfigure", "-migrate-state", "-upgrade", "-no-color", "-json"},
+			values: []string{"-backend-config", "-from-module", "-plugin-dir", "-lockfile", "-test-directory"},
+		}.with(inputFlags, lockFlags, variableFlags, remoteFlags),
+		variables: true,
+		conflicts: [][2]string{{"-reconfigure", "-migrate-state"}},
+	},
+	"plan": {
+		name: "plan",
+		flags: flagSet{
+			bools:  []string{"-detailed-exitcode"},
+			values: []string{"-out", "-parallelism", "-generate-config-out", "-state"},
+		}.with(uiFlags, inputFlags, lockFlags, variableFlags, planningFlags),
+		variables: true,
+		stateFlag: true,
+		conflicts: [][2]string{{"-destroy", "-refresh-only"}},
+	},
+	"apply": {
+		name: "apply",
+		flags: flagSet{
+			bools:  []string{"-auto-approve", "-show-sensitive"},
+			values: []string{"-parallelism"},
+		}.with(uiFlags, inputFlags, lockFlags, variableFlags, planningFlags, legacyStateFlags),
+		variables: true,
+		stateFlag: true,
+		planFile:  true,
+		maxArgs:   1,
+		conflicts: [][2]string{{"-destroy", "-refresh-only"}},
+	},
+	"destroy": {
+		name: "destroy",
+		flags: flagSet{
+			bools:  []string{"-auto-approve", "-refresh"},
+			values: []string{"-parallelism", "-target", "-exclude"},
+		}.with(uiFlags, inputFlags, lockFlags, variableFlags, legacyStateFlags),
+		variables: true,
+		stateFlag: true,
+	},
+	"validate": {
+		name:  "validate",
+		flags: flagSet{bools: []string{"-json", "-no-color", "-no-tests"}, values: []string{"-test-directory"}},
+	},
+	"show": {
+		name:      "show",
+		flags:     flagSet{bools: []string{"-json", "-no-color", "-show-sensitive"}},
+		statePath: true,
+		planFile:  true,
+		maxArgs:   1,
+	},
+	"output": {
+		name:      "output",
+		flags:     flagSet{bools: []string{"-json", "-raw", "-no-color", "-show-sensitive"}, values: []string{"-state"}},
+		stateFlag: true,
+		maxArgs:   1,
+		conflicts: [][2]string{{"-json", "-raw"}},
+	},
+	"state": {
+		name: "state",
+		subcommands: map[string]*CommandBuilder{
+			"list": {name: "state list", flags: flagSet{values: []string{"-state", "-id"}}, stateFlag: true, maxArgs: -1},
+			"show": {name: "state show", flags: flagSet{bools: []string{"-show-sensitive"}, values: []string{"-state"}}, stateFlag: true, minArgs: 1, maxArgs: 1},
+			"mv": {
+				name:      "state mv",
+				flags:     flagSet{bools: []string{"-dry-run"}, values: []string{"-backup-out"}}.with(lockFlags, legacyStateFlags, remoteFlags),
+				stateFlag: true,
+				minArgs:   2,
+				maxArgs:   2,
+			},
+			"rm": {
+				name:      "state rm",
+				flags:     flagSet{bools: []string{"-dry-run"}, values: []string{"-state", "-backup"}}.with(lockFlags, remoteFlags),
+				stateFlag: true,
+				minArgs:   1,
+				maxArgs:   -1,
+			},
+			"pull": {name: "state pull"},
+			"push": {name: "state push", flags: flagSet{bools: []string{"-force"}}.with(lockFlags, remoteFlags), minArgs: 1, maxArgs: 1},
+			"replace-provider": {
+				name:      "state replace-provider",
+				flags:     flagSet{bools: []string{"-auto-approve"}, values: []string{"-state", "-backup"}}.with(lockFlags, remoteFlags),
+				stateFlag: true,
+				minArgs:   2,
+				maxArgs:   2,
+			},
+		},
+	},
+	"import": {
+		name: "import",
+		flags: flagSet{
+			bools:  []string{"-no-color"},
+			values: []string{"-config", "-parallelism"},
+		}.with(inputFlags, lockFlags, variableFlags, legacyStateFlags, remoteFlags),
+		variables: true,
+		stateFlag: true,
+		minArgs:   2,
+		maxArgs:   2,
+	},
+	"refresh": {
+		name: "refresh",
+		flags: flagSet{
+			values: []string{"-parallelism", "-target", "-exclude"},
+		}.with(uiFlags, inputFlags, lockFlags, variableFlags, legacyStateFlags),
+		variables: true,
+		stateFlag: true,
+	},
+	"taint": {
+		name:      "taint",
+		flags:     flagSet{bools: []string{"-allow-missing"}}.with(lockFlags, legacyStateFlags, remoteFlags),
+		stateFlag: true,
+		minArgs:   1,
+		maxArgs:   1,
+	},
+	"untaint": {
+		name:      "untaint",
+		flags:     flagSet{bools: []string{"-allow-missing"}}.with(lockFlags, legacyStateFlags, remoteFlags),
+		stateFlag: true,
+		minArgs:   1,
+		maxArgs:   1,
+	},
+	"workspace": {
+		name: "workspace",
+		subcommands: map[string]*CommandBuilder{
+			"list":   {name: "workspace list"},
+			"show":   {name: "workspace show"},
+			"new":    {name: "workspace new", flags: flagSet{values: []string{"-state"}}.with(lockFlags), minArgs: 1, maxArgs: 1},
+			"select": {name: "workspace select", flags: flagSet{bools: []string{"-or-create"}}, minArgs: 1, maxArgs: 1},
+			"delete": {name: "workspace delete", flags: flagSet{bools: []string{"-force"}}.with(lockFlags), minArgs: 1, maxArgs: 1},
+		},
+	},
+	"version": {
+		name:  "version",
+		flags: flagSet{bools: []string{"-json"}},
+	},
+}
+
+// BuildCommandArgs returns the arguments to run the OpenTofu command described by input. The
+// arguments start with the subcommand, followed by flags in the order given, variables sorted by
+// name, the given var-files, -state, the positional arguments and finally the plan file. The
+// working directory is not passed as -chdir, since commands already run in it.
+//
+// Var-files carry values the station adds to the input, such as the temporary file holding the
+// values of SecretVariables, which are not passed on the command line.
+func BuildCommandArgs(input *TFCommandInput, varFiles ...string) ([]string, error) {
+	if input == nil {
+		return nil, NewInvalidInputError("input cannot be nil")
+	}
+	builder, ok := commandBuilders[input.Command]
+	if !ok {
+		return nil, NewInvalidInputError("invalid opentofu command", input.Command)
+	}
+	return builder.Build(input, varFiles...)
+}
+
+// Build returns the arguments to run the builder's command with the given input and var-files
+func (b *CommandBuilder) Build(input *TFCommandInput, varFiles ...string) ([]string, error) {
+	if b.subcommands != nil {
+		return b.buildSubcommand(input, varFiles)
+	}
+	return b.build(input, varFiles, []string{b.name}, input.Arguments)
+}
+
+// buildSubcommand builds a command such as `state mv`, whose subcommand is the first argument
+func (b *CommandBuilder) buildSubcommand(input *TFCommandInput, varFiles []string) ([]string, error) {
+	if len(input.Arguments) == 0 {
+		return nil, NewInvalidInputError(b.name+" requires a subcommand", strings.Join(b.subcommandNames(), ", "))
+	}
+	sub, ok := b.subcommands[input.Arguments[0]]
+	if !ok {
+		return nil, NewInvalidInputError("invalid "+b.name+" subcommand", input.Arguments[0])
+	}
+	return sub.build(input, varFiles, []string{b.name, input.Arguments[0]}, input.Arguments[1:])
+}
+
+// subcommandNames lists the subcommands of a builder in alphabetical order
+func (b *CommandBuilder) subcommandNames() []string {
+	names := make([]string, 0, len(b.subcommands))
+	for name := range b.subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// build validates the input against the flags and arguments the command accepts and
+// assembles the command line after the given command words
+func (b *CommandBuilder) build(input *TFCommandInput, varFiles, command, arguments []string) ([]string, error) {
+	flags, positional, err := b.parseArguments(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := len(input.Variables) > 0 || len(input.SecretVariables) > 0 || len(varFiles) > 0
+	if variables && !b.variables {
+		return nil, NewInvalidInputError(b.name + " does not accept variables")
+	}
+	if input.StateFile != "" && !b.stateFlag && !b.statePath {
+		return nil, NewInvalidInputError(b.name + " does not accept a state file")
+	}
+	if input.PlanFile != "" && !b.planFile {
+		return nil, NewInvalidInputError(b.name + " does not accept a plan file")
+	}
+	if input.PlanFile != "" && input.StateFile != "" && b.statePath {
+		return nil, NewInvalidInputError(b.name + " accepts either a plan file or a state file, not both")
+	}
+	if err := b.checkConflicts(flags); err != nil {
+		return nil, err
+	}
+
+	if input.PlanFile != "" {
+		// A saved plan already fixes variables and what the plan covers
+		if variables || hasFlagNamed(flags, variableFlags.values...) {
+			return nil, NewInvalidInputError("variables cannot be set when applying a saved plan")
+		}
+		if hasFlagNamed(flags, planningFlags.bools...) || hasFlagNamed(flags, planningFlags.values...) {
+			return nil, NewInvalidInputError("planning options cannot be set when applying a saved plan")
+		}
+	}
+
+	// The plan or state file given by the input is a positional argument too
+	argCount := len(positional)
+	if input.PlanFile != "" || (input.StateFile != "" && b.statePath) {
+		argCount++
+	}
+	if argCount < b.minArgs {
+		return nil, NewInvalidInputError(b.name+" requires more arguments", strings.Join(positional, " "))
+	}
+	if b.maxArgs >= 0 && argCount > b.maxArgs {
+		return nil, NewInvalidInputError(b.name+" received too many arguments", strings.Join(positional, " "))
+	}
+
+	args := append([]string(nil), command...)
+	args = append(args, flags...)
+	for _, name := range sortedKeys(input.Variables) {
+		args = append(args, "-var="+name+"="+input.Variables[name])
+	}
+	for _, varFile := range varFiles {
+		args = append(args, "-var-file="+varFile)
+	}
+	if input.StateFile != "" && b.stateFlag {
+		if hasFlagNamed(flags, "-state") {
+			return nil, NewInvalidInputError("state_file and -state cannot both be set")
+		}
+		args = append(args, "-state="+input.StateFile)
+	}
+	if len(positional) > 0 && strings.HasPrefix(positional[0], "-") {
+		// Keep arguments that look like flags from being read as flags
+		args = append(args, "--")
+	}
+	args = append(args, positional...)
+	if input.PlanFile != "" {
+		args = append(args, input.PlanFile)
+	} else if input.StateFile != "" && b.statePath {
+		args = append(args, input.StateFile)
+	}
+	return args, nil
+}
+
+// parseArguments splits arguments into flags, each written as -name or -name=value, and positional
+// arguments, rejecting flags the command does not accept
+func (b *CommandBuilder) parseArguments(arguments []string) (flags, positional []string, err error) {
+	for i := 0; i < len(arguments); i++ {
+		arg := arguments[i]
+		if arg == "--" {
+			return flags, append(positional, arguments[i+1:]...), nil
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+
+		// Go's flag package, which OpenTofu uses, accepts both -name and --name
+		name, value, hasValue := strings.Cut(arg, "=")
+		name = "-" + strings.TrimLeft(name, "-")
+		if name == "-chdir" {
+			return nil, nil, NewInvalidInputError("-chdir is a global option, set working_directory instead")
+		}
+
+		accepted, takesValue := b.flags.kind(name)
+		if !accepted {
+			return nil, nil, NewInvalidInputError(b.name + " does not accept " + name)
+		}
+		if takesValue && !hasValue {
+			// The value may also be the next argument, as in -target aws_instance.web
+			if i+1 >= len(arguments) {
+				return nil, nil, NewInvalidInputError(name + " requires a value")
+			}
+			i++
+			value, hasValue = arguments[i], true
+		}
+		if hasValue {
+			flags = append(flags, name+"="+value)
+		} else {
+			flags = append(flags, name)
+		}
+	}
+	return flags, positional, nil
+}
+
+// checkConflicts rejects flags that cannot be used together, unless one of them is switched off
+func (b *CommandBuilder) checkConflicts(flags []string) error {
+	for _, pair := range b.conflicts {
+		if flagEnabled(flags, pair[0]) && flagEnabled(flags, pair[1]) {
+			return NewInvalidInputError(pair[0] + " and " + pair[1] + " cannot be used together")
+		}
+	}
+	return nil
+}
+
+// hasFlagNamed reports whether flags, as normalized by parseArguments, set any of the named flags
+func hasFlagNamed(flags []string, names ...string) bool {
+	for _, flag := range flags {
+		name, _, _ := strings.Cut(flag, "=")
+		for _, n := range names {
+			if name == n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// flagEnabled reports whether flags switch on a boolean flag
+func flagEnabled(flags []string, name string) bool {
+	enabled := false
+	for _, flag := range flags {
+		if flag == name || flag == name+"=true" {
+			enabled = true
+		} else if flag == name+"=false" {
+			enabled = false
+		}
+	}
+	return enabled
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,116 @@
+package TerraformStation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCommandArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *TFCommandInput
+		want  []string
+	}{
+		{
+			name:  "init flags normalized",
+			input: &TFCommandInput{Command: "init", WorkingDirectory: "/infra", Arguments: []string{"-backend-config", "bucket=state", "-upgrade"}},
+			want:  []string{"init", "-backend-config=bucket=state", "-upgrade"},
+		},
+		{
+			name: "plan variables sorted before state",
+			input: &TFCommandInput{
+				Command:   "plan",
+				Arguments: []string{"-input=false", "--target=aws_instance.web"},
+				Variables: map[string]string{"region": "eu-west-1", "env": "prod", "count": "3"},
+				StateFile: "custom.tfstate",
+			},
+			want: []string{"plan", "-input=false", "-target=aws_instance.web", "-var=count=3", "-var=env=prod", "-var=region=eu-west-1", "-state=custom.tfstate"},
+		},
+		{
+			name:  "apply plan file last",
+			input: &TFCommandInput{Command: "apply", Arguments: []string{"-auto-approve", "-json"}, StateFile: "custom.tfstate", PlanFile: "tfplan"},
+			want:  []string{"apply", "-auto-approve", "-json", "-state=custom.tfstate", "tfplan"},
+		},
+		{
+			name:  "show state path",
+			input: &TFCommandInput{Command: "show", Arguments: []string{"-json"}, StateFile: "custom.tfstate"},
+			want:  []string{"show", "-json", "custom.tfstate"},
+		},
+		{
+			name:  "state subcommand",
+			input: &TFCommandInput{Command: "state", Arguments: []string{"mv", "aws_instance.a", "aws_instance.b", "-dry-run"}, StateFile: "custom.tfstate"},
+			want:  []string{"state", "mv", "-dry-run", "-state=custom.tfstate", "aws_instance.a", "aws_instance.b"},
+		},
+		{
+			name:  "import",
+			input: &TFCommandInput{Command: "import", Arguments: []string{"aws_instance.web", "i-abc123"}, Variables: map[string]string{"env": "prod"}},
+			want:  []string{"import", "-var=env=prod", "aws_instance.web", "i-abc123"},
+		},
+		{
+			name:  "arguments after end of flags",
+			input: &TFCommandInput{Command: "workspace", Arguments: []string{"new", "--", "-odd"}},
+			want:  []string{"workspace", "new", "--", "-odd"},
+		},
+		{
+			name:  "switched off conflict",
+			input: &TFCommandInput{Command: "plan", Arguments: []string{"-destroy", "-refresh-only=false"}},
+			want:  []string{"plan", "-destroy", "-refresh-only=false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := BuildCommandArgs(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, args)
+		})
+	}
+}
+
+func TestBuildCommandArgsVarFiles(t *testing.T) {
+	input := &TFCommandInput{Command: "plan", Variables: map[string]string{"env": "prod"}, StateFile: "custom.tfstate"}
+	args, err := BuildCommandArgs(input, "/tmp/secrets.tfvars.json")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"plan", "-var=env=prod", "-var-file=/tmp/secrets.tfvars.json", "-state=custom.tfstate"}, args)
+
+	_, err = BuildCommandArgs(&TFCommandInput{Command: "validate"}, "/tmp/secrets.tfvars.json")
+	assert.Error(t, err)
+}
+
+func TestBuildCommandArgsRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *TFCommandInput
+	}{
+		{name: "unknown command", input: &TFCommandInput{Command: "console"}},
+		{name: "chdir", input: &TFCommandInput{Command: "plan", Arguments: []string{"-chdir=/tmp"}}},
+		{name: "unknown flag", input: &TFCommandInput{Command: "validate", Arguments: []string{"-auto-approve"}}},
+		{name: "missing value", input: &TFCommandInput{Command: "plan", Arguments: []string{"-target"}}},
+		{name: "variables on validate", input: &TFCommandInput{Command: "validate", Variables: map[string]string{"env": "prod"}}},
+		{name: "state file on init", input: &TFCommandInput{Command: "init", StateFile: "custom.tfstate"}},
+		{name: "plan file on plan", input: &TFCommandInput{Command: "plan", PlanFile: "tfplan"}},
+		{name: "variables with saved plan", input: &TFCommandInput{Command: "apply", PlanFile: "tfplan", Variables: map[string]string{"env": "prod"}}},
+		{name: "secret variables on validate", input: &TFCommandInput{Command: "validate", SecretVariables: map[string]string{"password": "db"}}},
+		{name: "secret variables with saved plan", input: &TFCommandInput{Command: "apply", PlanFile: "tfplan", SecretVariables: map[string]string{"password": "db"}}},
+		{name: "target with saved plan", input: &TFCommandInput{Command: "apply", PlanFile: "tfplan", Arguments: []string{"-target=aws_instance.web"}}},
+		{name: "destroy and refresh-only", input: &TFCommandInput{Command: "plan", Arguments: []string{"-destroy", "-refresh-only"}}},
+		{name: "output json and raw", input: &TFCommandInput{Command: "output", Arguments: []string{"-json", "-raw"}}},
+		{name: "show plan and state", input: &TFCommandInput{Command: "show", PlanFile: "tfplan", StateFile: "custom.tfstate"}},
+		{name: "state file twice", input: &TFCommandInput{Command: "plan", Arguments: []string{"-state=a.tfstate"}, StateFile: "b.tfstate"}},
+		{name: "too few arguments", input: &TFCommandInput{Command: "import", Arguments: []string{"aws_instance.web"}}},
+		{name: "too many arguments", input: &TFCommandInput{Command: "plan", Arguments: []string{"extra"}}},
+		{name: "missing subcommand", input: &TFCommandInput{Command: "state"}},
+		{name: "unknown subcommand", input: &TFCommandInput{Command: "workspace", Arguments: []string{"rename", "a", "b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildCommandArgs(tt.input)
+			var tfErr *TerraformError
+			require.ErrorAs(t, err, &tfErr)
+			assert.Equal(t, ErrCodeInvalidInput, tfErr.Code)
+		})
+	}
+}
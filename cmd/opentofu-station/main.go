@@ -5,47 +5,63 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/ForestMars/TerraformStation"
 	"github.com/ForestMars/TerraformStation/factory"
+	"github.com/ForestMars/TerraformStation/server"
 )
 
 func main() {
 	// Parse command line flags
+	configPath := flag.String("config", "", "Path to the YAML configuration file")
 	opentofuPath := flag.String("opentofu", "tofu", "Path to opentofu binary")
 	workingDir := flag.String("workdir", "./tofu", "Working directory for OpenTofu operations")
 	port := flag.String("port", "8080", "Port to listen on")
+	grpcPort := flag.String("grpc-port", "9091", "Port for the gRPC API to listen on")
 	host := flag.String("host", "localhost", "Host to bind to")
 	dbDriver := flag.String("db-driver", "sqlite", "Database driver (sqlite or postgres)")
 	flag.Parse()
 
-	// Create default configuration
-	cfg := TerraformStation.DefaultConfig()
-	
-	// Override with command line flags
-	if *opentofuPath != "" {
-		cfg.OpenTofuPath = *opentofuPath
-	}
-	if *workingDir != "" {
-		cfg.WorkingDirectory = *workingDir
-	}
-	if *port != "" {
-		cfg.Port = *port
-	}
-	if *host != "" {
-		cfg.Host = *host
+	// Load defaults, the configuration file and environment variables
+	cfg, err := TerraformStation.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
 	}
-	if *dbDriver != "" {
-		cfg.Database.Driver = *dbDriver
+
+	// Override with command line flags that were set explicitly
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "opentofu":
+			cfg.OpenTofuPath = *opentofuPath
+		case "workdir":
+			cfg.WorkingDirectory = *workingDir
+		case "port":
+			cfg.Port = *port
+		case "grpc-port":
+			cfg.GRPCPort = *grpcPort
+		case "host":
+			cfg.Host = *host
+		case "db-driver":
+			cfg.Database.Driver = *dbDriver
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
 	}
 
+	// Log at the configured level from here on
+	TerraformStation.ConfigureLogging(cfg)
+
 	// Initialize database
 	dbManager, err := TerraformStation.NewDatabaseManager(cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		slog.Error("Failed to initialize database", "error", err)
+		os.Exit(1)
 	}
 	defer dbManager.Close()
 
@@ -65,41 +81,40 @@ func main() {
 
 	go func() {
 		<-sigChan
-		log.Println("Received shutdown signal, shutting down gracefully...")
+		slog.Info("Received shutdown signal, shutting down gracefully...")
 		cancel()
 	}()
 
 	// Start the service
-	log.Printf("Starting OpenTofu Station on %s:%s", cfg.Host, cfg.Port)
-	log.Printf("OpenTofu binary: %s", cfg.OpenTofuPath)
-	log.Printf("Working directory: %s", cfg.WorkingDirectory)
-	log.Printf("Database driver: %s", cfg.Database.Driver)
-
-	// For now, just run a simple test command
-	if err := runTestCommand(ctx, service); err != nil {
-		log.Printf("Test command failed: %v", err)
-	}
+	slog.Info(fmt.Sprintf("Starting OpenTofu Station on %s:%s (gRPC on port %s)", cfg.Host, cfg.Port, cfg.GRPCPort))
+	slog.Info("OpenTofu binary: " + cfg.OpenTofuPath)
+	slog.Info("Working directory: " + cfg.WorkingDirectory)
+	slog.Info("Database driver: " + cfg.Database.Driver)
 
-	// Wait for context cancellation
-	<-ctx.Done()
-	log.Println("OpenTofu Station stopped")
-}
+	// Run queued jobs in the background until shutdown
+	service.StartJobWorkers(ctx)
 
-func runTestCommand(ctx context.Context, service TerraformStation.TerraformStationService) error {
-	log.Println("Running test command...")
+	// Check registered working directories for drift on their schedules
+	service.StartDriftScheduler(ctx)
 
-	// Create a test input
-	input := &TerraformStation.TFCommandInput{
-		Command:        "version",
-		WorkingDirectory: "./tofu",
-	}
+	// Serve the HTTP and gRPC APIs until shutdown
+	httpServer := server.NewHTTPServer(service, cfg)
+	grpcServer := server.NewGRPCServer(service, cfg)
 
-	// Execute the command
-	result, err := service.TFCommand(ctx, input)
-	if err != nil {
-		return fmt.Errorf("failed to execute opentofu version: %w", err)
+	errChan := make(chan error, 2)
+	go func() {
+		errChan <- httpServer.ListenAndServe(ctx)
+	}()
+	go func() {
+		errChan <- grpcServer.ListenAndServe(ctx)
+	}()
+
+	for i := 0; i < 2; i++ {
+		if err := <-errChan; err != nil {
+			slog.Error("Server stopped with error", "error", err)
+			cancel()
+		}
 	}
 
-	log.Printf("Command executed successfully: %s", result.Result)
-	return nil
+	slog.Info("OpenTofu Station stopped")
 }
@@ -9,6 +9,25 @@ type Config struct {
 	OpenTofuPath    string        `json:"opentofu_path" yaml:"opentofu_path"`
 	WorkingDirectory string        `json:"working_directory" yaml:"working_directory"`
 	Timeout          time.Duration `json:"timeout" yaml:"timeout"`
+	// InterruptGracePeriod is how long a cancelled or timed out command may take to stop after
+	// being interrupted before its process group is killed
+	InterruptGracePeriod time.Duration `json:"interrupt_grace_period" yaml:"interrupt_grace_period"`
+	
+	// Saved plan configuration
+	PlanDirectory string        `json:"plan_directory" yaml:"plan_directory"`
+	PlanMaxAge    time.Duration `json:"plan_max_age" yaml:"plan_max_age"`
+	
+	// Approval configuration
+	Approvals ApprovalConfig `json:"approvals" yaml:"approvals"`
+	
+	// Asynchronous job configuration
+	Jobs JobsConfig `json:"jobs" yaml:"jobs"`
+	
+	// Working directory lock configuration
+	Locks LocksConfig `json:"locks" yaml:"locks"`
+	
+	// Scheduled drift detection configuration
+	Drift DriftConfig `json:"drift" yaml:"drift"`
 	
 	// Database configuration
 	Database DatabaseConfig `json:"database" yaml:"database"`
@@ -18,8 +37,30 @@ type Config struct {
 	
 	// API configuration
 	Port         string `json:"port" yaml:"port"`
+	GRPCPort     string `json:"grpc_port" yaml:"grpc_port"`
 	Host         string `json:"host" yaml:"host"`
 	EnableCORS   bool   `json:"enable_cors" yaml:"enable_cors"`
+	
+	// Security configuration
+	Security SecurityConfig `json:"security" yaml:"security"`
+	
+	// OpenTofu provider configuration
+	Providers ProvidersConfig `json:"providers" yaml:"providers"`
+	
+	// Environment of OpenTofu commands
+	Environment EnvironmentConfig `json:"environment" yaml:"environment"`
+	
+	// Secret store configuration
+	Secrets SecretsConfig `json:"secrets" yaml:"secrets"`
+	
+	// Output redaction configuration
+	Redaction RedactionConfig `json:"redaction" yaml:"redaction"`
+	
+	// Backup configuration
+	Backup BackupConfig `json:"backup" yaml:"backup"`
+	
+	// Monitoring configuration
+	Monitoring MonitoringConfig `json:"monitoring" yaml:"monitoring"`
 }
 
 type DatabaseConfig struct {
@@ -32,14 +73,160 @@ type DatabaseConfig struct {
 	SSLMode  string `json:"ssl_mode" yaml:"ssl_mode"`
 }
 
+// JobsConfig sizes the worker pool that runs asynchronous jobs
+type JobsConfig struct {
+	Workers int `json:"workers" yaml:"workers"`
+	// PollInterval is how often idle workers check the queue for jobs submitted elsewhere
+	PollInterval time.Duration `json:"poll_interval" yaml:"poll_interval"`
+}
+
+// LocksConfig controls how conflicting runs against a working directory are serialized
+type LocksConfig struct {
+	// WaitTimeout is how long a run waits for a held lock before it is rejected; zero rejects immediately
+	WaitTimeout   time.Duration `json:"wait_timeout" yaml:"wait_timeout"`
+	RetryInterval time.Duration `json:"retry_interval" yaml:"retry_interval"`
+	// TTL is how long a lock survives without a heartbeat before another run may take it over
+	TTL time.Duration `json:"ttl" yaml:"ttl"`
+}
+
+// DriftConfig controls scheduled drift detection for registered working directories
+type DriftConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Schedule is the cron expression used by working directories without a schedule of their own
+	Schedule           string        `json:"schedule" yaml:"schedule"`
+	WorkingDirectories []DriftTarget `json:"working_directories" yaml:"working_directories"`
+	// WebhookURL receives a JSON event when drift first appears in a working directory or is resolved
+	WebhookURL string `json:"webhook_url" yaml:"webhook_url"`
+}
+
+// DriftTarget registers a working directory for drift detection
+type DriftTarget struct {
+	Path string `json:"path" yaml:"path"`
+	// Workspace to check, defaulting to the workspace selected in the working directory
+	Workspace string `json:"workspace" yaml:"workspace"`
+	Schedule  string `json:"schedule" yaml:"schedule"`
+}
+
+// ScheduleFor returns the cron expression drift detection uses for a registered working directory
+func (c DriftConfig) ScheduleFor(target DriftTarget) string {
+	if target.Schedule != "" {
+		return target.Schedule
+	}
+	return c.Schedule
+}
+
+type SecurityConfig struct {
+	EnableAuth     bool     `json:"enable_auth" yaml:"enable_auth"`
+	JWTSecret      string   `json:"jwt_secret" yaml:"jwt_secret"`
+	AllowedOrigins []string `json:"allowed_origins" yaml:"allowed_origins"`
+}
+
+// SecretsConfig enables the secret store
+type SecretsConfig struct {
+	// MasterKeyFile holds the key secrets are encrypted with. Secrets cannot be stored or used without it.
+	MasterKeyFile string `json:"master_key_file" yaml:"master_key_file"`
+}
+
+type ProvidersConfig struct {
+	AWS   AWSProviderConfig   `json:"aws" yaml:"aws"`
+	Azure AzureProviderConfig `json:"azure" yaml:"azure"`
+	GCP   GCPProviderConfig   `json:"gcp" yaml:"gcp"`
+}
+
+type AWSProviderConfig struct {
+	Region          string `json:"region" yaml:"region"`
+	Profile         string `json:"profile" yaml:"profile"`
+	AccessKeyID     string `json:"access_key_id" yaml:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key" yaml:"secret_access_key"`
+	SessionToken    string `json:"session_token" yaml:"session_token"`
+}
+
+type AzureProviderConfig struct {
+	SubscriptionID string `json:"subscription_id" yaml:"subscription_id"`
+	TenantID       string `json:"tenant_id" yaml:"tenant_id"`
+	ClientID       string `json:"client_id" yaml:"client_id"`
+	ClientSecret   string `json:"client_secret" yaml:"client_secret"`
+}
+
+type GCPProviderConfig struct {
+	ProjectID string `json:"project_id" yaml:"project_id"`
+	Region    string `json:"region" yaml:"region"`
+	// Credentials is the path to or contents of a service account key file
+	Credentials string `json:"credentials" yaml:"credentials"`
+}
+
+type BackupConfig struct {
+	Enable        bool   `json:"enable" yaml:"enable"`
+	Schedule      string `json:"schedule" yaml:"schedule"`
+	RetentionDays int    `json:"retention_days" yaml:"retention_days"`
+	StoragePath   string `json:"storage_path" yaml:"storage_path"`
+}
+
+type MonitoringConfig struct {
+	EnableMetrics       bool          `json:"enable_metrics" yaml:"enable_metrics"`
+	MetricsPort         string        `json:"metrics_port" yaml:"metrics_port"`
+	EnableHealthCheck   bool          `json:"enable_health_check" yaml:"enable_health_check"`
+	HealthCheckInterval time.Duration `json:"health_check_interval" yaml:"health_check_interval"`
+}
+
+// ApprovalConfig controls how many reviewers must approve a plan before it can be applied
+type ApprovalConfig struct {
+	// RequiredApprovals applies to working directories not listed in WorkingDirectories
+	RequiredApprovals  int            `json:"required_approvals" yaml:"required_approvals"`
+	WorkingDirectories map[string]int `json:"working_directories" yaml:"working_directories"`
+	Timeout            time.Duration  `json:"timeout" yaml:"timeout"`
+}
+
+// RequiredFor returns the number of approvals needed for plans in a working directory
+func (c ApprovalConfig) RequiredFor(workingDir string) int {
+	for dir, required := range c.WorkingDirectories {
+		if canonical, err := CanonicalWorkingDirectory(dir); err == nil && canonical == workingDir {
+			return required
+		}
+	}
+	return c.RequiredApprovals
+}
+
+// Required reports whether any working directory requires approvals
+func (c ApprovalConfig) Required() bool {
+	if c.RequiredApprovals > 0 {
+		return true
+	}
+	for _, required := range c.WorkingDirectories {
+		if required > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // DefaultConfig returns a default configuration
 func DefaultConfig() *Config {
 	return &Config{
 		OpenTofuPath:    "tofu",
 		WorkingDirectory: "./tofu",
 		Timeout:          30 * time.Minute,
+		InterruptGracePeriod: time.Minute,
+		PlanDirectory:    "./plans",
+		PlanMaxAge:       24 * time.Hour,
+		Approvals: ApprovalConfig{
+			Timeout: 24 * time.Hour,
+		},
+		Jobs: JobsConfig{
+			Workers:      2,
+			PollInterval: 5 * time.Second,
+		},
+		Locks: LocksConfig{
+			WaitTimeout:   10 * time.Minute,
+			RetryInterval: time.Second,
+			TTL:           2 * time.Minute,
+		},
+		Drift: DriftConfig{
+			Schedule: "0 * * * *",
+		},
 		LogLevel:         "info",
 		Port:             "8080",
+		GRPCPort:         "9091",
 		Host:             "localhost",
 		EnableCORS:       true,
 		Database: DatabaseConfig{
@@ -48,5 +235,17 @@ func DefaultConfig() *Config {
 			Port:     5432,
 			SSLMode:  "disable",
 		},
+		Environment: EnvironmentConfig{
+			Allowlist: append([]string(nil), DefaultEnvironmentAllowlist...),
+		},
+		Backup: BackupConfig{
+			Schedule:      "0 2 * * *",
+			RetentionDays: 30,
+			StoragePath:   "./backups",
+		},
+		Monitoring: MonitoringConfig{
+			MetricsPort:       "9090",
+			EnableHealthCheck: true,
+		},
 	}
 }
@@ -0,0 +1,323 @@
+package TerraformStation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldError describes why a single configuration field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ConfigError lists every invalid field found in a configuration
+type ConfigError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+func (e *ConfigError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fieldErr.Error()
+	}
+	return "invalid configuration: " + strings.Join(messages, "; ")
+}
+
+// add records an invalid field
+func (e *ConfigError) add(field, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// errOrNil returns the error if any field was invalid
+func (e *ConfigError) errOrNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// envBinding maps an environment variable onto a configuration field
+type envBinding struct {
+	name  string
+	field string
+	set   func(cfg *Config, value string) error
+}
+
+// envBindings lists the environment variables that override the configuration file
+var envBindings = []envBinding{
+	{"OPENTOFU_PATH", "opentofu_path", setString(func(c *Config) *string { return &c.OpenTofuPath })},
+	{"WORKING_DIRECTORY", "working_directory", setString(func(c *Config) *string { return &c.WorkingDirectory })},
+	{"TIMEOUT", "timeout", setDuration(func(c *Config) *time.Duration { return &c.Timeout })},
+	{"INTERRUPT_GRACE_PERIOD", "interrupt_grace_period", setDuration(func(c *Config) *time.Duration { return &c.InterruptGracePeriod })},
+	{"PLAN_DIRECTORY", "plan_directory", setString(func(c *Config) *string { return &c.PlanDirectory })},
+	{"PLAN_MAX_AGE", "plan_max_age", setDuration(func(c *Config) *time.Duration { return &c.PlanMaxAge })},
+	{"JOB_WORKERS", "jobs.workers", setInt(func(c *Config) *int { return &c.Jobs.Workers })},
+	{"DB_DRIVER", "database.driver", setString(func(c *Config) *string { return &c.Database.Driver })},
+	{"DB_HOST", "database.host", setString(func(c *Config) *string { return &c.Database.Host })},
+	{"DB_PORT", "database.port", setInt(func(c *Config) *int { return &c.Database.Port })},
+	{"DB_USERNAME", "database.username", setString(func(c *Config) *string { return &c.Database.Username })},
+	{"DB_PASSWORD", "database.password", setString(func(c *Config) *string { return &c.Database.Password })},
+	{"DB_DATABASE", "database.database", setString(func(c *Config) *string { return &c.Database.Database })},
+	{"DB_SSL_MODE", "database.ssl_mode", setString(func(c *Config) *string { return &c.Database.SSLMode })},
+	{"LOG_LEVEL", "log_level", setString(func(c *Config) *string { return &c.LogLevel })},
+	{"PORT", "port", setString(func(c *Config) *string { return &c.Port })},
+	{"GRPC_PORT", "grpc_port", setString(func(c *Config) *string { return &c.GRPCPort })},
+	{"HOST", "host", setString(func(c *Config) *string { return &c.Host })},
+	{"ENABLE_CORS", "enable_cors", setBool(func(c *Config) *bool { return &c.EnableCORS })},
+	{"JWT_SECRET", "security.jwt_secret", setString(func(c *Config) *string { return &c.Security.JWTSecret })},
+	{"SECRETS_MASTER_KEY_FILE", "secrets.master_key_file", setString(func(c *Config) *string { return &c.Secrets.MasterKeyFile })},
+}
+
+func setString(field func(*Config) *string) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		*field(cfg) = value
+		return nil
+	}
+}
+
+func setInt(field func(*Config) *int) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		*field(cfg) = n
+		return nil
+	}
+}
+
+func setBool(field func(*Config) *bool) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		*field(cfg) = b
+		return nil
+	}
+}
+
+func setDuration(field func(*Config) *time.Duration) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration", value)
+		}
+		*field(cfg) = d
+		return nil
+	}
+}
+
+// LoadConfig builds a configuration from the defaults, then the YAML file at path (skipped when
+// path is empty), then environment variables. Callers apply command line flags on top and call
+// Validate on the result.
+func LoadConfig(path string) (*Config, error) {
+	cfg := DefaultConfig()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		if err := cfg.ApplyYAML(data); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.ApplyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ApplyYAML overrides the configuration with the fields set in a YAML document. Unknown fields are rejected.
+func (c *Config) ApplyYAML(data []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// ApplyEnv overrides the configuration with the environment variables returned by lookup
+func (c *Config) ApplyEnv(lookup func(string) (string, bool)) error {
+	configErr := &ConfigError{}
+	for _, binding := range envBindings {
+		value, ok := lookup(binding.name)
+		if !ok {
+			continue
+		}
+		if err := binding.set(c, value); err != nil {
+			configErr.add(binding.field, "%s: %v", binding.name, err)
+		}
+	}
+	return configErr.errOrNil()
+}
+
+// Validate checks the configuration and reports every invalid field
+func (c *Config) Validate() error {
+	configErr := &ConfigError{}
+
+	if c.OpenTofuPath == "" {
+		configErr.add("opentofu_path", "must not be empty")
+	}
+	if c.WorkingDirectory == "" {
+		configErr.add("working_directory", "must not be empty")
+	}
+	if c.Timeout <= 0 {
+		configErr.add("timeout", "must be positive")
+	}
+	if c.InterruptGracePeriod < 0 {
+		configErr.add("interrupt_grace_period", "must not be negative")
+	}
+	if c.PlanDirectory == "" {
+		configErr.add("plan_directory", "must not be empty")
+	}
+	if c.PlanMaxAge <= 0 {
+		configErr.add("plan_max_age", "must be positive")
+	}
+
+	// Reviewers name themselves until callers can be authenticated, so one caller could approve a
+	// plan under several names, or approve their own plan. Refuse approvals rather than offer a
+	// control any single caller can get around.
+	validateApprovalCount(configErr, "approvals.required_approvals", c.Approvals.RequiredApprovals)
+	for dir, required := range c.Approvals.WorkingDirectories {
+		validateApprovalCount(configErr, "approvals.working_directories."+dir, required)
+	}
+	if c.Approvals.Timeout < 0 {
+		configErr.add("approvals.timeout", "must not be negative")
+	}
+
+	if c.Jobs.Workers < 1 {
+		configErr.add("jobs.workers", "must be at least 1")
+	}
+	if c.Jobs.PollInterval <= 0 {
+		configErr.add("jobs.poll_interval", "must be positive")
+	}
+
+	if c.Locks.WaitTimeout < 0 {
+		configErr.add("locks.wait_timeout", "must not be negative")
+	}
+	if c.Locks.RetryInterval <= 0 {
+		configErr.add("locks.retry_interval", "must be positive")
+	}
+	if c.Locks.TTL <= 0 {
+		configErr.add("locks.ttl", "must be positive")
+	}
+
+	if c.Drift.Enabled {
+		if len(c.Drift.WorkingDirectories) == 0 {
+			configErr.add("drift.working_directories", "must list at least one working directory when drift detection is enabled")
+		}
+		validateSchedule(configErr, "drift.schedule", c.Drift.Schedule)
+		for i, target := range c.Drift.WorkingDirectories {
+			field := fmt.Sprintf("drift.working_directories[%d]", i)
+			if target.Path == "" {
+				configErr.add(field+".path", "must not be empty")
+			}
+			if target.Workspace != "" {
+				if ValidateWorkspaceName(target.Workspace) != nil {
+					configErr.add(field+".workspace", "must be a workspace name of letters, digits, '.', '_' and '-', got %q", target.Workspace)
+				}
+			}
+			if target.Schedule != "" {
+				validateSchedule(configErr, field+".schedule", target.Schedule)
+			}
+		}
+	}
+	if c.Drift.WebhookURL != "" {
+		if u, err := url.Parse(c.Drift.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			configErr.add("drift.webhook_url", "must be an http or https URL, got %q", c.Drift.WebhookURL)
+		}
+	}
+
+	switch c.Database.Driver {
+	case "sqlite":
+	case "postgres":
+		if c.Database.Host == "" {
+			configErr.add("database.host", "is required for postgres")
+		}
+		if c.Database.Database == "" {
+			configErr.add("database.database", "is required for postgres")
+		}
+		if c.Database.Port < 1 || c.Database.Port > 65535 {
+			configErr.add("database.port", "must be between 1 and 65535")
+		}
+	default:
+		configErr.add("database.driver", "must be sqlite or postgres, got %q", c.Database.Driver)
+	}
+
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		configErr.add("log_level", "must be one of debug, info, warn or error, got %q", c.LogLevel)
+	}
+
+	validatePort(configErr, "port", c.Port)
+	validatePort(configErr, "grpc_port", c.GRPCPort)
+	if c.Port != "" && c.Port == c.GRPCPort {
+		configErr.add("grpc_port", "must differ from port")
+	}
+
+	// Authentication, backups and metrics are not implemented, so enabling them is refused
+	// rather than silently leaving the station unprotected or unmonitored
+	if c.Security.EnableAuth {
+		configErr.add("security.enable_auth", "is not supported")
+	}
+
+	validateEnvironment(configErr, c.Environment)
+	validateRedaction(configErr, c.Redaction)
+
+	if c.Backup.Enable {
+		configErr.add("backup.enable", "is not supported")
+	}
+	if c.Monitoring.EnableMetrics {
+		configErr.add("monitoring.enable_metrics", "is not supported")
+	}
+	if c.Monitoring.HealthCheckInterval != 0 {
+		configErr.add("monitoring.health_check_interval", "is not supported")
+	}
+
+	return configErr.errOrNil()
+}
+
+// validateApprovalCount checks a number of required approvals, which must be zero until the
+// station can authenticate reviewers
+func validateApprovalCount(configErr *ConfigError, field string, required int) {
+	switch {
+	case required < 0:
+		configErr.add(field, "must not be negative")
+	case required > 0:
+		configErr.add(field, "requires authenticated reviewers, which is not supported yet")
+	}
+}
+
+// validateSchedule checks that a schedule is a valid cron expression
+func validateSchedule(configErr *ConfigError, field, schedule string) {
+	if _, err := ParseCronSchedule(schedule); err != nil {
+		configErr.add(field, "must be a cron expression: %v", err)
+	}
+}
+
+// validatePort checks that a port is a number between 1 and 65535
+func validatePort(configErr *ConfigError, field, port string) {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		configErr.add(field, "must be a port between 1 and 65535, got %q", port)
+	}
+}
@@ -0,0 +1,177 @@
+package TerraformStation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigExampleFile(t *testing.T) {
+	cfg, err := LoadConfig(filepath.Join("config", "config.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, 30*time.Minute, cfg.Timeout)
+	assert.Equal(t, time.Minute, cfg.InterruptGracePeriod)
+	assert.Equal(t, "opentofu_station", cfg.Database.Database)
+	assert.Empty(t, cfg.Security.AllowedOrigins)
+	assert.Equal(t, "us-west-2", cfg.Providers.AWS.Region)
+	assert.Equal(t, "us-central1", cfg.Providers.GCP.Region)
+	assert.Equal(t, "0 2 * * *", cfg.Backup.Schedule)
+	assert.True(t, cfg.Monitoring.EnableHealthCheck)
+	assert.Equal(t, DefaultEnvironmentAllowlist, cfg.Environment.Allowlist)
+	assert.Equal(t, []string{"aws"}, cfg.Environment.Providers)
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("timeout: 5m\nlog_level: warn\ndatabase:\n  host: db.internal\n"), 0600))
+
+	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("DB_PORT", "6543")
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+
+	// File values override defaults and environment variables override the file
+	assert.Equal(t, 5*time.Minute, cfg.Timeout)
+	assert.Equal(t, "debug", cfg.LogLevel)
+	assert.Equal(t, "db.internal", cfg.Database.Host)
+	assert.Equal(t, 6543, cfg.Database.Port)
+	assert.Equal(t, "disable", cfg.Database.SSLMode)
+}
+
+func TestLoadConfigInvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "bad duration", content: "timeout: soon\n"},
+		{name: "unknown field", content: "timout: 5m\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			_, err := LoadConfig(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestApplyEnvInvalidValues(t *testing.T) {
+	env := map[string]string{"DB_PORT": "five", "TIMEOUT": "10", "ENABLE_CORS": "maybe"}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	err := DefaultConfig().ApplyEnv(lookup)
+
+	var configErr *ConfigError
+	require.ErrorAs(t, err, &configErr)
+	assert.ElementsMatch(t, []string{"database.port", "timeout", "enable_cors"}, fieldNames(configErr))
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, DefaultConfig().Validate())
+
+	cfg := DefaultConfig()
+	cfg.Timeout = 0
+	cfg.InterruptGracePeriod = -time.Second
+	cfg.Database.Driver = "postgres"
+	cfg.Database.Database = ""
+	cfg.LogLevel = "verbose"
+	cfg.GRPCPort = cfg.Port
+
+	err := cfg.Validate()
+
+	var configErr *ConfigError
+	require.ErrorAs(t, err, &configErr)
+	assert.ElementsMatch(t, []string{"timeout", "interrupt_grace_period", "database.database", "log_level", "grpc_port"}, fieldNames(configErr))
+}
+
+func TestValidateUnsupported(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Security.EnableAuth = true
+	cfg.Security.JWTSecret = "secret"
+	cfg.Backup.Enable = true
+	cfg.Monitoring.EnableMetrics = true
+	cfg.Monitoring.HealthCheckInterval = 30 * time.Second
+	cfg.Approvals.RequiredApprovals = 1
+	cfg.Approvals.WorkingDirectories = map[string]int{"./tofu/production": 2, "./tofu/sandbox": 0}
+
+	err := cfg.Validate()
+
+	var configErr *ConfigError
+	require.ErrorAs(t, err, &configErr)
+	assert.ElementsMatch(t, []string{"security.enable_auth", "backup.enable", "monitoring.enable_metrics", "monitoring.health_check_interval",
+		"approvals.required_approvals", "approvals.working_directories../tofu/production"}, fieldNames(configErr))
+}
+
+func TestValidateDrift(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Drift.Enabled = true
+	cfg.Drift.Schedule = "every hour"
+	cfg.Drift.WorkingDirectories = []DriftTarget{{Path: "/srv/network"}, {Path: "", Schedule: "0 25 * * *"}}
+	cfg.Drift.WebhookURL = "ftp://hooks.internal/drift"
+
+	err := cfg.Validate()
+
+	var configErr *ConfigError
+	require.ErrorAs(t, err, &configErr)
+	assert.ElementsMatch(t, []string{"drift.schedule", "drift.working_directories[1].path",
+		"drift.working_directories[1].schedule", "drift.webhook_url"}, fieldNames(configErr))
+
+	cfg.Drift.Schedule = "@daily"
+	cfg.Drift.WorkingDirectories = []DriftTarget{{Path: "/srv/network", Schedule: "*/30 * * * *"}}
+	cfg.Drift.WebhookURL = "https://hooks.internal/drift"
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, "*/30 * * * *", cfg.Drift.ScheduleFor(cfg.Drift.WorkingDirectories[0]))
+	assert.Equal(t, "@daily", cfg.Drift.ScheduleFor(DriftTarget{Path: "/srv/dns"}))
+}
+
+func TestValidateEnvironment(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Environment.Allowlist = []string{"PATH", "LC_*", "*", "", "BAD-NAME", "TF_WORKSPACE"}
+	cfg.Environment.Env = map[string]string{"TF_WORKSPACE": "prod", "1BAD": "x"}
+	cfg.Environment.Providers = []string{"aws", "digitalocean"}
+	cfg.Environment.Scopes = []EnvironmentScope{
+		{WorkingDirectory: "/srv/network", Workspace: "eu west", EnvironmentVariables: EnvironmentVariables{TFVars: map[string]string{"bad var": "1"}}},
+		{Workspace: "prod"},
+	}
+
+	err := cfg.Validate()
+
+	var configErr *ConfigError
+	require.ErrorAs(t, err, &configErr)
+	assert.ElementsMatch(t, []string{"environment.allowlist[3]", "environment.allowlist[4]", "environment.allowlist[5]", "environment.env.1BAD",
+		"environment.env.TF_WORKSPACE", "environment.providers[1]", "environment.scopes[0].workspace",
+		"environment.scopes[0].tf_vars.bad var", "environment.scopes[1].working_directory"}, fieldNames(configErr))
+}
+
+func TestValidateRedaction(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Redaction.Patterns = []string{`token=(\w+)`, `(unclosed`, `x*`}
+
+	err := cfg.Validate()
+
+	var configErr *ConfigError
+	require.ErrorAs(t, err, &configErr)
+	assert.ElementsMatch(t, []string{"redaction.patterns[1]", "redaction.patterns[2]"}, fieldNames(configErr))
+}
+
+// fieldNames returns the names of the invalid fields in a ConfigError
+func fieldNames(configErr *ConfigError) []string {
+	var names []string
+	for _, fieldErr := range configErr.Errors {
+		names = append(names, fieldErr.Field)
+	}
+	return names
+}
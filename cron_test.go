@@ -0,0 +1,49 @@
+package TerraformStation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// Saturday 15 March 2025, 10:07
+	from := time.Date(2025, 3, 15, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2025, 3, 15, 10, 8, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2025, 3, 15, 10, 15, 0, 0, time.UTC)},
+		{expr: "0 2 * * *", want: time.Date(2025, 3, 16, 2, 0, 0, 0, time.UTC)},
+		{expr: "30 9-17 * * 1-5", want: time.Date(2025, 3, 17, 9, 30, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", want: time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1,20 * *", want: time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)},
+		// With both day fields restricted, either one matching is enough
+		{expr: "0 0 1 * 1", want: time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", want: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+
+	never, err := ParseCronSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, never.Next(from).IsZero())
+}
+
+func TestParseCronScheduleInvalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@often"} {
+		_, err := ParseCronSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+}
@@ -1,13 +1,15 @@
 package TerraformStation
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"gorm.io/gorm/clause"
 )
 
 // DatabaseManager handles database operations
@@ -34,7 +36,16 @@ func NewDatabaseManager(cfg *Config) (*DatabaseManager, error) {
 	}
 
 	// Configure GORM
-	db.Logger = db.Logger.LogMode(logger.Info)
+	db.Logger = newGormLogger(cfg)
+
+	return NewDatabaseManagerWithDB(db)
+}
+
+// NewDatabaseManagerWithDB creates a database manager around an existing connection
+func NewDatabaseManagerWithDB(db *gorm.DB) (*DatabaseManager, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection cannot be nil")
+	}
 
 	// Auto migrate models
 	if err := autoMigrate(db); err != nil {
@@ -80,21 +91,30 @@ func connectSQLite(cfg DatabaseConfig) (*gorm.DB, error) {
 
 // autoMigrate automatically migrates the database schema
 func autoMigrate(db *gorm.DB) error {
-	log.Println("Running database migrations...")
+	slog.Info("Running database migrations...")
 
 	// Migrate all models
 	err := db.AutoMigrate(
 		&TerraformOperation{},
 		&TerraformPlan{},
+		&TerraformPlanApproval{},
 		&TerraformApply{},
 		&TerraformState{},
+		&TerraformOutputChunk{},
+		&TerraformOperationEvent{},
+		&TerraformJob{},
+		&TerraformLock{},
+		&TerraformStateVersion{},
+		&TerraformStateLock{},
+		&TerraformDriftReport{},
+		&TerraformSecret{},
 	)
 
 	if err != nil {
 		return err
 	}
 
-	log.Println("Database migrations completed successfully")
+	slog.Info("Database migrations completed successfully")
 	return nil
 }
 
@@ -146,6 +166,53 @@ func (dm *DatabaseManager) CreatePlan(plan *TerraformPlan) error {
 	return dm.db.Create(plan).Error
 }
 
+// GetPlanByPlanID retrieves a plan by its plan ID
+func (dm *DatabaseManager) GetPlanByPlanID(planID string) (*TerraformPlan, error) {
+	var plan TerraformPlan
+	err := dm.db.Where("plan_id = ?", planID).First(&plan).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// MarkPlanApplied records that a plan has been applied
+func (dm *DatabaseManager) MarkPlanApplied(planID string, appliedAt time.Time) error {
+	return dm.db.Model(&TerraformPlan{}).
+		Where("plan_id = ?", planID).
+		Updates(map[string]interface{}{"status": PlanStatusApplied, "applied_at": appliedAt}).Error
+}
+
+// TransitionPlanStatus atomically moves a plan from one status to another,
+// reporting whether the plan was in the expected status
+func (dm *DatabaseManager) TransitionPlanStatus(planID, from, to string) (bool, error) {
+	result := dm.db.Model(&TerraformPlan{}).
+		Where("plan_id = ? AND status = ?", planID, from).
+		Update("status", to)
+	return result.RowsAffected == 1, result.Error
+}
+
+// CreatePlanApproval records a review decision on a plan
+func (dm *DatabaseManager) CreatePlanApproval(approval *TerraformPlanApproval) error {
+	return dm.db.Create(approval).Error
+}
+
+// ListPlanApprovals retrieves the review decisions on a plan in the order they were made
+func (dm *DatabaseManager) ListPlanApprovals(planID string) ([]TerraformPlanApproval, error) {
+	var approvals []TerraformPlanApproval
+	err := dm.db.Where("plan_id = ?", planID).Order("created_at ASC, id ASC").Find(&approvals).Error
+	return approvals, err
+}
+
+// CountStateWritesSince counts operations that wrote the state of a workspace, started after a point in time
+func (dm *DatabaseManager) CountStateWritesSince(workingDir, workspace string, since time.Time) (int64, error) {
+	var count int64
+	err := dm.db.Model(&TerraformOperation{}).
+		Where("working_dir = ? AND workspace = ? AND writes_state = ? AND started_at > ?", workingDir, workspace, true, since).
+		Count(&count).Error
+	return count, err
+}
+
 // CreateApply creates a new Terraform apply record
 func (dm *DatabaseManager) CreateApply(apply *TerraformApply) error {
 	return dm.db.Create(apply).Error
@@ -155,3 +222,380 @@ func (dm *DatabaseManager) CreateApply(apply *TerraformApply) error {
 func (dm *DatabaseManager) CreateState(state *TerraformState) error {
 	return dm.db.Create(state).Error
 }
+
+// CreateOutputChunk stores a line of operation output
+func (dm *DatabaseManager) CreateOutputChunk(chunk *TerraformOutputChunk) error {
+	return dm.db.Create(chunk).Error
+}
+
+// ListOutputChunks retrieves the output of an operation after the given sequence number
+func (dm *DatabaseManager) ListOutputChunks(commandID string, afterSequence int64) ([]TerraformOutputChunk, error) {
+	var chunks []TerraformOutputChunk
+	err := dm.db.Where("command_id = ? AND sequence > ?", commandID, afterSequence).
+		Order("sequence ASC").Find(&chunks).Error
+	return chunks, err
+}
+
+// CreateOperationEvent stores a machine-readable UI message of an operation
+func (dm *DatabaseManager) CreateOperationEvent(event *TerraformOperationEvent) error {
+	return dm.db.Create(event).Error
+}
+
+// ListOperationEvents retrieves the UI messages of an operation after the given sequence number
+func (dm *DatabaseManager) ListOperationEvents(commandID string, afterSequence int64) ([]TerraformOperationEvent, error) {
+	var events []TerraformOperationEvent
+	err := dm.db.Where("command_id = ? AND sequence > ?", commandID, afterSequence).
+		Order("sequence ASC").Find(&events).Error
+	return events, err
+}
+
+// CreateJob queues a new job
+func (dm *DatabaseManager) CreateJob(job *TerraformJob) error {
+	return dm.db.Create(job).Error
+}
+
+// UpdateJob updates an existing job
+func (dm *DatabaseManager) UpdateJob(job *TerraformJob) error {
+	return dm.db.Save(job).Error
+}
+
+// GetJobByJobID retrieves a job by its job ID
+func (dm *DatabaseManager) GetJobByJobID(jobID string) (*TerraformJob, error) {
+	var job TerraformJob
+	err := dm.db.Where("job_id = ?", jobID).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListJobs retrieves jobs, newest first, with optional filtering by status
+func (dm *DatabaseManager) ListJobs(limit, offset int, status string) ([]TerraformJob, error) {
+	var jobs []TerraformJob
+	query := dm.db
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&jobs).Error
+	return jobs, err
+}
+
+// ClaimNextJob marks the oldest queued job as running by owner and returns it, or nil if the queue is empty
+func (dm *DatabaseManager) ClaimNextJob(owner string, startedAt time.Time) (*TerraformJob, error) {
+	for {
+		var job TerraformJob
+		err := dm.db.Where("status = ?", JobStatusQueued).Order("created_at ASC, id ASC").First(&job).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Another worker may claim the same job first, in which case try the next one
+		result := dm.db.Model(&TerraformJob{}).
+			Where("job_id = ? AND status = ?", job.JobID, JobStatusQueued).
+			Updates(map[string]interface{}{"status": JobStatusRunning, "owner": owner, "started_at": startedAt, "heartbeat_at": startedAt})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			job.Status = JobStatusRunning
+			job.Owner = owner
+			job.StartedAt = &startedAt
+			job.HeartbeatAt = &startedAt
+			return &job, nil
+		}
+	}
+}
+
+// TransitionJobStatus atomically moves a job from one status to another,
+// reporting whether the job was in the expected status
+func (dm *DatabaseManager) TransitionJobStatus(jobID, from, to string) (bool, error) {
+	result := dm.db.Model(&TerraformJob{}).
+		Where("job_id = ? AND status = ?", jobID, from).
+		Update("status", to)
+	return result.RowsAffected == 1, result.Error
+}
+
+// SetJobCommandID links a job to the operation it runs
+func (dm *DatabaseManager) SetJobCommandID(jobID, commandID string) error {
+	return dm.db.Model(&TerraformJob{}).Where("job_id = ?", jobID).Update("command_id", commandID).Error
+}
+
+// HeartbeatJob records that the owner of a running job is still running it
+func (dm *DatabaseManager) HeartbeatJob(jobID, owner string, at time.Time) error {
+	return dm.db.Model(&TerraformJob{}).
+		Where("job_id = ? AND owner = ? AND status = ?", jobID, owner, JobStatusRunning).
+		Update("heartbeat_at", at).Error
+}
+
+// FailStaleJobs marks running jobs without a heartbeat since staleBefore as failed, returning how many there were
+func (dm *DatabaseManager) FailStaleJobs(message string, staleBefore, completedAt time.Time) (int64, error) {
+	result := dm.db.Model(&TerraformJob{}).
+		Where("status = ? AND (heartbeat_at IS NULL OR heartbeat_at < ?)", JobStatusRunning, staleBefore).
+		Updates(map[string]interface{}{"status": JobStatusFailed, "error_message": message, "completed_at": completedAt})
+	return result.RowsAffected, result.Error
+}
+
+// HeartbeatOperation refreshes the lease of an operation its owner is still running
+func (dm *DatabaseManager) HeartbeatOperation(commandID, owner string, at time.Time) error {
+	return dm.db.Model(&TerraformOperation{}).
+		Where("command_id = ? AND owner = ? AND status = ?", commandID, owner, OperationStatusRunning).
+		Update("heartbeat_at", at).Error
+}
+
+// FailStaleOperations marks running operations without a heartbeat since staleBefore as failed, returning how many there were
+func (dm *DatabaseManager) FailStaleOperations(message string, staleBefore, completedAt time.Time) (int64, error) {
+	result := dm.db.Model(&TerraformOperation{}).
+		Where("status = ? AND (heartbeat_at IS NULL OR heartbeat_at < ?)", OperationStatusRunning, staleBefore).
+		Updates(map[string]interface{}{"status": OperationStatusFailed, "error_message": message, "completed_at": completedAt})
+	return result.RowsAffected, result.Error
+}
+
+// CreateLock inserts a lock unless its working directory and workspace are already locked,
+// reporting whether the lock was taken
+func (dm *DatabaseManager) CreateLock(lock *TerraformLock) (bool, error) {
+	result := dm.db.Clauses(clause.OnConflict{DoNothing: true}).Create(lock)
+	return result.RowsAffected == 1, result.Error
+}
+
+// GetLock retrieves the lock held on a working directory and workspace
+func (dm *DatabaseManager) GetLock(workingDir, workspace string) (*TerraformLock, error) {
+	var lock TerraformLock
+	err := dm.db.Where("working_dir = ? AND workspace = ?", workingDir, workspace).First(&lock).Error
+	if err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// GetLockByLockID retrieves a lock by its lock ID
+func (dm *DatabaseManager) GetLockByLockID(lockID string) (*TerraformLock, error) {
+	var lock TerraformLock
+	err := dm.db.Where("lock_id = ?", lockID).First(&lock).Error
+	if err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// ListLocks retrieves the locks currently held, optionally only those on a working directory
+func (dm *DatabaseManager) ListLocks(workingDir string) ([]TerraformLock, error) {
+	var locks []TerraformLock
+	query := dm.db
+
+	if workingDir != "" {
+		query = query.Where("working_dir = ?", workingDir)
+	}
+
+	err := query.Order("acquired_at ASC, id ASC").Find(&locks).Error
+	return locks, err
+}
+
+// HeartbeatLock records that the holder of a lock is still running, reporting whether the lock is still held
+func (dm *DatabaseManager) HeartbeatLock(lockID string, at time.Time) (bool, error) {
+	result := dm.db.Model(&TerraformLock{}).Where("lock_id = ?", lockID).Update("heartbeat_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
+// DeleteLock releases a lock, reporting whether it was still held
+func (dm *DatabaseManager) DeleteLock(lockID string) (bool, error) {
+	result := dm.db.Where("lock_id = ?", lockID).Delete(&TerraformLock{})
+	return result.RowsAffected == 1, result.Error
+}
+
+// DeleteStaleLock releases a lock whose holder stopped sending heartbeats before staleBefore,
+// reporting whether it was removed
+func (dm *DatabaseManager) DeleteStaleLock(lockID string, staleBefore time.Time) (bool, error) {
+	result := dm.db.Where("lock_id = ? AND heartbeat_at < ?", lockID, staleBefore).Delete(&TerraformLock{})
+	return result.RowsAffected == 1, result.Error
+}
+
+// GetLatestStateVersion retrieves the newest version of a state stored through the http backend
+func (dm *DatabaseManager) GetLatestStateVersion(name string) (*TerraformStateVersion, error) {
+	var version TerraformStateVersion
+	err := dm.db.Where("name = ?", name).Order("version DESC").First(&version).Error
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// GetStateVersion retrieves a single version of a state
+func (dm *DatabaseManager) GetStateVersion(name string, version int) (*TerraformStateVersion, error) {
+	var stateVersion TerraformStateVersion
+	err := dm.db.Where("name = ? AND version = ?", name, version).First(&stateVersion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stateVersion, nil
+}
+
+// ListStateVersions retrieves the versions of a state, newest first, without their state data
+func (dm *DatabaseManager) ListStateVersions(name string, limit, offset int) ([]TerraformStateVersion, error) {
+	var versions []TerraformStateVersion
+	err := dm.db.Omit("state_data").
+		Where("name = ?", name).
+		Order("version DESC").
+		Limit(limit).Offset(offset).
+		Find(&versions).Error
+	return versions, err
+}
+
+// CountStateVersionsSince counts the versions of a state written by an operation after a point in time
+func (dm *DatabaseManager) CountStateVersionsSince(name, operation string, since time.Time) (int64, error) {
+	var count int64
+	err := dm.db.Model(&TerraformStateVersion{}).
+		Where("name = ? AND operation = ? AND created_at > ?", name, operation, since).
+		Count(&count).Error
+	return count, err
+}
+
+// stateVersionAttempts is how often CreateStateVersion tries to store a version before giving up
+// when concurrent writers keep taking the next version number
+const stateVersionAttempts = 5
+
+// CreateStateVersion stores a state as the next version of its name. Numbering continues
+// past deleted versions, so a version number always refers to the same data. Two writers can
+// pick the same number; the unique index on name and version rejects the second, which then
+// retries with the next number.
+func (dm *DatabaseManager) CreateStateVersion(version *TerraformStateVersion) error {
+	for attempt := 1; ; attempt++ {
+		err := dm.db.Transaction(func(tx *gorm.DB) error {
+			var latest int
+			err := tx.Unscoped().Model(&TerraformStateVersion{}).
+				Where("name = ?", version.Name).
+				Select("COALESCE(MAX(version), 0)").
+				Scan(&latest).Error
+			if err != nil {
+				return err
+			}
+			version.Version = latest + 1
+			return tx.Create(version).Error
+		})
+		if err == nil || attempt == stateVersionAttempts {
+			return err
+		}
+		if taken, takenErr := dm.stateVersionTaken(version.Name, version.Version); takenErr != nil || !taken {
+			return err
+		}
+	}
+}
+
+// stateVersionTaken reports whether a version number of a state is in use, including by a deleted version
+func (dm *DatabaseManager) stateVersionTaken(name string, version int) (bool, error) {
+	var count int64
+	err := dm.db.Unscoped().Model(&TerraformStateVersion{}).
+		Where("name = ? AND version = ?", name, version).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// DeleteStateVersions removes every version of a state, reporting how many were removed
+func (dm *DatabaseManager) DeleteStateVersions(name string) (int64, error) {
+	result := dm.db.Where("name = ?", name).Delete(&TerraformStateVersion{})
+	return result.RowsAffected, result.Error
+}
+
+// CreateStateLock inserts a state lock unless the state is already locked, reporting whether the lock was taken
+func (dm *DatabaseManager) CreateStateLock(lock *TerraformStateLock) (bool, error) {
+	result := dm.db.Clauses(clause.OnConflict{DoNothing: true}).Create(lock)
+	return result.RowsAffected == 1, result.Error
+}
+
+// GetStateLock retrieves the lock held on a state
+func (dm *DatabaseManager) GetStateLock(name string) (*TerraformStateLock, error) {
+	var lock TerraformStateLock
+	err := dm.db.Where("name = ?", name).First(&lock).Error
+	if err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// DeleteStateLock releases the lock on a state if it is still held under lockID, reporting whether it was removed
+func (dm *DatabaseManager) DeleteStateLock(name, lockID string) (bool, error) {
+	result := dm.db.Where("name = ? AND lock_id = ?", name, lockID).Delete(&TerraformStateLock{})
+	return result.RowsAffected == 1, result.Error
+}
+
+// CreateDriftReport stores the outcome of a drift check
+func (dm *DatabaseManager) CreateDriftReport(report *TerraformDriftReport) error {
+	return dm.db.Create(report).Error
+}
+
+// GetDriftReportByReportID retrieves a drift report by its report ID
+func (dm *DatabaseManager) GetDriftReportByReportID(reportID string) (*TerraformDriftReport, error) {
+	var report TerraformDriftReport
+	err := dm.db.Where("report_id = ?", reportID).First(&report).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetLatestDriftOutcome retrieves the newest drift report of a working directory that completed its check,
+// skipping failed checks that say nothing about drift
+func (dm *DatabaseManager) GetLatestDriftOutcome(workingDir, workspace string) (*TerraformDriftReport, error) {
+	var report TerraformDriftReport
+	err := dm.db.Where("working_dir = ? AND workspace = ? AND status IN ?", workingDir, workspace, []string{DriftStatusClean, DriftStatusDrifted}).
+		Order("created_at DESC, id DESC").
+		First(&report).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ListDriftReports retrieves drift reports, newest first, with optional filtering by working directory, workspace and status
+func (dm *DatabaseManager) ListDriftReports(limit, offset int, workingDir, workspace, status string) ([]TerraformDriftReport, error) {
+	var reports []TerraformDriftReport
+	query := dm.db
+
+	if workingDir != "" {
+		query = query.Where("working_dir = ?", workingDir)
+	}
+	if workspace != "" {
+		query = query.Where("workspace = ?", workspace)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&reports).Error
+	return reports, err
+}
+
+// PutSecret stores a secret, replacing the value of any secret with the same name
+func (dm *DatabaseManager) PutSecret(secret *TerraformSecret) error {
+	return dm.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"ciphertext", "key_id", "updated_at"}),
+	}).Create(secret).Error
+}
+
+// GetSecret retrieves a secret by its name
+func (dm *DatabaseManager) GetSecret(name string) (*TerraformSecret, error) {
+	var secret TerraformSecret
+	err := dm.db.Where("name = ?", name).First(&secret).Error
+	if err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+// ListSecrets retrieves every secret, ordered by name
+func (dm *DatabaseManager) ListSecrets() ([]TerraformSecret, error) {
+	var secrets []TerraformSecret
+	err := dm.db.Order("name ASC").Find(&secrets).Error
+	return secrets, err
+}
+
+// DeleteSecret removes a secret, reporting whether it existed
+func (dm *DatabaseManager) DeleteSecret(name string) (bool, error) {
+	result := dm.db.Where("name = ?", name).Delete(&TerraformSecret{})
+	return result.RowsAffected == 1, result.Error
+}
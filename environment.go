@@ -0,0 +1,216 @@
+package TerraformStation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultEnvironmentAllowlist names the variables of the station's own environment that OpenTofu
+// commands inherit unless configured otherwise
+var DefaultEnvironmentAllowlist = []string{
+	"PATH", "HOME", "USER", "TMPDIR", "LANG", "LC_*", "TZ",
+	"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy",
+	"SSL_CERT_FILE", "SSL_CERT_DIR", "TF_PLUGIN_CACHE_DIR",
+}
+
+// EnvironmentConfig controls the environment OpenTofu commands run with. Commands inherit only the
+// variables of the station's environment named in the allowlist. The base variables, then those of
+// every scope matching the working directory and workspace of the command, are set on top.
+type EnvironmentConfig struct {
+	// Allowlist names the inherited variables. An entry ending in * matches every variable starting with the rest of it.
+	Allowlist            []string `json:"allowlist" yaml:"allowlist"`
+	EnvironmentVariables `yaml:",inline"`
+	Scopes               []EnvironmentScope `json:"scopes" yaml:"scopes"`
+}
+
+// EnvironmentVariables are the variables set for the commands of a scope
+type EnvironmentVariables struct {
+	// Env holds plain environment variables
+	Env map[string]string `json:"env" yaml:"env"`
+	// TFVars sets input variables through TF_VAR_<name>
+	TFVars map[string]string `json:"tf_vars" yaml:"tf_vars"`
+	// CLIArgs is passed in TF_CLI_ARGS, which OpenTofu adds to the arguments of every command
+	CLIArgs string `json:"cli_args" yaml:"cli_args"`
+	// Providers names the entries of the providers section whose settings and credentials are passed on
+	Providers []string `json:"providers" yaml:"providers"`
+}
+
+// EnvironmentScope sets variables for the commands run in a working directory, or in one of its workspaces
+type EnvironmentScope struct {
+	WorkingDirectory string `json:"working_directory" yaml:"working_directory"`
+	// Workspace limits the scope to one workspace; empty matches every workspace
+	Workspace            string `json:"workspace" yaml:"workspace"`
+	EnvironmentVariables `yaml:",inline"`
+}
+
+// Provider names accepted in the providers list of an environment
+const (
+	ProviderAWS   = "aws"
+	ProviderAzure = "azure"
+	ProviderGCP   = "gcp"
+)
+
+var (
+	envNamePattern   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	tfVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+)
+
+// CommandEnv returns the KEY=value pairs set for commands run in a working directory and workspace:
+// the base variables, then those of the scopes of the whole directory, then those of scopes for the
+// workspace alone, so that more specific scopes win
+func (c *Config) CommandEnv(workingDir, workspace string) []string {
+	env := c.Providers.env(c.Environment.EnvironmentVariables)
+
+	var directory, workspaceScopes []EnvironmentScope
+	for _, scope := range c.Environment.Scopes {
+		if canonical, err := CanonicalWorkingDirectory(scope.WorkingDirectory); err != nil || canonical != workingDir {
+			continue
+		}
+		switch scope.Workspace {
+		case "":
+			directory = append(directory, scope)
+		case workspace:
+			workspaceScopes = append(workspaceScopes, scope)
+		}
+	}
+	for _, scope := range append(directory, workspaceScopes...) {
+		env = append(env, c.Providers.env(scope.EnvironmentVariables)...)
+	}
+	return env
+}
+
+// env returns the KEY=value pairs of a set of variables. Provider settings come first so that
+// plain variables can override them.
+func (p ProvidersConfig) env(v EnvironmentVariables) []string {
+	var env []string
+	for _, provider := range v.Providers {
+		env = append(env, p.providerEnv(provider)...)
+	}
+	for _, name := range sortedKeys(v.Env) {
+		env = append(env, name+"="+v.Env[name])
+	}
+	for _, name := range sortedKeys(v.TFVars) {
+		env = append(env, "TF_VAR_"+name+"="+v.TFVars[name])
+	}
+	if v.CLIArgs != "" {
+		env = append(env, "TF_CLI_ARGS="+v.CLIArgs)
+	}
+	return env
+}
+
+// providerEnv returns the variables through which a provider reads the configured settings and credentials
+func (p ProvidersConfig) providerEnv(provider string) []string {
+	var settings [][2]string
+	switch provider {
+	case ProviderAWS:
+		settings = [][2]string{
+			{"AWS_REGION", p.AWS.Region},
+			{"AWS_PROFILE", p.AWS.Profile},
+			{"AWS_ACCESS_KEY_ID", p.AWS.AccessKeyID},
+			{"AWS_SECRET_ACCESS_KEY", p.AWS.SecretAccessKey},
+			{"AWS_SESSION_TOKEN", p.AWS.SessionToken},
+		}
+	case ProviderAzure:
+		settings = [][2]string{
+			{"ARM_SUBSCRIPTION_ID", p.Azure.SubscriptionID},
+			{"ARM_TENANT_ID", p.Azure.TenantID},
+			{"ARM_CLIENT_ID", p.Azure.ClientID},
+			{"ARM_CLIENT_SECRET", p.Azure.ClientSecret},
+		}
+	case ProviderGCP:
+		settings = [][2]string{
+			{"GOOGLE_PROJECT", p.GCP.ProjectID},
+			{"GOOGLE_REGION", p.GCP.Region},
+			{"GOOGLE_CREDENTIALS", p.GCP.Credentials},
+		}
+	}
+
+	var env []string
+	for _, setting := range settings {
+		if setting[1] != "" {
+			env = append(env, setting[0]+"="+setting[1])
+		}
+	}
+	return env
+}
+
+// SecretValues returns the configured provider credentials, which are masked in command output.
+// GCP credentials are only a secret when given as the contents of a key file rather than its path.
+func (p ProvidersConfig) SecretValues() []string {
+	values := []string{p.AWS.SecretAccessKey, p.AWS.SessionToken, p.Azure.ClientSecret}
+	if strings.HasPrefix(strings.TrimSpace(p.GCP.Credentials), "{") {
+		values = append(values, p.GCP.Credentials)
+	}
+	return values
+}
+
+// inheritedEnv returns the KEY=value pairs of environ whose names the allowlist matches. TF_WORKSPACE
+// is never inherited, since it is set from the workspace of each command.
+func inheritedEnv(environ, allowlist []string) []string {
+	var env []string
+	for _, kv := range environ {
+		name, _, _ := strings.Cut(kv, "=")
+		if name == "TF_WORKSPACE" {
+			continue
+		}
+		for _, allowed := range allowlist {
+			prefix, wildcard := strings.CutSuffix(allowed, "*")
+			if name == allowed || (wildcard && strings.HasPrefix(name, prefix)) {
+				env = append(env, kv)
+				break
+			}
+		}
+	}
+	return env
+}
+
+// validateEnvironment checks the allowlist, base variables and scopes of an environment
+func validateEnvironment(configErr *ConfigError, c EnvironmentConfig) {
+	for i, allowed := range c.Allowlist {
+		// A lone * inherits the whole environment
+		field := fmt.Sprintf("environment.allowlist[%d]", i)
+		switch name := strings.TrimSuffix(allowed, "*"); {
+		case allowed == "" || (name != "" && !envNamePattern.MatchString(name)):
+			configErr.add(field, "must be a variable name, optionally ending in *, got %q", allowed)
+		case allowed == "TF_WORKSPACE":
+			configErr.add(field, "is set from the workspace of each command")
+		}
+	}
+	validateEnvironmentVariables(configErr, "environment", c.EnvironmentVariables)
+
+	for i, scope := range c.Scopes {
+		field := fmt.Sprintf("environment.scopes[%d]", i)
+		if scope.WorkingDirectory == "" {
+			configErr.add(field+".working_directory", "must not be empty")
+		}
+		if scope.Workspace != "" && ValidateWorkspaceName(scope.Workspace) != nil {
+			configErr.add(field+".workspace", "must be a workspace name of letters, digits, '.', '_' and '-', got %q", scope.Workspace)
+		}
+		validateEnvironmentVariables(configErr, field, scope.EnvironmentVariables)
+	}
+}
+
+// validateEnvironmentVariables checks the names of the variables set for a scope
+func validateEnvironmentVariables(configErr *ConfigError, field string, v EnvironmentVariables) {
+	for _, name := range sortedKeys(v.Env) {
+		switch {
+		case !envNamePattern.MatchString(name):
+			configErr.add(field+".env."+name, "must be a variable name of letters, digits and '_'")
+		case name == "TF_WORKSPACE":
+			configErr.add(field+".env."+name, "is set from the workspace of each command")
+		}
+	}
+	for _, name := range sortedKeys(v.TFVars) {
+		if !tfVarNamePattern.MatchString(name) {
+			configErr.add(field+".tf_vars."+name, "must be an input variable name")
+		}
+	}
+	for i, provider := range v.Providers {
+		switch provider {
+		case ProviderAWS, ProviderAzure, ProviderGCP:
+		default:
+			configErr.add(fmt.Sprintf("%s.providers[%d]", field, i), "must be one of aws, azure or gcp, got %q", provider)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package TerraformStation
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandEnv(t *testing.T) {
+	production, err := CanonicalWorkingDirectory(t.TempDir())
+	require.NoError(t, err)
+
+	cfg := DefaultConfig()
+	cfg.Providers.AWS = AWSProviderConfig{Region: "us-west-2", Profile: "default", AccessKeyID: "AKIA123"}
+	cfg.Providers.GCP = GCPProviderConfig{ProjectID: "network", Credentials: "/etc/gcp.json"}
+	cfg.Environment.Providers = []string{ProviderAWS}
+	cfg.Environment.Env = map[string]string{"TF_IN_AUTOMATION": "1"}
+	cfg.Environment.TFVars = map[string]string{"region": "us-west-2"}
+	cfg.Environment.Scopes = []EnvironmentScope{
+		{
+			WorkingDirectory:     production,
+			Workspace:            "eu",
+			EnvironmentVariables: EnvironmentVariables{TFVars: map[string]string{"region": "eu-west-1"}},
+		},
+		{
+			WorkingDirectory: production,
+			EnvironmentVariables: EnvironmentVariables{
+				Env:       map[string]string{"AWS_PROFILE": "production"},
+				TFVars:    map[string]string{"region": "us-east-1"},
+				CLIArgs:   "-parallelism=4",
+				Providers: []string{ProviderGCP},
+			},
+		},
+		{WorkingDirectory: filepath.Join(production, "other"), EnvironmentVariables: EnvironmentVariables{Env: map[string]string{"OTHER": "1"}}},
+	}
+
+	base := []string{"AWS_REGION=us-west-2", "AWS_PROFILE=default", "AWS_ACCESS_KEY_ID=AKIA123", "TF_IN_AUTOMATION=1", "TF_VAR_region=us-west-2"}
+	assert.Equal(t, base, cfg.CommandEnv(filepath.Join(production, "staging"), "default"))
+
+	// Scopes of the whole directory come after the base variables, and scopes of the workspace last
+	directory := append(append([]string(nil), base...), "GOOGLE_PROJECT=network", "GOOGLE_CREDENTIALS=/etc/gcp.json",
+		"AWS_PROFILE=production", "TF_VAR_region=us-east-1", "TF_CLI_ARGS=-parallelism=4")
+	assert.Equal(t, directory, cfg.CommandEnv(production, "default"))
+	assert.Equal(t, append(directory, "TF_VAR_region=eu-west-1"), cfg.CommandEnv(production, "eu"))
+}
+
+func TestInheritedEnv(t *testing.T) {
+	environ := []string{"PATH=/usr/bin", "LC_ALL=C", "AWS_SECRET_ACCESS_KEY=secret", "DB_PASSWORD=hunter2", "PATHEXT=.exe", "TF_WORKSPACE=prod"}
+
+	assert.Equal(t, []string{"PATH=/usr/bin", "LC_ALL=C"}, inheritedEnv(environ, DefaultEnvironmentAllowlist))
+	// TF_WORKSPACE is set from the workspace of each command, even when everything else is inherited
+	assert.Equal(t, environ[:5], inheritedEnv(environ, []string{"*"}))
+	assert.Empty(t, inheritedEnv(environ, nil))
+}
@@ -28,6 +28,8 @@ const (
 	ErrCodeWorkingDirError  = "WORKING_DIR_ERROR"
 	ErrCodeTerraformNotFound = "TERRAFORM_NOT_FOUND"
 	ErrCodePermissionDenied = "PERMISSION_DENIED"
+	ErrCodeNotFound         = "NOT_FOUND"
+	ErrCodeLocked           = "LOCKED"
 )
 
 // Error constructors
@@ -78,3 +80,27 @@ func NewPermissionDeniedError(message string, details ...string) *TerraformError
 		Details: strings.Join(details, "; "),
 	}
 }
+
+func NewInvalidStateError(message string, details ...string) *TerraformError {
+	return &TerraformError{
+		Code:    ErrCodeInvalidState,
+		Message: message,
+		Details: strings.Join(details, "; "),
+	}
+}
+
+func NewNotFoundError(message string, details ...string) *TerraformError {
+	return &TerraformError{
+		Code:    ErrCodeNotFound,
+		Message: message,
+		Details: strings.Join(details, "; "),
+	}
+}
+
+func NewLockedError(message string, details ...string) *TerraformError {
+	return &TerraformError{
+		Code:    ErrCodeLocked,
+		Message: message,
+		Details: strings.Join(details, "; "),
+	}
+}
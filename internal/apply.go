@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/ForestMars/TerraformStation"
+)
+
+// applySummary holds the resource counts and per-resource outcomes of an apply
+type applySummary struct {
+	added     int
+	changed   int
+	destroyed int
+	failed    int
+	applies   []*TerraformStation.TFResourceApply
+}
+
+// summarizeApply derives resource counts and per-resource outcomes from the machine-readable UI events of an apply
+func summarizeApply(events []*TerraformStation.UIEvent) *applySummary {
+	summary := &applySummary{}
+
+	// Replacements report a delete and a create for the same address, so key by both
+	byKey := make(map[string]*TerraformStation.TFResourceApply)
+	started := make(map[string]*TerraformStation.UIEvent)
+	resourceApply := func(hook *TerraformStation.UIHook) *TerraformStation.TFResourceApply {
+		key := hook.Resource.Addr + "|" + hook.Action
+		if ra, ok := byKey[key]; ok {
+			return ra
+		}
+		ra := &TerraformStation.TFResourceApply{Address: hook.Resource.Addr, Action: hook.Action}
+		byKey[key] = ra
+		summary.applies = append(summary.applies, ra)
+		return ra
+	}
+	elapsed := func(event *TerraformStation.UIEvent) float64 {
+		key := event.Hook.Resource.Addr + "|" + event.Hook.Action
+		if start, ok := started[key]; ok && !start.Timestamp.IsZero() && !event.Timestamp.IsZero() {
+			return event.Timestamp.Sub(start.Timestamp).Seconds()
+		}
+		return event.Hook.ElapsedSeconds
+	}
+
+	var changeSummary *TerraformStation.UIChangeSummary
+	var errors []*TerraformStation.UIDiagnostic
+	for _, event := range events {
+		switch event.Type {
+		case TerraformStation.UIEventApplyStart:
+			if event.Hook != nil {
+				started[event.Hook.Resource.Addr+"|"+event.Hook.Action] = event
+				resourceApply(event.Hook)
+			}
+		case TerraformStation.UIEventApplyComplete:
+			if event.Hook != nil {
+				ra := resourceApply(event.Hook)
+				ra.Success = true
+				ra.ElapsedSeconds = elapsed(event)
+				ra.IdValue = event.Hook.IDValue
+			}
+		case TerraformStation.UIEventApplyErrored:
+			if event.Hook != nil {
+				ra := resourceApply(event.Hook)
+				ra.Success = false
+				ra.ElapsedSeconds = elapsed(event)
+			}
+		case TerraformStation.UIEventChangeSummary:
+			if event.Changes != nil && event.Changes.Operation == "apply" {
+				changeSummary = event.Changes
+			}
+		case TerraformStation.UIEventDiagnostic:
+			if event.Diagnostic != nil && event.Diagnostic.Severity == "error" && event.Diagnostic.Address != "" {
+				errors = append(errors, event.Diagnostic)
+			}
+		}
+	}
+
+	// Attach error diagnostics to the resources that failed
+	for _, diag := range errors {
+		message := diag.Summary
+		if diag.Detail != "" {
+			message += ": " + diag.Detail
+		}
+		for _, ra := range summary.applies {
+			if ra.Address == diag.Address && !ra.Success && ra.ErrorMessage == "" {
+				ra.ErrorMessage = message
+			}
+		}
+	}
+
+	for _, ra := range summary.applies {
+		if !ra.Success {
+			summary.failed++
+		}
+	}
+
+	// Prefer the totals reported by OpenTofu, falling back to counting completed resources
+	if changeSummary != nil {
+		summary.added = changeSummary.Add
+		summary.changed = changeSummary.Change
+		summary.destroyed = changeSummary.Remove
+		return summary
+	}
+	for _, ra := range summary.applies {
+		if !ra.Success {
+			continue
+		}
+		switch ra.Action {
+		case TerraformStation.ActionCreate:
+			summary.added++
+		case TerraformStation.ActionUpdate:
+			summary.changed++
+		case TerraformStation.ActionDelete:
+			summary.destroyed++
+		}
+	}
+	return summary
+}
+
+// appendMissingFlags adds each flag to args unless it is already present
+func appendMissingFlags(args []string, flags ...string) []string {
+	for _, flag := range flags {
+		if !hasFlag(args, strings.SplitN(flag, "=", 2)[0]) {
+			args = append(args, flag)
+		}
+	}
+	return args
+}
+
+// hasFlag reports whether args set the named flag, with or without a value
+func hasFlag(args []string, name string) bool {
+	for _, arg := range args {
+		if arg == name || strings.HasPrefix(arg, name+"=") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeApply(t *testing.T) {
+	data, err := os.ReadFile("testdata/apply.jsonl")
+	require.NoError(t, err)
+
+	summary := summarizeApply(TerraformStation.ParseUIEvents(string(data)))
+	assert.Equal(t, 2, summary.added)
+	assert.Equal(t, 0, summary.changed)
+	assert.Equal(t, 1, summary.destroyed)
+	assert.Equal(t, 1, summary.failed)
+	require.Len(t, summary.applies, 4)
+
+	a := summary.applies[0]
+	assert.Equal(t, "local_file.a", a.Address)
+	assert.True(t, a.Success)
+	assert.Equal(t, "abc", a.IdValue)
+	assert.InDelta(t, 2.5, a.ElapsedSeconds, 0.001)
+
+	b := summary.applies[1]
+	assert.Equal(t, "local_file.b", b.Address)
+	assert.False(t, b.Success)
+	assert.Equal(t, "Create local file error: permission denied", b.ErrorMessage)
+
+	// A replacement is reported as a delete followed by a create
+	assert.Equal(t, "aws_instance.web", summary.applies[2].Address)
+	assert.Equal(t, TerraformStation.ActionDelete, summary.applies[2].Action)
+	assert.Equal(t, TerraformStation.ActionCreate, summary.applies[3].Action)
+	assert.InDelta(t, 30, summary.applies[3].ElapsedSeconds, 0.001)
+}
+
+func TestSummarizeApplyWithoutChangeSummary(t *testing.T) {
+	events := TerraformStation.ParseUIEvents(`{"type":"apply_complete","hook":{"resource":{"addr":"a.one"},"action":"create"}}
+{"type":"apply_complete","hook":{"resource":{"addr":"a.two"},"action":"create"}}
+{"type":"apply_complete","hook":{"resource":{"addr":"a.three"},"action":"update"}}
+not json
+`)
+
+	summary := summarizeApply(events)
+	assert.Equal(t, 2, summary.added)
+	assert.Equal(t, 1, summary.changed)
+	assert.Equal(t, 0, summary.destroyed)
+}
+
+func TestAppendMissingFlags(t *testing.T) {
+	args := appendMissingFlags([]string{"-json", "-parallelism=2"}, "-auto-approve", "-json")
+	assert.Equal(t, []string{"-json", "-parallelism=2", "-auto-approve"}, args)
+
+	args = appendMissingFlags([]string{"-out=custom"}, "-out=tfplan")
+	assert.Equal(t, []string{"-out=custom"}, args)
+}
+
+func TestTFApplyCounts(t *testing.T) {
+	impl := newScriptTestImpl(t, `cat "`+testdataPath(t, "apply.jsonl")+`"
+echo "Error: provider crashed" >&2
+exit 1
+`)
+
+	applyResult, err := impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	assert.False(t, applyResult.Success)
+	assert.Equal(t, int32(2), applyResult.ResourcesAdded)
+	assert.Equal(t, int32(1), applyResult.ResourcesDestroyed)
+	assert.Equal(t, int32(1), applyResult.ResourcesFailed)
+	assert.Len(t, applyResult.ResourceApplies, 4)
+	assert.Contains(t, applyResult.ApplyOutput, "Apply complete! Resources: 2 added, 0 changed, 1 destroyed.")
+	assert.Contains(t, applyResult.ApplyOutput, "Error: provider crashed")
+	assert.NotContains(t, applyResult.ApplyOutput, `"@level"`)
+
+	var apply TerraformStation.TerraformApply
+	require.NoError(t, impl.db.Where("apply_id = ?", applyResult.ApplyId).First(&apply).Error)
+	assert.Equal(t, 2, apply.ResourcesAdded)
+	assert.Equal(t, 1, apply.ResourcesFailed)
+	assert.Contains(t, apply.ResourceApplies, `"address":"local_file.b"`)
+
+	operation, err := impl.store.GetOperationByID(apply.OperationID)
+	require.NoError(t, err)
+	assert.Contains(t, operation.Arguments, `"-auto-approve"`)
+	assert.Contains(t, operation.Arguments, `"-json"`)
+}
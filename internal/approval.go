@@ -0,0 +1,250 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// TFApprovePlan records an approval of a plan, approving the plan once enough distinct reviewers have done so
+func (impl *TerraformStationImpl) TFApprovePlan(ctx context.Context, input *TerraformStation.TFPlanReviewInput) (*TerraformStation.TFPlanApprovalStatus, error) {
+	return impl.reviewPlan(input, TerraformStation.ApprovalDecisionApproved)
+}
+
+// TFRejectPlan records a rejection of a plan, after which it can no longer be applied
+func (impl *TerraformStationImpl) TFRejectPlan(ctx context.Context, input *TerraformStation.TFPlanReviewInput) (*TerraformStation.TFPlanApprovalStatus, error) {
+	return impl.reviewPlan(input, TerraformStation.ApprovalDecisionRejected)
+}
+
+// TFGetPlanApproval returns the approval status of a plan and the decisions made so far
+func (impl *TerraformStationImpl) TFGetPlanApproval(ctx context.Context, input *TerraformStation.TFPlanApprovalInput) (*TerraformStation.TFPlanApprovalStatus, error) {
+	if input == nil || input.PlanId == "" {
+		return nil, TerraformStation.NewInvalidInputError("plan id cannot be empty")
+	}
+
+	plan, err := impl.loadPlan(input.PlanId)
+	if err != nil {
+		return nil, err
+	}
+	impl.expireApproval(plan)
+
+	return impl.approvalStatus(plan)
+}
+
+// reviewPlan records a reviewer's decision and moves the plan to its resulting status
+func (impl *TerraformStationImpl) reviewPlan(input *TerraformStation.TFPlanReviewInput, decision string) (*TerraformStation.TFPlanApprovalStatus, error) {
+	if input == nil || input.PlanId == "" {
+		return nil, TerraformStation.NewInvalidInputError("plan id cannot be empty")
+	}
+	if input.Approver == "" {
+		return nil, TerraformStation.NewInvalidInputError("approver cannot be empty")
+	}
+
+	plan, err := impl.loadPlan(input.PlanId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plans stay open for review until they are applied, so an approved plan can still be rejected
+	if impl.expireApproval(plan) {
+		return nil, TerraformStation.NewInvalidStateError("plan approval has expired", plan.PlanID)
+	}
+	if plan.Status != TerraformStation.PlanStatusAwaitingApproval && plan.Status != TerraformStation.PlanStatusApproved {
+		return nil, TerraformStation.NewInvalidStateError("plan is not awaiting approval", plan.PlanID, plan.Status)
+	}
+
+	approvals, err := impl.store.ListPlanApprovals(plan.PlanID)
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to read plan approvals", err.Error())
+	}
+	for _, approval := range approvals {
+		if approval.Approver == input.Approver {
+			return nil, TerraformStation.NewInvalidStateError("approver has already reviewed this plan", input.Approver)
+		}
+	}
+
+	approval := &TerraformStation.TerraformPlanApproval{
+		PlanID:   plan.PlanID,
+		Approver: input.Approver,
+		Decision: decision,
+		Comment:  input.Comment,
+	}
+	if err := impl.store.CreatePlanApproval(approval); err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to record plan review", err.Error())
+	}
+	approvals = append(approvals, *approval)
+
+	next := plan.Status
+	if decision == TerraformStation.ApprovalDecisionRejected {
+		next = TerraformStation.PlanStatusRejected
+	} else if countApprovals(approvals) >= plan.RequiredApprovals {
+		next = TerraformStation.PlanStatusApproved
+	}
+
+	if next != plan.Status {
+		moved, err := impl.store.TransitionPlanStatus(plan.PlanID, plan.Status, next)
+		if err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("failed to update plan", err.Error())
+		}
+		if !moved {
+			return nil, TerraformStation.NewInvalidStateError("plan changed while it was being reviewed", plan.PlanID)
+		}
+		plan.Status = next
+	}
+	if plan.Status == TerraformStation.PlanStatusRejected {
+		discardPlanFile(plan)
+	}
+
+	return impl.approvalStatus(plan)
+}
+
+// requestApproval puts a plan with changes into the awaiting approval state when its working directory requires approvals
+func (impl *TerraformStationImpl) requestApproval(planResult *TerraformStation.TFPlanResult, workingDir string) {
+	required := impl.cfg.Approvals.RequiredFor(workingDir)
+	if required <= 0 || !planResult.HasChanges {
+		return
+	}
+
+	planResult.Status = TerraformStation.PlanStatusAwaitingApproval
+	planResult.RequiredApprovals = int32(required)
+	if impl.cfg.Approvals.Timeout > 0 {
+		planResult.ApprovalExpiresAt = timestamppb.New(time.Now().Add(impl.cfg.Approvals.Timeout))
+	}
+}
+
+// checkPlanApproved rejects plans that are not approved for apply
+func (impl *TerraformStationImpl) checkPlanApproved(plan *TerraformStation.TerraformPlan) error {
+	switch plan.Status {
+	case TerraformStation.PlanStatusCompleted, TerraformStation.PlanStatusApproved:
+		return nil
+	case TerraformStation.PlanStatusAwaitingApproval:
+		if impl.expireApproval(plan) {
+			return TerraformStation.NewInvalidStateError("plan approval has expired", plan.PlanID)
+		}
+		approvals, err := impl.store.ListPlanApprovals(plan.PlanID)
+		if err != nil {
+			return TerraformStation.NewExecutionFailedError("failed to read plan approvals", err.Error())
+		}
+		return TerraformStation.NewPermissionDeniedError("plan is awaiting approval",
+			fmt.Sprintf("%d of %d approvals", countApprovals(approvals), plan.RequiredApprovals))
+	case TerraformStation.PlanStatusRejected:
+		return TerraformStation.NewInvalidStateError("plan was rejected", plan.PlanID)
+	case TerraformStation.PlanStatusExpired:
+		return TerraformStation.NewInvalidStateError("plan approval has expired", plan.PlanID)
+	case TerraformStation.PlanStatusApplying, TerraformStation.PlanStatusApplied:
+		return TerraformStation.NewInvalidStateError("plan has already been applied", plan.PlanID)
+	default:
+		return TerraformStation.NewInvalidStateError("plan did not complete successfully", plan.PlanID, plan.Status)
+	}
+}
+
+// requireReviewedPlan refuses commands that write state and would bypass the approval gate of a working directory.
+// Changes can be applied there by plan id, while commands such as import, taint or state rm, which never go
+// through a plan, cannot run at all.
+func (impl *TerraformStationImpl) requireReviewedPlan(input *TerraformStation.TFCommandInput) error {
+	if input == nil || !writesState(input) {
+		return nil
+	}
+
+	workingDir, err := impl.resolveWorkingDir(input)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(stateChangingCommands, input.Command) && impl.cfg.Approvals.RequiredFor(workingDir) > 0 {
+		return TerraformStation.NewPermissionDeniedError("working directory requires an approved plan, apply it by plan id", workingDir)
+	}
+	operation := input.Command
+	if len(input.Arguments) > 0 && (input.Command == "state" || input.Command == "workspace") {
+		operation += " " + input.Arguments[0]
+	}
+	return impl.refuseUnreviewedStateWrite(workingDir, operation)
+}
+
+// refuseUnreviewedStateWrite refuses a change to state made outside a plan in a working directory that requires approvals
+func (impl *TerraformStationImpl) refuseUnreviewedStateWrite(workingDir, operation string) error {
+	if impl.cfg.Approvals.RequiredFor(workingDir) == 0 {
+		return nil
+	}
+	return TerraformStation.NewPermissionDeniedError("working directory requires approved plans, state cannot be changed outside a plan",
+		operation, workingDir)
+}
+
+// refuseBackendStateWrite refuses changes to http backend states while approvals are required. Those
+// states are not tied to a working directory, so writes to them cannot be matched to an approved plan.
+func (impl *TerraformStationImpl) refuseBackendStateWrite(name string) error {
+	if !impl.cfg.Approvals.Required() {
+		return nil
+	}
+	return TerraformStation.NewPermissionDeniedError("http backend states cannot be changed while plan approvals are required", name)
+}
+
+// expireApproval marks a plan whose approval window has passed as expired, reporting whether it did so
+func (impl *TerraformStationImpl) expireApproval(plan *TerraformStation.TerraformPlan) bool {
+	if plan.Status != TerraformStation.PlanStatusAwaitingApproval || plan.ApprovalExpiresAt == nil || time.Now().Before(*plan.ApprovalExpiresAt) {
+		return false
+	}
+
+	if _, err := impl.store.TransitionPlanStatus(plan.PlanID, plan.Status, TerraformStation.PlanStatusExpired); err != nil {
+		log.Printf("failed to expire plan %s: %v", plan.PlanID, err)
+	}
+	plan.Status = TerraformStation.PlanStatusExpired
+	discardPlanFile(plan)
+	return true
+}
+
+// loadPlan retrieves a plan by its plan ID
+func (impl *TerraformStationImpl) loadPlan(planID string) (*TerraformStation.TerraformPlan, error) {
+	plan, err := impl.store.GetPlanByPlanID(planID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, TerraformStation.NewNotFoundError("plan not found", planID)
+		}
+		return nil, TerraformStation.NewExecutionFailedError("failed to read plan", err.Error())
+	}
+	return plan, nil
+}
+
+// approvalStatus builds the approval status of a plan from its recorded decisions
+func (impl *TerraformStationImpl) approvalStatus(plan *TerraformStation.TerraformPlan) (*TerraformStation.TFPlanApprovalStatus, error) {
+	approvals, err := impl.store.ListPlanApprovals(plan.PlanID)
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to read plan approvals", err.Error())
+	}
+
+	status := &TerraformStation.TFPlanApprovalStatus{
+		PlanId:            plan.PlanID,
+		Status:            plan.Status,
+		RequiredApprovals: int32(plan.RequiredApprovals),
+		ApprovalCount:     int32(countApprovals(approvals)),
+	}
+	if plan.ApprovalExpiresAt != nil {
+		status.ExpiresAt = timestamppb.New(*plan.ApprovalExpiresAt)
+	}
+	for _, approval := range approvals {
+		status.Approvals = append(status.Approvals, &TerraformStation.TFPlanApproval{
+			Approver:  approval.Approver,
+			Decision:  approval.Decision,
+			Comment:   approval.Comment,
+			CreatedAt: timestamppb.New(approval.CreatedAt),
+		})
+	}
+	return status, nil
+}
+
+// countApprovals counts the approving decisions among a plan's reviews
+func countApprovals(approvals []TerraformStation.TerraformPlanApproval) int {
+	count := 0
+	for _, approval := range approvals {
+		if approval.Decision == TerraformStation.ApprovalDecisionApproved {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newApprovalTestImpl creates a saved-plan implementation whose working directory requires the given approvals
+func newApprovalTestImpl(t *testing.T, required int) *TerraformStationImpl {
+	impl := newSavedPlanTestImpl(t)
+	impl.cfg.Approvals.WorkingDirectories = map[string]int{impl.workingDir: required}
+	return impl
+}
+
+func TestPlanAwaitsApproval(t *testing.T) {
+	impl := newApprovalTestImpl(t, 2)
+
+	planResult, err := impl.TFPlan(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.PlanStatusAwaitingApproval, planResult.Status)
+	assert.Equal(t, int32(2), planResult.RequiredApprovals)
+	assert.NotNil(t, planResult.ApprovalExpiresAt)
+
+	// Apply is refused until enough distinct approvers have approved
+	_, err = impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{PlanId: planResult.PlanId})
+	assertErrorCode(t, err, TerraformStation.ErrCodePermissionDenied)
+
+	status, err := impl.TFApprovePlan(context.Background(), &TerraformStation.TFPlanReviewInput{PlanId: planResult.PlanId, Approver: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.PlanStatusAwaitingApproval, status.Status)
+	assert.Equal(t, int32(1), status.ApprovalCount)
+
+	_, err = impl.TFApprovePlan(context.Background(), &TerraformStation.TFPlanReviewInput{PlanId: planResult.PlanId, Approver: "alice"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+
+	_, err = impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{PlanId: planResult.PlanId})
+	assertErrorCode(t, err, TerraformStation.ErrCodePermissionDenied)
+
+	status, err = impl.TFApprovePlan(context.Background(), &TerraformStation.TFPlanReviewInput{PlanId: planResult.PlanId, Approver: "bob", Comment: "lgtm"})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.PlanStatusApproved, status.Status)
+	assert.Equal(t, int32(2), status.ApprovalCount)
+	require.Len(t, status.Approvals, 2)
+	assert.Equal(t, "lgtm", status.Approvals[1].Comment)
+
+	applyResult, err := impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{PlanId: planResult.PlanId})
+	require.NoError(t, err)
+	assert.True(t, applyResult.Success)
+
+	status, err = impl.TFGetPlanApproval(context.Background(), &TerraformStation.TFPlanApprovalInput{PlanId: planResult.PlanId})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.PlanStatusApplied, status.Status)
+}
+
+func TestRejectedPlanCannotBeApplied(t *testing.T) {
+	impl := newApprovalTestImpl(t, 1)
+
+	planResult, err := impl.TFPlan(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+
+	status, err := impl.TFRejectPlan(context.Background(), &TerraformStation.TFPlanReviewInput{PlanId: planResult.PlanId, Approver: "alice", Comment: "deletes the database"})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.PlanStatusRejected, status.Status)
+
+	_, err = impl.TFApprovePlan(context.Background(), &TerraformStation.TFPlanReviewInput{PlanId: planResult.PlanId, Approver: "bob"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+
+	_, err = impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{PlanId: planResult.PlanId})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+}
+
+func TestExpiredApproval(t *testing.T) {
+	impl := newApprovalTestImpl(t, 1)
+
+	planResult, err := impl.TFPlan(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	plan, err := impl.store.GetPlanByPlanID(planResult.PlanId)
+	require.NoError(t, err)
+	require.NoError(t, impl.db.Model(plan).Update("approval_expires_at", time.Now().Add(-time.Minute)).Error)
+
+	_, err = impl.TFApprovePlan(context.Background(), &TerraformStation.TFPlanReviewInput{PlanId: planResult.PlanId, Approver: "alice"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+
+	status, err := impl.TFGetPlanApproval(context.Background(), &TerraformStation.TFPlanApprovalInput{PlanId: planResult.PlanId})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.PlanStatusExpired, status.Status)
+}
+
+func TestApprovalGateBlocksDirectApply(t *testing.T) {
+	impl := newApprovalTestImpl(t, 1)
+
+	_, err := impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{})
+	assertErrorCode(t, err, TerraformStation.ErrCodePermissionDenied)
+
+	_, err = impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "destroy"})
+	assertErrorCode(t, err, TerraformStation.ErrCodePermissionDenied)
+}
+
+func TestApprovalGateBlocksStateWrites(t *testing.T) {
+	impl := newApprovalTestImpl(t, 1)
+
+	for _, input := range []*TerraformStation.TFCommandInput{
+		{Command: "import", Arguments: []string{"null_resource.a", "a"}},
+		{Command: "taint", Arguments: []string{"null_resource.a"}},
+		{Command: "untaint", Arguments: []string{"null_resource.a"}},
+		{Command: "refresh"},
+		{Command: "state", Arguments: []string{"rm", "null_resource.a"}},
+		{Command: "state", Arguments: []string{"mv", "null_resource.a", "null_resource.b"}},
+		{Command: "state", Arguments: []string{"push", "terraform.tfstate"}},
+		{Command: "workspace", Arguments: []string{"delete", "-force", "staging"}},
+	} {
+		_, err := impl.TFCommand(context.Background(), input)
+		assertErrorCode(t, err, TerraformStation.ErrCodePermissionDenied)
+	}
+
+	// Reading state is still allowed
+	result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "state", Arguments: []string{"list"}})
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestApprovalGateBlocksWorkspaceDelete(t *testing.T) {
+	impl := newApprovalTestImpl(t, 1)
+
+	_, err := impl.TFDeleteWorkspace(context.Background(), &TerraformStation.TFWorkspaceInput{Name: "staging", Force: true})
+	assertErrorCode(t, err, TerraformStation.ErrCodePermissionDenied)
+}
+
+func TestApprovalGateBlocksStateRollback(t *testing.T) {
+	impl := newApprovalTestImpl(t, 1)
+
+	_, err := impl.TFRollbackState(context.Background(), &TerraformStation.TFStateRollbackInput{WorkingDirectory: impl.workingDir, Version: 1})
+	assertErrorCode(t, err, TerraformStation.ErrCodePermissionDenied)
+}
+
+func TestApprovalGateBlocksBackendStateWrites(t *testing.T) {
+	impl := newApprovalTestImpl(t, 1)
+	ctx := context.Background()
+
+	// http backend states belong to no working directory, so no write can be tied to an approved plan
+	err := impl.PutBackendState(ctx, "network/production", "", []byte(`{"version":4,"serial":1,"lineage":"a","resources":[]}`))
+	assertErrorCode(t, err, TerraformStation.ErrCodePermissionDenied)
+	err = impl.DeleteBackendState(ctx, "network/production", "")
+	assertErrorCode(t, err, TerraformStation.ErrCodePermissionDenied)
+	_, err = impl.TFRollbackState(ctx, &TerraformStation.TFStateRollbackInput{Name: "network/production", Version: 1})
+	assertErrorCode(t, err, TerraformStation.ErrCodePermissionDenied)
+
+	data, err := impl.GetBackendState(ctx, "network/production")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestReviewInputValidation(t *testing.T) {
+	impl := newApprovalTestImpl(t, 1)
+
+	_, err := impl.TFApprovePlan(context.Background(), &TerraformStation.TFPlanReviewInput{Approver: "alice"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+
+	_, err = impl.TFApprovePlan(context.Background(), &TerraformStation.TFPlanReviewInput{PlanId: "tofu_1"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+
+	_, err = impl.TFRejectPlan(context.Background(), &TerraformStation.TFPlanReviewInput{PlanId: "tofu_missing", Approver: "alice"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeNotFound)
+}
@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"gorm.io/gorm"
+)
+
+// stateNamePattern restricts state names to path-like identifiers such as "network/production"
+var stateNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*(/[A-Za-z0-9][A-Za-z0-9._-]*)*$`)
+
+// validateStateName checks the name a state is stored under
+func validateStateName(name string) error {
+	if name == "" {
+		return TerraformStation.NewInvalidInputError("state name cannot be empty")
+	}
+	if len(name) > 255 || !stateNamePattern.MatchString(name) {
+		return TerraformStation.NewInvalidInputError("invalid state name", name)
+	}
+	return nil
+}
+
+// GetBackendState returns the newest version of a state, or nil if nothing is stored under the name
+func (impl *TerraformStationImpl) GetBackendState(ctx context.Context, name string) ([]byte, error) {
+	if err := validateStateName(name); err != nil {
+		return nil, err
+	}
+
+	version, err := impl.store.GetLatestStateVersion(name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to read state", err.Error())
+	}
+	return []byte(version.StateData), nil
+}
+
+// PutBackendState stores data as the next version of a state. While the state is locked,
+// only the holder of the lock, identified by lockID, can write it.
+func (impl *TerraformStationImpl) PutBackendState(ctx context.Context, name, lockID string, data []byte) error {
+	if err := validateStateName(name); err != nil {
+		return err
+	}
+	if err := impl.refuseBackendStateWrite(name); err != nil {
+		return err
+	}
+	version, err := newStateVersion(name, data)
+	if err != nil {
+		return err
+	}
+
+	holder, err := impl.stateLockHolder(name)
+	if err != nil {
+		return err
+	}
+	if holder != nil {
+		if holder.ID != lockID {
+			return TerraformStation.NewLockedError("state is locked", describeStateLock(name, holder))
+		}
+		version.Operation = holder.Operation
+	}
+	version.LockID = lockID
+
+	_, err = impl.recordStateVersion(version)
+	return err
+}
+
+// DeleteBackendState removes a state, as OpenTofu does when its workspace is deleted
+func (impl *TerraformStationImpl) DeleteBackendState(ctx context.Context, name, lockID string) error {
+	if err := validateStateName(name); err != nil {
+		return err
+	}
+	if err := impl.refuseBackendStateWrite(name); err != nil {
+		return err
+	}
+	if err := impl.checkStateLock(name, lockID); err != nil {
+		return err
+	}
+
+	if _, err := impl.store.DeleteStateVersions(name); err != nil {
+		return TerraformStation.NewExecutionFailedError("failed to delete state", err.Error())
+	}
+	return nil
+}
+
+// LockBackendState locks a state for the client described by info. If another client holds
+// the lock, its description is returned with a LOCKED error.
+func (impl *TerraformStationImpl) LockBackendState(ctx context.Context, name string, info *TerraformStation.StateLockInfo) (*TerraformStation.StateLockInfo, error) {
+	if err := validateStateName(name); err != nil {
+		return nil, err
+	}
+	if info == nil || info.ID == "" {
+		return nil, TerraformStation.NewInvalidInputError("lock id cannot be empty")
+	}
+	if info.Created.IsZero() {
+		info.Created = time.Now().UTC()
+	}
+
+	encoded, err := json.Marshal(info)
+	if err != nil {
+		return nil, TerraformStation.NewInvalidInputError("invalid lock info", err.Error())
+	}
+
+	taken, err := impl.store.CreateStateLock(&TerraformStation.TerraformStateLock{
+		Name:      name,
+		LockID:    info.ID,
+		Operation: info.Operation,
+		Who:       info.Who,
+		Info:      string(encoded),
+	})
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to lock state", err.Error())
+	}
+	if taken {
+		return info, nil
+	}
+
+	holder, err := impl.stateLockHolder(name)
+	if err != nil {
+		return nil, err
+	}
+	if holder == nil {
+		// Unlocked in the meantime, so try again
+		return impl.LockBackendState(ctx, name, info)
+	}
+	if holder.ID == info.ID {
+		return holder, nil
+	}
+	return holder, TerraformStation.NewLockedError("state is locked", describeStateLock(name, holder))
+}
+
+// UnlockBackendState releases the lock on a state. A nil info, which is what `tofu force-unlock`
+// sends, releases the lock regardless of its holder; otherwise info must match the holder.
+func (impl *TerraformStationImpl) UnlockBackendState(ctx context.Context, name string, info *TerraformStation.StateLockInfo) (*TerraformStation.StateLockInfo, error) {
+	if err := validateStateName(name); err != nil {
+		return nil, err
+	}
+
+	holder, err := impl.stateLockHolder(name)
+	if err != nil || holder == nil {
+		return nil, err
+	}
+	if info != nil && info.ID != holder.ID {
+		return holder, TerraformStation.NewLockedError("state is locked by another client", describeStateLock(name, holder))
+	}
+
+	removed, err := impl.store.DeleteStateLock(name, holder.ID)
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to unlock state", err.Error())
+	}
+	if removed && info == nil {
+		log.Printf("force-unlocked %s", describeStateLock(name, holder))
+	}
+	return holder, nil
+}
+
+// checkStateLock rejects writes to a locked state by anyone but the holder of the lock
+func (impl *TerraformStationImpl) checkStateLock(name, lockID string) error {
+	holder, err := impl.stateLockHolder(name)
+	if err != nil {
+		return err
+	}
+	if holder != nil && holder.ID != lockID {
+		return TerraformStation.NewLockedError("state is locked", describeStateLock(name, holder))
+	}
+	return nil
+}
+
+// stateLockHolder returns the description of the lock held on a state, or nil if it is not locked
+func (impl *TerraformStationImpl) stateLockHolder(name string) (*TerraformStation.StateLockInfo, error) {
+	lock, err := impl.store.GetStateLock(name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to read state lock", err.Error())
+	}
+
+	var info TerraformStation.StateLockInfo
+	if err := json.Unmarshal([]byte(lock.Info), &info); err != nil {
+		// Still report who holds the lock when its stored description cannot be read
+		info = TerraformStation.StateLockInfo{Operation: lock.Operation, Who: lock.Who, Created: lock.CreatedAt}
+	}
+	info.ID = lock.LockID
+	return &info, nil
+}
+
+// describeStateLock summarizes who holds a state lock, for error messages and logs
+func describeStateLock(name string, info *TerraformStation.StateLockInfo) string {
+	return fmt.Sprintf("lock %s on state %s held by %s for %s since %s",
+		info.ID, name, info.Who, info.Operation, info.Created.Format(time.RFC3339))
+}
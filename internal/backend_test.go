@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stateFile builds a minimal state file with the given serial
+func stateFile(serial int) []byte {
+	return []byte(fmt.Sprintf(`{"version":4,"terraform_version":"1.8.0","serial":%d,"lineage":"3f1c2a","outputs":{},"resources":[]}`, serial))
+}
+
+func TestBackendStateRoundTrip(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo ok\n")
+	ctx := context.Background()
+
+	data, err := impl.GetBackendState(ctx, "network/production")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	require.NoError(t, impl.PutBackendState(ctx, "network/production", "", stateFile(1)))
+	require.NoError(t, impl.PutBackendState(ctx, "network/production", "", stateFile(2)))
+	// Writing the same state again does not add a version
+	require.NoError(t, impl.PutBackendState(ctx, "network/production", "", stateFile(2)))
+
+	data, err = impl.GetBackendState(ctx, "network/production")
+	require.NoError(t, err)
+	assert.Equal(t, stateFile(2), data)
+
+	latest, err := impl.store.GetLatestStateVersion("network/production")
+	require.NoError(t, err)
+	assert.Equal(t, 2, latest.Version)
+	assert.Equal(t, int64(2), latest.Serial)
+	assert.Equal(t, "3f1c2a", latest.Lineage)
+	assert.Equal(t, TerraformStation.StateChecksum(stateFile(2)), latest.Checksum)
+
+	require.NoError(t, impl.DeleteBackendState(ctx, "network/production", ""))
+	data, err = impl.GetBackendState(ctx, "network/production")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	// Version numbers are not reused after a delete
+	require.NoError(t, impl.PutBackendState(ctx, "network/production", "", stateFile(1)))
+	latest, err = impl.store.GetLatestStateVersion("network/production")
+	require.NoError(t, err)
+	assert.Equal(t, 3, latest.Version)
+}
+
+func TestBackendStateLocking(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo ok\n")
+	ctx := context.Background()
+
+	holder, err := impl.LockBackendState(ctx, "production", &TerraformStation.StateLockInfo{ID: "a1", Operation: "OperationTypeApply", Who: "alice@laptop"})
+	require.NoError(t, err)
+	assert.Equal(t, "a1", holder.ID)
+
+	holder, err = impl.LockBackendState(ctx, "production", &TerraformStation.StateLockInfo{ID: "b2", Operation: "OperationTypePlan", Who: "bob@ci"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeLocked)
+	require.NotNil(t, holder)
+	assert.Equal(t, "a1", holder.ID)
+	assert.Equal(t, "alice@laptop", holder.Who)
+
+	// Only the holder can write or release the state
+	err = impl.PutBackendState(ctx, "production", "b2", stateFile(1))
+	assertErrorCode(t, err, TerraformStation.ErrCodeLocked)
+	require.NoError(t, impl.PutBackendState(ctx, "production", "a1", stateFile(1)))
+
+	_, err = impl.UnlockBackendState(ctx, "production", &TerraformStation.StateLockInfo{ID: "b2"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeLocked)
+	_, err = impl.UnlockBackendState(ctx, "production", &TerraformStation.StateLockInfo{ID: "a1"})
+	require.NoError(t, err)
+
+	_, err = impl.LockBackendState(ctx, "production", &TerraformStation.StateLockInfo{ID: "b2"})
+	require.NoError(t, err)
+
+	// force-unlock sends no lock info
+	holder, err = impl.UnlockBackendState(ctx, "production", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "b2", holder.ID)
+
+	require.NoError(t, impl.PutBackendState(ctx, "production", "", stateFile(2)))
+}
+
+func TestBackendStateValidation(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo ok\n")
+	ctx := context.Background()
+
+	_, err := impl.GetBackendState(ctx, "../production")
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+
+	err = impl.PutBackendState(ctx, "production", "", []byte("not json"))
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+
+	_, err = impl.LockBackendState(ctx, "production", &TerraformStation.StateLockInfo{})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+}
@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// errOperationCancelled is the cause recorded for operations stopped through TFCancelOperation
+var errOperationCancelled = errors.New("operation was cancelled")
+
+// operationRunner tracks the operations running in this process so they can be cancelled
+type operationRunner struct {
+	mu      sync.Mutex
+	running map[string]context.CancelCauseFunc
+}
+
+func newOperationRunner() *operationRunner {
+	return &operationRunner{running: make(map[string]context.CancelCauseFunc)}
+}
+
+// start registers a running operation and returns the context it runs under along with
+// the function that unregisters it once it has finished
+func (r *operationRunner) start(ctx context.Context, commandID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.running[commandID] = cancel
+
+	return ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.running, commandID)
+		cancel(nil)
+	}
+}
+
+// cancel interrupts a running operation, reporting whether it was running in this process
+func (r *operationRunner) cancel(commandID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cancel, ok := r.running[commandID]
+	if !ok {
+		return false
+	}
+	cancel(errOperationCancelled)
+	return true
+}
+
+// TFCancelOperation interrupts a running command. OpenTofu is sent SIGINT so it can finish the
+// resource operations in flight and release its locks, and is only killed along with its provider
+// plugins if it has not stopped once the interrupt grace period ends. It returns without waiting
+// for the command to stop; the operation is recorded as cancelled when it does.
+func (impl *TerraformStationImpl) TFCancelOperation(ctx context.Context, input *TerraformStation.TFOperationInput) (*TerraformStation.TFOperation, error) {
+	if input == nil || input.CommandId == "" {
+		return nil, TerraformStation.NewInvalidInputError("command id cannot be empty")
+	}
+
+	operation, err := impl.loadOperation(input.CommandId)
+	if err != nil {
+		return nil, err
+	}
+	if operationFinished(operation) {
+		return nil, TerraformStation.NewInvalidStateError("operation has already finished", operation.CommandID, operation.Status)
+	}
+
+	if !impl.operations.cancel(operation.CommandID) {
+		// The operation may have finished in the meantime
+		if operation, err = impl.loadOperation(input.CommandId); err != nil {
+			return nil, err
+		}
+		if operationFinished(operation) {
+			return nil, TerraformStation.NewInvalidStateError("operation has already finished", operation.CommandID, operation.Status)
+		}
+		return nil, TerraformStation.NewInvalidStateError("operation is not running on this station", operation.CommandID)
+	}
+
+	return operationToProto(operation), nil
+}
+
+// loadOperation retrieves an operation by its command ID
+func (impl *TerraformStationImpl) loadOperation(commandID string) (*TerraformStation.TerraformOperation, error) {
+	operation, err := impl.store.GetOperationByCommandID(commandID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, TerraformStation.NewNotFoundError("operation not found", commandID)
+		}
+		return nil, TerraformStation.NewExecutionFailedError("failed to read operation", err.Error())
+	}
+	return operation, nil
+}
+
+// operationFinished reports whether an operation has reached a final status
+func operationFinished(operation *TerraformStation.TerraformOperation) bool {
+	switch operation.Status {
+	case TerraformStation.OperationStatusSucceeded, TerraformStation.OperationStatusFailed, TerraformStation.OperationStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// operationToProto converts a persisted operation to its API representation
+func operationToProto(operation *TerraformStation.TerraformOperation) *TerraformStation.TFOperation {
+	out := &TerraformStation.TFOperation{
+		CommandId:        operation.CommandID,
+		Command:          operation.Command,
+		WorkingDirectory: operation.WorkingDir,
+		Workspace:        operation.Workspace,
+		Status:           operation.Status,
+		ExitCode:         int32(operation.ExitCode),
+		Signal:           operation.Signal,
+		ErrorMessage:     operation.ErrorMessage,
+		StartedAt:        timestamppb.New(operation.StartedAt),
+	}
+	if operation.CompletedAt != nil {
+		out.CompletedAt = timestamppb.New(*operation.CompletedAt)
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/ForestMars/TerraformStation/tofutest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startRefresh runs a refresh in the background and returns its operation once the fake has been
+// invoked, along with a channel that receives the result
+func startRefresh(t *testing.T, impl *TerraformStationImpl, fake *tofutest.Fake) (*TerraformStation.TerraformOperation, <-chan *TerraformStation.TFCommandResult) {
+	done := make(chan *TerraformStation.TFCommandResult, 1)
+	go func() {
+		result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "refresh"})
+		assert.NoError(t, err)
+		done <- result
+	}()
+
+	require.Eventually(t, func() bool { return len(fake.CallsTo("refresh")) > 0 }, 10*time.Second, 10*time.Millisecond)
+
+	var operation TerraformStation.TerraformOperation
+	require.NoError(t, impl.db.Where("command = ? AND status = ?", "refresh", TerraformStation.OperationStatusRunning).First(&operation).Error)
+	return &operation, done
+}
+
+func TestCancelOperation(t *testing.T) {
+	impl, fake := newFakeTestImpl(t)
+	fake.On("refresh").Delay(time.Minute).OnInterrupt("Interrupt received.\nStopping operation...\n", 1)
+
+	operation, done := startRefresh(t, impl, fake)
+
+	cancelled, err := impl.TFCancelOperation(context.Background(), &TerraformStation.TFOperationInput{CommandId: operation.CommandID})
+	require.NoError(t, err)
+	assert.Equal(t, operation.CommandID, cancelled.CommandId)
+	assert.Equal(t, "refresh", cancelled.Command)
+
+	// OpenTofu is given the chance to stop on its own
+	var result *TerraformStation.TFCommandResult
+	select {
+	case result = <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("refresh did not stop after being cancelled")
+	}
+	assert.False(t, result.Success)
+	assert.True(t, result.Cancelled)
+	assert.Equal(t, int32(1), result.ExitCode)
+	assert.Empty(t, result.Signal)
+	assert.Contains(t, result.Stdout, "Stopping operation")
+
+	recorded, err := impl.store.GetOperationByCommandID(operation.CommandID)
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.OperationStatusCancelled, recorded.Status)
+	assert.NotNil(t, recorded.CompletedAt)
+
+	// A cancelled operation cannot be cancelled again
+	_, err = impl.TFCancelOperation(context.Background(), &TerraformStation.TFOperationInput{CommandId: operation.CommandID})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+}
+
+func TestCancelOperationKillsAfterGracePeriod(t *testing.T) {
+	impl, fake := newFakeTestImpl(t)
+	impl.executor = TerraformStation.NewOpenTofuExecutor(fake.Path, time.Minute).WithGracePeriod(100 * time.Millisecond)
+	fake.On("refresh").Delay(time.Minute).IgnoreInterrupt()
+
+	operation, done := startRefresh(t, impl, fake)
+
+	_, err := impl.TFCancelOperation(context.Background(), &TerraformStation.TFOperationInput{CommandId: operation.CommandID})
+	require.NoError(t, err)
+
+	var result *TerraformStation.TFCommandResult
+	select {
+	case result = <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("refresh was not killed after the grace period")
+	}
+	assert.True(t, result.Cancelled)
+	assert.Equal(t, "killed", result.Signal)
+
+	recorded, err := impl.store.GetOperationByCommandID(operation.CommandID)
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.OperationStatusCancelled, recorded.Status)
+	assert.Equal(t, "killed", recorded.Signal)
+}
+
+func TestCancelOperationErrors(t *testing.T) {
+	impl, fake := newFakeTestImpl(t)
+	fake.On("validate").Stdout("Success!\n")
+
+	_, err := impl.TFCancelOperation(context.Background(), &TerraformStation.TFOperationInput{})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+
+	_, err = impl.TFCancelOperation(context.Background(), &TerraformStation.TFOperationInput{CommandId: "tofu_missing"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeNotFound)
+
+	result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "validate"})
+	require.NoError(t, err)
+	_, err = impl.TFCancelOperation(context.Background(), &TerraformStation.TFOperationInput{CommandId: result.CommandId})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+
+	// An operation left running by another station cannot be interrupted from this one
+	require.NoError(t, impl.store.CreateOperation(&TerraformStation.TerraformOperation{
+		CommandID:  "tofu_elsewhere",
+		Command:    "apply",
+		WorkingDir: impl.workingDir,
+		Status:     TerraformStation.OperationStatusRunning,
+		StartedAt:  time.Now(),
+	}))
+	_, err = impl.TFCancelOperation(context.Background(), &TerraformStation.TFOperationInput{CommandId: "tofu_elsewhere"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+}
@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ForestMars/TerraformStation"
+)
+
+// Diagnostic severities reported by OpenTofu
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// commandDiagnostics reads the diagnostics of a command run with -json: the document printed by
+// `validate -json`, or the diagnostic messages of the machine-readable UI of other commands
+func commandDiagnostics(command string, args []string, stdout string) []*TerraformStation.TFDiagnostic {
+	if !hasFlag(args, "-json") {
+		return nil
+	}
+
+	var diagnostics []*TerraformStation.UIDiagnostic
+	if command == "validate" {
+		validate, err := TerraformStation.ParseValidateOutput(stdout)
+		if err != nil {
+			return nil
+		}
+		diagnostics = validate.Diagnostics
+	} else {
+		for _, event := range TerraformStation.ParseUIEvents(stdout) {
+			if event.Type == TerraformStation.UIEventDiagnostic && event.Diagnostic != nil {
+				diagnostics = append(diagnostics, event.Diagnostic)
+			}
+		}
+	}
+
+	converted := make([]*TerraformStation.TFDiagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		if diagnostic != nil {
+			converted = append(converted, diagnosticToProto(diagnostic))
+		}
+	}
+	return converted
+}
+
+// diagnosticToProto converts a diagnostic from the machine-readable UI
+func diagnosticToProto(d *TerraformStation.UIDiagnostic) *TerraformStation.TFDiagnostic {
+	diagnostic := &TerraformStation.TFDiagnostic{
+		Severity: d.Severity,
+		Summary:  d.Summary,
+		Detail:   d.Detail,
+		Address:  d.Address,
+	}
+	if d.Range != nil {
+		diagnostic.Range = &TerraformStation.TFSourceRange{
+			Filename: d.Range.Filename,
+			Start:    sourcePosToProto(d.Range.Start),
+			End:      sourcePosToProto(d.Range.End),
+		}
+	}
+	if d.Snippet != nil {
+		diagnostic.Snippet = &TerraformStation.TFDiagnosticSnippet{
+			Code:                 d.Snippet.Code,
+			StartLine:            int32(d.Snippet.StartLine),
+			HighlightStartOffset: int32(d.Snippet.HighlightStartOffset),
+			HighlightEndOffset:   int32(d.Snippet.HighlightEndOffset),
+		}
+		if d.Snippet.Context != nil {
+			diagnostic.Snippet.Context = *d.Snippet.Context
+		}
+		for _, value := range d.Snippet.Values {
+			diagnostic.Snippet.Values = append(diagnostic.Snippet.Values, &TerraformStation.TFExpressionValue{
+				Traversal: value.Traversal,
+				Statement: value.Statement,
+			})
+		}
+	}
+	return diagnostic
+}
+
+// redactDiagnostics masks the text of diagnostics parsed from unmasked output
+func redactDiagnostics(redactor *TerraformStation.Redactor, diagnostics []*TerraformStation.TFDiagnostic) {
+	for _, diagnostic := range diagnostics {
+		diagnostic.Summary = redactor.Redact(diagnostic.Summary)
+		diagnostic.Detail = redactor.Redact(diagnostic.Detail)
+		diagnostic.Address = redactor.Redact(diagnostic.Address)
+		if snippet := diagnostic.Snippet; snippet != nil {
+			snippet.Code = redactor.Redact(snippet.Code)
+			snippet.Context = redactor.Redact(snippet.Context)
+			for _, value := range snippet.Values {
+				value.Statement = redactor.Redact(value.Statement)
+			}
+		}
+	}
+}
+
+func sourcePosToProto(pos TerraformStation.UISourcePos) *TerraformStation.TFSourcePos {
+	return &TerraformStation.TFSourcePos{Line: int32(pos.Line), Column: int32(pos.Column), Byte: int32(pos.Byte)}
+}
+
+// countDiagnostics returns the number of errors and warnings among diagnostics
+func countDiagnostics(diagnostics []*TerraformStation.TFDiagnostic) (errors, warnings int32) {
+	for _, diagnostic := range diagnostics {
+		switch diagnostic.Severity {
+		case severityError:
+			errors++
+		case severityWarning:
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+// renderDiagnostics formats diagnostics the way OpenTofu prints them without -json
+func renderDiagnostics(diagnostics []*TerraformStation.TFDiagnostic) string {
+	var b strings.Builder
+	for _, d := range diagnostics {
+		severity := "Error"
+		if d.Severity == severityWarning {
+			severity = "Warning"
+		}
+		fmt.Fprintf(&b, "%s: %s\n", severity, d.Summary)
+
+		if d.Range != nil && d.Range.Start != nil {
+			fmt.Fprintf(&b, "\n  on %s line %d", d.Range.Filename, d.Range.Start.Line)
+			if d.Snippet != nil && d.Snippet.Context != "" {
+				fmt.Fprintf(&b, ", in %s", d.Snippet.Context)
+			}
+			b.WriteString(":\n")
+			if d.Snippet != nil {
+				for i, line := range strings.Split(d.Snippet.Code, "\n") {
+					fmt.Fprintf(&b, "%4d: %s\n", int(d.Snippet.StartLine)+i, line)
+				}
+			}
+		}
+		if d.Detail != "" {
+			fmt.Fprintf(&b, "\n%s\n", d.Detail)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
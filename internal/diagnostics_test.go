@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTFValidateDiagnostics(t *testing.T) {
+	impl, fake := newFakeTestImpl(t)
+	fake.On("validate", "-json").StdoutFile(testdataPath(t, "validate.json")).ExitCode(1)
+
+	result, err := impl.TFValidate(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, int32(1), result.ErrorCount)
+	assert.Equal(t, int32(1), result.WarningCount)
+	require.Len(t, result.Diagnostics, 2)
+
+	diagnostic := result.Diagnostics[0]
+	assert.Equal(t, "error", diagnostic.Severity)
+	assert.Equal(t, "Unsupported argument", diagnostic.Summary)
+	assert.Equal(t, "main.tf", diagnostic.Range.Filename)
+	assert.Equal(t, int32(12), diagnostic.Range.Start.Line)
+	assert.Equal(t, int32(3), diagnostic.Range.Start.Column)
+	assert.Equal(t, int32(15), diagnostic.Range.End.Column)
+	assert.Equal(t, `resource "aws_instance" "web"`, diagnostic.Snippet.Context)
+	assert.Equal(t, "  instance_typ = var.instance_type", diagnostic.Snippet.Code)
+
+	warning := result.Diagnostics[1]
+	assert.Empty(t, warning.Snippet.Context)
+	require.Len(t, warning.Snippet.Values, 1)
+	assert.Equal(t, `is "eu-west-1"`, warning.Snippet.Values[0].Statement)
+
+	assert.Contains(t, result.Result, "Error: Unsupported argument\n\n  on main.tf line 12, in resource \"aws_instance\" \"web\":\n  12:   instance_typ = var.instance_type\n")
+	assert.Contains(t, result.Result, "Warning: Deprecated attribute")
+
+	calls := fake.CallsTo("validate")
+	require.Len(t, calls, 1)
+	assert.Equal(t, []string{"validate", "-json"}, calls[0].Args)
+
+	// The diagnostics are kept with the operation
+	operation, err := impl.store.GetOperationByCommandID(result.CommandId)
+	require.NoError(t, err)
+	assert.Equal(t, 1, operation.ErrorCount)
+	assert.Equal(t, 1, operation.WarningCount)
+	stored, err := decodeMessages(operation.Diagnostics, func() *TerraformStation.TFDiagnostic { return &TerraformStation.TFDiagnostic{} })
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	assert.Equal(t, int32(12), stored[0].Range.Start.Line)
+}
+
+func TestTFValidateValid(t *testing.T) {
+	impl, fake := newFakeTestImpl(t)
+	fake.On("validate", "-json").Stdout(`{"format_version":"1.0","valid":true,"error_count":0,"warning_count":0,"diagnostics":[]}`)
+
+	result, err := impl.TFValidate(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Empty(t, result.Diagnostics)
+	assert.Equal(t, "Success! The configuration is valid.\n", result.Result)
+
+	operation, err := impl.store.GetOperationByCommandID(result.CommandId)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", operation.Diagnostics)
+}
+
+func TestCommandDiagnosticsFromUIEvents(t *testing.T) {
+	output := `{"@level":"info","@message":"OpenTofu 1.8.0","type":"version"}
+{"@level":"error","@message":"Error: Invalid reference","type":"diagnostic","diagnostic":{"severity":"error","summary":"Invalid reference","detail":"A reference to a resource type must be followed by at least one attribute access.","range":{"filename":"main.tf","start":{"line":4,"column":9,"byte":60},"end":{"line":4,"column":12,"byte":63}}}}
+`
+	diagnostics := commandDiagnostics("plan", []string{"plan", "-json"}, output)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "Invalid reference", diagnostics[0].Summary)
+	assert.Equal(t, int32(4), diagnostics[0].Range.Start.Line)
+	assert.Nil(t, diagnostics[0].Snippet)
+
+	// Without -json there is nothing to read
+	assert.Nil(t, commandDiagnostics("plan", []string{"plan"}, output))
+}
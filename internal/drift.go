@@ -0,0 +1,348 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultDriftReportListLimit is the number of drift reports listed when no limit is given
+	defaultDriftReportListLimit = 50
+	// driftWebhookTimeout bounds how long delivering a drift event may take
+	driftWebhookTimeout = 10 * time.Second
+)
+
+// driftEvent is the JSON body posted to the drift webhook
+type driftEvent struct {
+	Event            string    `json:"event"`
+	ReportID         string    `json:"report_id"`
+	WorkingDirectory string    `json:"working_directory"`
+	Workspace        string    `json:"workspace"`
+	ResourceCount    int       `json:"resource_count"`
+	Addresses        []string  `json:"addresses"`
+	Time             time.Time `json:"time"`
+}
+
+// StartDriftScheduler checks each registered working directory for drift on its schedule until ctx is cancelled
+func (impl *TerraformStationImpl) StartDriftScheduler(ctx context.Context) {
+	if !impl.cfg.Drift.Enabled {
+		return
+	}
+
+	for _, target := range impl.cfg.Drift.WorkingDirectories {
+		workingDir, err := TerraformStation.CanonicalWorkingDirectory(target.Path)
+		if err != nil {
+			log.Printf("drift detection disabled for %s: %v", target.Path, err)
+			continue
+		}
+		schedule, err := TerraformStation.ParseCronSchedule(impl.cfg.Drift.ScheduleFor(target))
+		if err != nil {
+			log.Printf("drift detection disabled for %s: %v", workingDir, err)
+			continue
+		}
+		if target.Workspace != "" {
+			if err := TerraformStation.ValidateWorkspaceName(target.Workspace); err != nil {
+				log.Printf("drift detection disabled for %s: %v", workingDir, err)
+				continue
+			}
+		}
+		go impl.driftScheduler(ctx, workingDir, target.Workspace, schedule)
+	}
+}
+
+// driftScheduler checks a workspace of a working directory for drift each time its schedule comes due
+func (impl *TerraformStationImpl) driftScheduler(ctx context.Context, workingDir, workspace string, schedule *TerraformStation.CronSchedule) {
+	for {
+		next := schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Printf("drift schedule of %s never runs", workingDir)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(next)):
+		}
+
+		if _, err := impl.detectDrift(ctx, workingDir, workspace, TerraformStation.DriftTriggerSchedule); err != nil {
+			log.Printf("drift check of %s failed: %v", workingDir, err)
+		}
+	}
+}
+
+// TFDetectDrift checks a working directory for drift straight away and returns the report
+func (impl *TerraformStationImpl) TFDetectDrift(ctx context.Context, input *TerraformStation.TFDriftInput) (*TerraformStation.TFDriftReport, error) {
+	if input == nil {
+		input = &TerraformStation.TFDriftInput{}
+	}
+	workingDir := impl.workingDir
+	if input.WorkingDirectory != "" {
+		workingDir = input.WorkingDirectory
+	}
+	workingDir, err := TerraformStation.CanonicalWorkingDirectory(workingDir)
+	if err != nil {
+		return nil, err
+	}
+	return impl.detectDrift(ctx, workingDir, input.Workspace, TerraformStation.DriftTriggerManual)
+}
+
+// detectDrift runs a refresh-only plan in a workspace of a working directory and records which resources changed
+// outside of OpenTofu. A plan that fails is recorded as a failed report rather than returned as an error.
+func (impl *TerraformStationImpl) detectDrift(ctx context.Context, workingDir, workspace, trigger string) (*TerraformStation.TFDriftReport, error) {
+	reportID := TerraformStation.GenerateCommandID()
+	planFile, err := impl.planFilePath(reportID)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(planFile)
+
+	input := &TerraformStation.TFCommandInput{
+		Command:          "plan",
+		WorkingDirectory: workingDir,
+		Workspace:        workspace,
+		Arguments:        []string{"-refresh-only", "-detailed-exitcode", "-input=false", "-out=" + planFile},
+	}
+	result, operation, err := impl.runCommand(ctx, input, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TerraformStation.TerraformDriftReport{
+		ReportID:   reportID,
+		WorkingDir: workingDir,
+		Workspace:  operation.Workspace,
+		Trigger:    trigger,
+		CommandID:  result.CommandId,
+	}
+
+	var resources []*TerraformStation.TFDriftedResource
+	if !result.Success {
+		report.Status = TerraformStation.DriftStatusFailed
+		report.ErrorMessage = result.ErrorMessage
+	} else if resources, err = impl.showDrift(ctx, workingDir, operation.Workspace, planFile); err != nil {
+		report.Status = TerraformStation.DriftStatusFailed
+		report.ErrorMessage = err.Error()
+	} else if len(resources) > 0 || result.ExitCode == 2 {
+		report.Status = TerraformStation.DriftStatusDrifted
+	} else {
+		report.Status = TerraformStation.DriftStatusClean
+	}
+
+	report.ResourceCount = len(resources)
+	if report.ResourceDrift, err = encodeMessages(resources); err != nil {
+		log.Printf("failed to encode resource drift of report %s: %v", reportID, err)
+	}
+	if report.Event, err = impl.driftEventFor(report); err != nil {
+		return nil, err
+	}
+
+	completedAt := time.Now()
+	report.CompletedAt = &completedAt
+	if err := impl.store.CreateDriftReport(report); err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to record drift report", err.Error())
+	}
+
+	if report.Event != "" {
+		impl.emitDriftEvent(report, resources)
+	}
+	return driftReportToProto(report), nil
+}
+
+// driftEventFor returns the event a report raises by changing the drift status of its workspace,
+// or an empty string if the status is unchanged. Failed checks never raise an event.
+func (impl *TerraformStationImpl) driftEventFor(report *TerraformStation.TerraformDriftReport) (string, error) {
+	if report.Status == TerraformStation.DriftStatusFailed {
+		return "", nil
+	}
+
+	wasDrifted := false
+	previous, err := impl.store.GetLatestDriftOutcome(report.WorkingDir, report.Workspace)
+	if err == nil {
+		wasDrifted = previous.Status == TerraformStation.DriftStatusDrifted
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", TerraformStation.NewExecutionFailedError("failed to read previous drift report", err.Error())
+	}
+
+	switch {
+	case report.Status == TerraformStation.DriftStatusDrifted && !wasDrifted:
+		return TerraformStation.DriftEventDetected, nil
+	case report.Status == TerraformStation.DriftStatusClean && wasDrifted:
+		return TerraformStation.DriftEventResolved, nil
+	}
+	return "", nil
+}
+
+// emitDriftEvent logs a drift event and posts it to the configured webhook in the background
+func (impl *TerraformStationImpl) emitDriftEvent(report *TerraformStation.TerraformDriftReport, resources []*TerraformStation.TFDriftedResource) {
+	event := driftEvent{
+		Event:            report.Event,
+		ReportID:         report.ReportID,
+		WorkingDirectory: report.WorkingDir,
+		Workspace:        report.Workspace,
+		ResourceCount:    report.ResourceCount,
+		Addresses:        make([]string, 0, len(resources)),
+		Time:             report.CompletedAt.UTC(),
+	}
+	for _, resource := range resources {
+		event.Addresses = append(event.Addresses, resource.Address)
+	}
+	log.Printf("%s in %s (workspace %s): %d resources drifted (report %s)",
+		event.Event, event.WorkingDirectory, event.Workspace, event.ResourceCount, event.ReportID)
+
+	webhookURL := impl.cfg.Drift.WebhookURL
+	if webhookURL == "" {
+		return
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("failed to encode drift event %s: %v", event.ReportID, err)
+		return
+	}
+
+	go func() {
+		client := &http.Client{Timeout: driftWebhookTimeout}
+		resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Printf("failed to deliver drift event %s: %v", event.ReportID, err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			log.Printf("drift webhook rejected event %s: %s", event.ReportID, resp.Status)
+		}
+	}()
+}
+
+// showDrift reads the resources that drifted from a saved refresh-only plan, in the workspace it was created in
+func (impl *TerraformStationImpl) showDrift(ctx context.Context, workingDir, workspace, planFile string) ([]*TerraformStation.TFDriftedResource, error) {
+	execResult, err := impl.executorFor(workingDir, workspace).Execute(ctx, workingDir, "show", "-json", planFile)
+	if err != nil {
+		return nil, impl.newRedactor().Error(TerraformStation.NewExecutionFailedError("failed to read saved plan", err.Error(), execResult.Stderr))
+	}
+	return parseResourceDrift(impl.newRedactor(), []byte(execResult.Stdout))
+}
+
+// parseResourceDrift converts the resource drift of a JSON plan into drifted resources
+// listing the attributes that changed
+func parseResourceDrift(redactor *TerraformStation.Redactor, data []byte) ([]*TerraformStation.TFDriftedResource, error) {
+	var plan planJSON
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to parse plan JSON", err.Error())
+	}
+
+	var resources []*TerraformStation.TFDriftedResource
+	for _, rc := range plan.ResourceDrift {
+		resource := &TerraformStation.TFDriftedResource{
+			Address:       rc.Address,
+			ModuleAddress: rc.ModuleAddress,
+			Type:          rc.Type,
+			Name:          rc.Name,
+			ProviderName:  rc.ProviderName,
+			Action:        planAction(rc.Change.Actions),
+		}
+
+		before, err := planInstance(rc.Change.Before, rc.Change.BeforeSensitive)
+		if err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("invalid before value", rc.Address, err.Error())
+		}
+		after, err := planInstance(rc.Change.After, rc.Change.AfterSensitive)
+		if err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("invalid after value", rc.Address, err.Error())
+		}
+		if resource.Attributes, err = diffAttributes(redactor, before, after); err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("invalid resource drift", rc.Address, err.Error())
+		}
+
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// TFListDriftReports lists drift reports, newest first
+func (impl *TerraformStationImpl) TFListDriftReports(ctx context.Context, input *TerraformStation.TFListDriftReportsInput) (*TerraformStation.TFDriftReportList, error) {
+	if input == nil {
+		input = &TerraformStation.TFListDriftReportsInput{}
+	}
+	if input.Limit < 0 || input.Offset < 0 {
+		return nil, TerraformStation.NewInvalidInputError("limit and offset cannot be negative")
+	}
+
+	workingDir := input.WorkingDirectory
+	if workingDir != "" {
+		var err error
+		if workingDir, err = TerraformStation.CanonicalWorkingDirectory(workingDir); err != nil {
+			return nil, err
+		}
+	}
+
+	limit := int(input.Limit)
+	if limit == 0 {
+		limit = defaultDriftReportListLimit
+	}
+
+	reports, err := impl.store.ListDriftReports(limit, int(input.Offset), workingDir, input.Workspace, input.Status)
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to list drift reports", err.Error())
+	}
+
+	list := &TerraformStation.TFDriftReportList{}
+	for i := range reports {
+		list.Reports = append(list.Reports, driftReportToProto(&reports[i]))
+	}
+	return list, nil
+}
+
+// TFGetDriftReport returns a single drift report
+func (impl *TerraformStationImpl) TFGetDriftReport(ctx context.Context, input *TerraformStation.TFDriftReportInput) (*TerraformStation.TFDriftReport, error) {
+	if input == nil || input.ReportId == "" {
+		return nil, TerraformStation.NewInvalidInputError("report id cannot be empty")
+	}
+
+	report, err := impl.store.GetDriftReportByReportID(input.ReportId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, TerraformStation.NewNotFoundError("drift report not found", input.ReportId)
+		}
+		return nil, TerraformStation.NewExecutionFailedError("failed to read drift report", err.Error())
+	}
+	return driftReportToProto(report), nil
+}
+
+// driftReportToProto converts a persisted drift report to its API representation
+func driftReportToProto(report *TerraformStation.TerraformDriftReport) *TerraformStation.TFDriftReport {
+	out := &TerraformStation.TFDriftReport{
+		ReportId:         report.ReportID,
+		WorkingDirectory: report.WorkingDir,
+		Workspace:        report.Workspace,
+		Status:           report.Status,
+		Trigger:          report.Trigger,
+		Event:            report.Event,
+		CommandId:        report.CommandID,
+		ResourceCount:    int32(report.ResourceCount),
+		ErrorMessage:     report.ErrorMessage,
+		CreatedAt:        timestamppb.New(report.CreatedAt),
+	}
+	if report.CompletedAt != nil {
+		out.CompletedAt = timestamppb.New(*report.CompletedAt)
+	}
+
+	resources, err := decodeMessages(report.ResourceDrift, func() *TerraformStation.TFDriftedResource {
+		return &TerraformStation.TFDriftedResource{}
+	})
+	if err != nil {
+		log.Printf("failed to decode resource drift of report %s: %v", report.ReportID, err)
+	}
+	out.Resources = resources
+	return out
+}
@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDriftTestImpl creates an implementation whose refresh-only plan reports the drift in
+// testdata/drift.json while a file named drifted exists in the working directory
+func newDriftTestImpl(t *testing.T) *TerraformStationImpl {
+	return newScriptTestImpl(t, `case "$1" in
+plan) `+writePlanFile+`; [ -f drifted ] && exit 2; exit 0 ;;
+show) if [ -f drifted ]; then cat "`+testdataPath(t, "drift.json")+`"; else echo '{"format_version":"1.2"}'; fi ;;
+esac
+`)
+}
+
+func TestDetectDriftEvents(t *testing.T) {
+	impl := newDriftTestImpl(t)
+	ctx := context.Background()
+
+	events := make(chan driftEvent, 2)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event driftEvent
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		events <- event
+	}))
+	defer webhook.Close()
+	impl.cfg.Drift.WebhookURL = webhook.URL
+
+	// A first clean check raises no event
+	report, err := impl.TFDetectDrift(ctx, &TerraformStation.TFDriftInput{})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.DriftStatusClean, report.Status)
+	assert.Equal(t, TerraformStation.DriftTriggerManual, report.Trigger)
+	assert.Empty(t, report.Event)
+
+	require.NoError(t, os.WriteFile(filepath.Join(impl.workingDir, "drifted"), nil, 0644))
+	drifted, err := impl.TFDetectDrift(ctx, &TerraformStation.TFDriftInput{})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.DriftStatusDrifted, drifted.Status)
+	assert.Equal(t, TerraformStation.DriftEventDetected, drifted.Event)
+	assert.Equal(t, int32(3), drifted.ResourceCount)
+	assert.NotEmpty(t, drifted.CommandId)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, TerraformStation.DriftEventDetected, event.Event)
+		assert.Equal(t, drifted.ReportId, event.ReportID)
+		assert.Equal(t, impl.workingDir, event.WorkingDirectory)
+		assert.Equal(t, []string{"aws_security_group.web", "module.db.aws_db_instance.main", "aws_s3_bucket.logs"}, event.Addresses)
+	case <-time.After(5 * time.Second):
+		t.Fatal("drift event was not delivered")
+	}
+
+	// Drift that persists raises no further event
+	again, err := impl.TFDetectDrift(ctx, &TerraformStation.TFDriftInput{})
+	require.NoError(t, err)
+	assert.Empty(t, again.Event)
+
+	require.NoError(t, os.Remove(filepath.Join(impl.workingDir, "drifted")))
+	resolved, err := impl.TFDetectDrift(ctx, &TerraformStation.TFDriftInput{})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.DriftStatusClean, resolved.Status)
+	assert.Equal(t, TerraformStation.DriftEventResolved, resolved.Event)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, TerraformStation.DriftEventResolved, event.Event)
+	case <-time.After(5 * time.Second):
+		t.Fatal("drift event was not delivered")
+	}
+
+	list, err := impl.TFListDriftReports(ctx, &TerraformStation.TFListDriftReportsInput{Status: TerraformStation.DriftStatusDrifted})
+	require.NoError(t, err)
+	require.Len(t, list.Reports, 2)
+	assert.Equal(t, again.ReportId, list.Reports[0].ReportId)
+
+	stored, err := impl.TFGetDriftReport(ctx, &TerraformStation.TFDriftReportInput{ReportId: drifted.ReportId})
+	require.NoError(t, err)
+	require.Len(t, stored.Resources, 3)
+	assert.Equal(t, "module.db", stored.Resources[1].ModuleAddress)
+
+	_, err = impl.TFGetDriftReport(ctx, &TerraformStation.TFDriftReportInput{ReportId: "tofu_missing"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeNotFound)
+}
+
+func TestDetectDriftFailedPlan(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo 'Error: No valid credential sources found' >&2\nexit 1\n")
+
+	report, err := impl.TFDetectDrift(context.Background(), &TerraformStation.TFDriftInput{})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.DriftStatusFailed, report.Status)
+	assert.NotEmpty(t, report.ErrorMessage)
+	assert.Empty(t, report.Event)
+}
+
+func TestDetectDriftInWorkspace(t *testing.T) {
+	impl := newScriptTestImpl(t, `case "$1" in
+plan) `+writePlanFile+`; exit 2 ;;
+show) [ "$TF_WORKSPACE" = staging ] || { echo "wrong workspace" >&2; exit 1; }; cat "`+testdataPath(t, "drift.json")+`" ;;
+esac
+`)
+
+	report, err := impl.TFDetectDrift(context.Background(), &TerraformStation.TFDriftInput{Workspace: "staging"})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.DriftStatusDrifted, report.Status, report.ErrorMessage)
+	assert.Equal(t, "staging", report.Workspace)
+	assert.Equal(t, int32(3), report.ResourceCount)
+}
+
+func TestParseResourceDrift(t *testing.T) {
+	data, err := os.ReadFile(testdataPath(t, "drift.json"))
+	require.NoError(t, err)
+
+	resources, err := parseResourceDrift(TerraformStation.NewRedactor(nil), data)
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+
+	web := resources[0]
+	assert.Equal(t, TerraformStation.ActionUpdate, web.Action)
+	require.Len(t, web.Attributes, 2)
+	assert.Equal(t, "ingress.0.cidr_blocks.0", web.Attributes[0].Path)
+	assert.Equal(t, "10.0.0.0/8", web.Attributes[0].Before.GetStringValue())
+	assert.Equal(t, "0.0.0.0/0", web.Attributes[0].After.GetStringValue())
+	assert.Equal(t, "tags.edited", web.Attributes[1].Path)
+	assert.Nil(t, web.Attributes[1].Before)
+
+	db := resources[1]
+	require.Len(t, db.Attributes, 1)
+	assert.Equal(t, "password", db.Attributes[0].Path)
+	assert.Equal(t, sensitiveValue, db.Attributes[0].Before.GetStringValue())
+	assert.Equal(t, sensitiveValue, db.Attributes[0].After.GetStringValue())
+
+	logs := resources[2]
+	assert.Equal(t, TerraformStation.ActionDelete, logs.Action)
+	require.Len(t, logs.Attributes, 1)
+	assert.Equal(t, "logs", logs.Attributes[0].Before.GetStringValue())
+	assert.Nil(t, logs.Attributes[0].After)
+}
@@ -2,17 +2,26 @@ package internal
 
 import (
 	"context"
-	"strings"
+	"os"
+	"regexp"
 
 	"github.com/ForestMars/TerraformStation"
 	"gorm.io/gorm"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type TerraformStationImpl struct {
 	db             *gorm.DB
+	store          *TerraformStation.DatabaseManager
 	cfg            *TerraformStation.Config
-	executor       *TerraformStation.OpenTofuExecutor
+	executor       TerraformStation.Executor
+	broker         *outputBroker
+	jobs           *jobRunner
+	operations     *operationRunner
+	secrets        *TerraformStation.SecretCipher
+	redaction      []*regexp.Regexp
+	owner          string
 	workingDir     string
 }
 
@@ -21,17 +30,41 @@ func New(db *gorm.DB, cfg *TerraformStation.Config) (*TerraformStationImpl, erro
 		return nil, TerraformStation.NewInvalidInputError("configuration cannot be nil")
 	}
 
+	// Create opentofu executor
+	executor := TerraformStation.NewOpenTofuExecutor(cfg.OpenTofuPath, cfg.Timeout).
+		WithGracePeriod(cfg.InterruptGracePeriod).
+		WithAllowedEnv(cfg.Environment.Allowlist)
+	return NewWithExecutor(db, cfg, executor)
+}
+
+// NewWithExecutor creates the service with the executor that runs its OpenTofu commands
+func NewWithExecutor(db *gorm.DB, cfg *TerraformStation.Config, executor TerraformStation.Executor) (*TerraformStationImpl, error) {
+	if cfg == nil {
+		return nil, TerraformStation.NewInvalidInputError("configuration cannot be nil")
+	}
+
 	if db == nil {
 		return nil, TerraformStation.NewInvalidInputError("database connection cannot be nil")
 	}
 
-	// Create opentofu executor
-	executor := TerraformStation.NewOpenTofuExecutor(cfg.OpenTofuPath, cfg.Timeout)
+	if executor == nil {
+		return nil, TerraformStation.NewInvalidInputError("executor cannot be nil")
+	}
+
+	store, err := TerraformStation.NewDatabaseManagerWithDB(db)
+	if err != nil {
+		return nil, err
+	}
 
 	impl := &TerraformStationImpl{
 		db:         db,
+		store:      store,
 		cfg:        cfg,
 		executor:   executor,
+		broker:     newOutputBroker(),
+		jobs:       newJobRunner(),
+		operations: newOperationRunner(),
+		owner:      stationOwner(),
 		workingDir: cfg.WorkingDirectory,
 	}
 
@@ -40,102 +73,318 @@ func New(db *gorm.DB, cfg *TerraformStation.Config) (*TerraformStationImpl, erro
 		return nil, err
 	}
 
+	// Output is masked with the configured patterns
+	if impl.redaction, err = cfg.Redaction.Compile(); err != nil {
+		return nil, TerraformStation.NewInvalidInputError("invalid redaction configuration", err.Error())
+	}
+
+	// The secret store is only available with a master key
+	if cfg.Secrets.MasterKeyFile != "" {
+		if impl.secrets, err = TerraformStation.LoadSecretCipher(cfg.Secrets.MasterKeyFile); err != nil {
+			return nil, TerraformStation.NewInvalidInputError("failed to load secrets master key", err.Error())
+		}
+	}
+
 	return impl, nil
 }
 
 // TFCommand executes a generic OpenTofu command
 func (impl *TerraformStationImpl) TFCommand(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFCommandResult, error) {
+	if err := impl.requireReviewedPlan(input); err != nil {
+		return nil, err
+	}
+
+	result, _, err := impl.runCommand(ctx, input, nil)
+	return result, err
+}
+
+// runCommand executes an OpenTofu command, recording the operation and its output as it is produced
+func (impl *TerraformStationImpl) runCommand(ctx context.Context, input *TerraformStation.TFCommandInput, handler TerraformStation.OutputHandler) (*TerraformStation.TFCommandResult, *TerraformStation.TerraformOperation, error) {
+	result, operation, _, err := impl.runCommandOutput(ctx, input, handler)
+	return result, operation, err
+}
+
+// unmaskedOutput is the stdout of a run before masking, for callers that parse it, along with the
+// redactor that masks what they parse from it. It must never leave the station as it is.
+type unmaskedOutput struct {
+	stdout   string
+	redactor *TerraformStation.Redactor
+}
+
+// runCommandOutput runs a command like runCommand, also returning its unmasked stdout. Masking a
+// document as text can break its structure, so parse the unmasked stdout and mask the result instead.
+func (impl *TerraformStationImpl) runCommandOutput(ctx context.Context, input *TerraformStation.TFCommandInput, handler TerraformStation.OutputHandler) (*TerraformStation.TFCommandResult, *TerraformStation.TerraformOperation, *unmaskedOutput, error) {
 	// Validate input
 	if err := TerraformStation.ValidateTFCommandInput(input); err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
-	// Use working directory from input or fallback to configured one
-	workingDir := impl.workingDir
-	if input.WorkingDirectory != "" {
-		workingDir = input.WorkingDirectory
+	workingDir, err := impl.resolveWorkingDir(input)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Decrypt the secrets the command uses, writing secret variables to a var-file kept off the command line
+	secrets, err := impl.resolveSecrets(input)
+	if err != nil {
+		return nil, nil, nil, err
 	}
+	defer secrets.cleanup()
 
 	// Build command arguments
-	args := TerraformStation.BuildOpenTofuArgs(input.Command, input)
+	args, err := TerraformStation.BuildCommandArgs(input, secrets.varFiles()...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Serialize runs that could modify the same working directory and workspace
+	workspace := resolveWorkspace(workingDir, input)
+	commandID := TerraformStation.GenerateCommandID()
+	lock, err := impl.lockWorkingDir(ctx, workingDir, workspace, input, commandID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer lock.release()
+
+	// Another run may have changed the state while this one waited for the lock
+	if plan := claimedPlan(ctx); plan != nil {
+		if err := impl.checkPlanFresh(plan); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	// Secret values, credentials and values marked sensitive never reach subscribers or the database
+	redactor := impl.newRedactor(secrets.secretValues()...)
+
+	// Record the operation before it starts, registering it so it can be cancelled. Subscribers are
+	// woken through the broker from the moment the operation can be seen until its outcome is recorded.
+	ctx, stop := impl.operations.start(ctx, commandID)
+	defer stop()
+	impl.broker.start(commandID)
+	defer impl.broker.finish(commandID)
+	operation, err := impl.startOperation(commandID, workingDir, workspace, input, args, redactor)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	stopHeartbeat := impl.heartbeatOperation(commandID)
+	impl.linkJobOperation(ctx, commandID)
+
+	// Execute command, recording output for subscribers
+	recorder := impl.newOutputRecorder(ctx, commandID, handler)
+	onLine := recorder.record
+	if usesUIStream(input.Command, args) {
+		onLine = TerraformStation.ParseUIStream(onLine, recorder.recordEvent)
+	}
+	onLine = redactor.Lines(onLine)
+	flushLines := func() {}
+	if emitsValuesJSON(input.Command, args) {
+		onLine, flushLines = learnSensitiveValues(redactor, onLine)
+	}
+	executor := impl.executorFor(workingDir, input.Workspace)
+	if input.Command == "workspace" {
+		executor = impl.workspaceCommandExecutor(workingDir)
+	}
+	if env := secrets.environment(); len(env) > 0 {
+		executor = executor.WithEnv(env...)
+	}
+	execResult, err := executor.ExecuteStream(ctx, workingDir, onLine, args...)
+	flushLines()
+	unmasked := &unmaskedOutput{stdout: execResult.Stdout, redactor: redactor}
+	execResult.Output = redactor.Redact(execResult.Output)
+	execResult.Stdout = redactor.Redact(execResult.Stdout)
+	execResult.Stderr = redactor.Redact(execResult.Stderr)
 
-	// Execute command
-	output, err := impl.executor.Execute(ctx, workingDir, args...)
-	
 	// Create result
 	result := &TerraformStation.TFCommandResult{
-		CommandId:   TerraformStation.GenerateCommandID(),
+		CommandId:   commandID,
 		ExecutedAt:  timestamppb.Now(),
-		Result:      output,
+		Result:      execResult.Output,
+		Stdout:      execResult.Stdout,
+		Stderr:      execResult.Stderr,
+		ExitCode:    int32(execResult.ExitCode),
+		DurationMs:  execResult.Duration.Milliseconds(),
+		Signal:      execResult.Signal,
+		TimedOut:    execResult.TimedOut,
+		Cancelled:   execResult.Cancelled,
+		Success:     err == nil,
 	}
 
-	if err != nil {
-		result.Success = false
-		result.ErrorMessage = err.Error()
-		result.ExitCode = 1
-	} else {
+	result.Diagnostics = commandDiagnostics(input.Command, args, unmasked.stdout)
+	redactDiagnostics(redactor, result.Diagnostics)
+	result.ErrorCount, result.WarningCount = countDiagnostics(result.Diagnostics)
+
+	// With -detailed-exitcode, plan exits with 2 when changes are present
+	if err != nil && execResult.ExitCode == 2 && usesDetailedExitCode(input.Command, args) {
 		result.Success = true
-		result.ExitCode = 0
 	}
 
-	return result, nil
+	if !result.Success {
+		result.ErrorMessage = redactor.Redact(err.Error())
+	}
+
+	stopHeartbeat()
+	impl.completeOperation(operation, result)
+
+	// Keep a version of the local state if the command changed it, while the lock still keeps other runs out
+	if lock != nil {
+		impl.captureStateVersion(localState{workingDir, workspace}, input, commandID)
+	}
+
+	return result, operation, unmasked, nil
 }
 
-// TFPlan executes opentofu plan
+// resolveWorkingDir returns the canonical working directory of an input, falling back to the configured one
+func (impl *TerraformStationImpl) resolveWorkingDir(input *TerraformStation.TFCommandInput) (string, error) {
+	workingDir := impl.workingDir
+	if input.WorkingDirectory != "" {
+		workingDir = input.WorkingDirectory
+	}
+	return TerraformStation.CanonicalWorkingDirectory(workingDir)
+}
+
+// usesDetailedExitCode reports whether a command was run with plan's -detailed-exitcode flag
+func usesDetailedExitCode(command string, args []string) bool {
+	if command != "plan" {
+		return false
+	}
+	for _, arg := range args {
+		if arg == "-detailed-exitcode" {
+			return true
+		}
+	}
+	return false
+}
+
+// TFPlan executes opentofu plan, saving the plan under its plan ID so it can be reviewed and applied later
 func (impl *TerraformStationImpl) TFPlan(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFPlanResult, error) {
 	// Override command to ensure it's plan
 	input.Command = "plan"
 
-	// Execute plan command
-	result, err := impl.TFCommand(ctx, input)
+	// The station owns plan files, so callers cannot choose where the plan is written
+	if hasFlag(input.Arguments, "-out") {
+		return nil, TerraformStation.NewInvalidInputError("-out cannot be set, plans are saved by the station")
+	}
+
+	planID := TerraformStation.GenerateCommandID()
+	planFile, err := impl.planFilePath(planID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse plan output to determine if there are changes
-	hasChanges := parsePlanOutput(result.Result)
-	resourceCount := countResourcesInPlan(result.Result)
+	// Use the machine-readable UI so progress is reported per resource
+	planInput := proto.Clone(input).(*TerraformStation.TFCommandInput)
+	planInput.Arguments = appendMissingFlags(planInput.Arguments, "-json")
+	planInput.Arguments = append(planInput.Arguments, "-out="+planFile)
+
+	// Execute plan command
+	result, operation, err := impl.runCommand(ctx, planInput, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	planResult := &TerraformStation.TFPlanResult{
-		PlanId:       TerraformStation.GenerateCommandID(),
-		PlanOutput:   result.Result,
-		HasChanges:   hasChanges,
-		ResourceCount: int32(resourceCount),
+		PlanId:       planID,
+		PlanOutput:   TerraformStation.RenderUIOutput(result.Result),
 		CreatedAt:    timestamppb.Now(),
-		Status:       "completed",
+		Status:       TerraformStation.PlanStatusCompleted,
 	}
 
+	// Values the saved plan marks sensitive are masked in the plan result as well
+	redactor := impl.newRedactor()
+	var checksum string
 	if !result.Success {
-		planResult.Status = "failed"
+		planResult.Status = TerraformStation.PlanStatusFailed
+		planResult.ErrorMessage = result.ErrorMessage
+	} else if checksum, err = fileChecksum(planFile); err != nil {
+		planResult.Status = TerraformStation.PlanStatusFailed
+		planResult.ErrorMessage = "plan file was not written: " + err.Error()
+	} else if changes, err := impl.showPlan(ctx, operation.WorkingDir, operation.Workspace, planFile, redactor); err != nil {
+		planResult.Status = TerraformStation.PlanStatusFailed
+		planResult.ErrorMessage = err.Error()
+	} else {
+		setPlanChanges(planResult, changes)
+		impl.requestApproval(planResult, operation.WorkingDir)
+	}
+
+	planResult.PlanOutput = redactor.Redact(planResult.PlanOutput)
+	planResult.ErrorMessage = redactor.Redact(planResult.ErrorMessage)
+
+	// Failed plans can never be applied, so their files are not kept
+	if planResult.Status == TerraformStation.PlanStatusFailed {
+		os.Remove(planFile)
+	}
+
+	if err := impl.recordPlan(operation, planResult, planFile, checksum); err != nil {
+		return nil, err
 	}
 
 	return planResult, nil
 }
 
-// TFApply executes opentofu apply
+// setPlanChanges stores resource changes on a plan result along with the counts derived from them
+func setPlanChanges(planResult *TerraformStation.TFPlanResult, changes []*TerraformStation.TFResourceChange) {
+	add, change, destroy, replace := summarizeChanges(changes)
+
+	planResult.ResourceChanges = changes
+	planResult.ToAdd = int32(add)
+	planResult.ToChange = int32(change)
+	planResult.ToDestroy = int32(destroy)
+	planResult.ToReplace = int32(replace)
+	planResult.ResourceCount = int32(add + change + destroy - replace)
+	planResult.HasChanges = planResult.ResourceCount > 0
+}
+
+// TFApply executes opentofu apply. When a plan ID is given, exactly that saved plan is applied.
 func (impl *TerraformStationImpl) TFApply(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFApplyResult, error) {
 	// Override command to ensure it's apply
 	input.Command = "apply"
 
+	applyInput := proto.Clone(input).(*TerraformStation.TFCommandInput)
+
+	var plan *TerraformStation.TerraformPlan
+	if input.PlanId != "" {
+		var err error
+		if plan, err = impl.claimPlan(input); err != nil {
+			return nil, err
+		}
+		applyInput.PlanFile = plan.PlanFile
+		applyInput.Workspace = plan.Workspace
+		// Count progress against the saved plan in case apply does not list the planned changes first
+		ctx = withPlannedChanges(ctx, plan.ResourceCount)
+		ctx = withClaimedPlan(ctx, plan)
+	} else if err := impl.requireReviewedPlan(input); err != nil {
+		return nil, err
+	}
+
+	// Use the machine-readable UI so resource counts and progress can be read reliably
+	applyInput.Arguments = appendMissingFlags(applyInput.Arguments, "-auto-approve", "-json")
+
 	// Execute apply command
-	result, err := impl.TFCommand(ctx, input)
+	result, operation, err := impl.runCommand(ctx, applyInput, nil)
+	if plan != nil {
+		impl.releasePlan(plan, err == nil)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse apply output to count resources
-	resourcesAdded, resourcesChanged, resourcesDestroyed := parseApplyOutput(result.Result)
+	summary := summarizeApply(TerraformStation.ParseUIEvents(result.Result))
 
 	applyResult := &TerraformStation.TFApplyResult{
 		ApplyId:           TerraformStation.GenerateCommandID(),
-		ApplyOutput:       result.Result,
+		ApplyOutput:       TerraformStation.RenderUIOutput(result.Result),
 		Success:           result.Success,
-		ResourcesAdded:    int32(resourcesAdded),
-		ResourcesChanged:  int32(resourcesChanged),
-		ResourcesDestroyed: int32(resourcesDestroyed),
+		ResourcesAdded:    int32(summary.added),
+		ResourcesChanged:  int32(summary.changed),
+		ResourcesDestroyed: int32(summary.destroyed),
+		ResourcesFailed:   int32(summary.failed),
+		ResourceApplies:   summary.applies,
 		ExecutedAt:        timestamppb.Now(),
+		PlanId:            input.PlanId,
 	}
 
+	impl.recordApply(operation, applyResult)
+
 	return applyResult, nil
 }
 
@@ -145,34 +394,65 @@ func (impl *TerraformStationImpl) TFInit(ctx context.Context, input *TerraformSt
 	return impl.TFCommand(ctx, input)
 }
 
-// TFValidate executes opentofu validate
+// TFValidate executes opentofu validate, returning the errors and warnings as structured diagnostics
 func (impl *TerraformStationImpl) TFValidate(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFCommandResult, error) {
 	input.Command = "validate"
-	return impl.TFCommand(ctx, input)
+	validateInput := proto.Clone(input).(*TerraformStation.TFCommandInput)
+	validateInput.Arguments = appendMissingFlags(validateInput.Arguments, "-json")
+
+	result, err := impl.TFCommand(ctx, validateInput)
+	if err != nil {
+		return nil, err
+	}
+
+	// Present the JSON document as the text OpenTofu would have printed. Diagnostics are only
+	// missing, rather than empty, when stdout was not a JSON document.
+	if result.Diagnostics != nil {
+		if result.Success && len(result.Diagnostics) == 0 {
+			result.Result = "Success! The configuration is valid.\n"
+		} else {
+			result.Result = renderDiagnostics(result.Diagnostics)
+		}
+	}
+	return result, nil
 }
 
 // TFState retrieves opentofu state information
 func (impl *TerraformStationImpl) TFState(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFStateInfo, error) {
-	// Use opentofu show to get state information
+	// Use the JSON representation of opentofu show to get state information
 	input.Command = "show"
-	
-	result, err := impl.TFCommand(ctx, input)
+	showInput := proto.Clone(input).(*TerraformStation.TFCommandInput)
+	showInput.Arguments = appendMissingFlags(showInput.Arguments, "-json")
+
+	result, operation, unmasked, err := impl.runCommandOutput(ctx, showInput, nil)
 	if err != nil {
 		return nil, err
 	}
+	if !result.Success {
+		return nil, TerraformStation.NewExecutionFailedError("failed to read state", result.ErrorMessage, result.Stderr)
+	}
 
-	// Parse state output
-	resourceCount := countResourcesInState(result.Result)
-	terraformVersion := extractOpenTofuVersion(result.Result)
-
-	stateInfo := &TerraformStation.TFStateInfo{
-		StateId:          TerraformStation.GenerateCommandID(),
-		StateFile:        input.StateFile,
-		ResourceCount:    int32(resourceCount),
-		LastUpdated:      timestamppb.Now(),
-		TerraformVersion: terraformVersion,
+	stateInfo, err := parseStateJSON([]byte(unmasked.stdout))
+	if err != nil {
+		return nil, unmasked.redactor.Error(err)
+	}
+	redactStateInfo(unmasked.redactor, stateInfo)
+	snapshot, err := redactJSON(unmasked.redactor, []byte(unmasked.stdout))
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to parse state JSON", err.Error())
+	}
+	stateInfo.StateId = TerraformStation.GenerateCommandID()
+	stateInfo.StateFile = input.StateFile
+	stateInfo.LastUpdated = timestamppb.Now()
+	stateInfo.CommandId = result.CommandId
+
+	if meta := impl.stateMeta(ctx, localState{operation.WorkingDir, operation.Workspace}, input.StateFile); meta != nil {
+		stateInfo.Serial = meta.Serial
+		stateInfo.Lineage = meta.Lineage
 	}
 
+	impl.recordState(operation, stateInfo, snapshot)
+
 	return stateInfo, nil
 }
 
@@ -194,82 +474,3 @@ func (impl *TerraformStationImpl) SetWorkingDirectory(dir string) error {
 func (impl *TerraformStationImpl) ValidateWorkingDirectory(dir string) error {
 	return impl.executor.ValidateWorkingDirectory(dir)
 }
-
-// Helper functions for parsing OpenTofu output
-
-func parsePlanOutput(output string) bool {
-	// Simple heuristic: if output contains "No changes" then no changes
-	return !contains(output, "No changes")
-}
-
-func countResourcesInPlan(output string) int {
-	// Simple counting of resource mentions in plan output
-	// This is a basic implementation - in production you might want more sophisticated parsing
-	count := 0
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		if strings.Contains(line, "+ ") || strings.Contains(line, "- ") || strings.Contains(line, "~ ") {
-			count++
-		}
-	}
-	return count
-}
-
-func parseApplyOutput(output string) (added, changed, destroyed int) {
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		if strings.Contains(line, "Resources:") {
-			// Parse the resources summary line
-			// Example: "Resources: 2 added, 0 changed, 0 destroyed"
-			// This is a simplified parser - in production you'd want more robust parsing
-			if strings.Contains(line, "added") {
-				added = 1 // Simplified for now
-			}
-			if strings.Contains(line, "changed") {
-				changed = 1 // Simplified for now
-			}
-			if strings.Contains(line, "destroyed") {
-				destroyed = 1 // Simplified for now
-			}
-		}
-	}
-	return
-}
-
-func countResourcesInState(output string) int {
-	// Count resource blocks in state output
-	count := 0
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		if strings.Contains(line, "resource \"") {
-			count++
-		}
-	}
-	return count
-}
-
-func extractOpenTofuVersion(output string) string {
-	// Extract OpenTofu version from output
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		if strings.Contains(line, "OpenTofu v") {
-			// Extract version number
-			parts := strings.Split(line, "OpenTofu v")
-			if len(parts) > 1 {
-				return strings.TrimSpace(parts[1])
-			}
-		}
-		// Also check for "Terraform v" as OpenTofu might still show this
-		if strings.Contains(line, "Terraform v") {
-			parts := strings.Split(line, "Terraform v")
-			if len(parts) > 1 {
-				return strings.TrimSpace(parts[1])
-			}
-		}
-	}
-	return "unknown"
-}
-
-func contains(s, substr string) bool {
-	return strings.Contains(s, substr)
-}
@@ -2,9 +2,12 @@ package internal
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ForestMars/TerraformStation"
+	"github.com/ForestMars/TerraformStation/tofutest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/driver/sqlite"
@@ -52,10 +55,14 @@ func TestTFCommand(t *testing.T) {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	require.NoError(t, err)
 
+	// Run against a fake tofu binary
+	fake := tofutest.New(t)
+	fake.On("init").Stdout("OpenTofu has been successfully initialized!\n")
+
 	// Create test configuration
 	cfg := TerraformStation.DefaultConfig()
-	cfg.OpenTofuPath = "echo" // Use echo for testing
-	cfg.WorkingDirectory = "/tmp"
+	cfg.OpenTofuPath = fake.Path
+	cfg.WorkingDirectory = t.TempDir()
 
 	// Create implementation
 	impl, err := New(db, cfg)
@@ -63,21 +70,84 @@ func TestTFCommand(t *testing.T) {
 
 	// Test valid command
 	input := &TerraformStation.TFCommandInput{
-		Command: "version",
+		Command:   "init",
+		Arguments: []string{"-input=false"},
+		Variables: map[string]string{"region": "us-west-2", "env": "prod"},
 	}
 
 	ctx := context.Background()
 	result, err := impl.TFCommand(ctx, input)
-	
-	// Since we're using echo, this should fail, but we can test the structure
-	if err != nil {
-		// Expected error for invalid terraform command
-		assert.Contains(t, err.Error(), "terraform")
-	} else {
-		assert.NotNil(t, result)
-		assert.NotEmpty(t, result.CommandId)
-		assert.NotNil(t, result.ExecutedAt)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.NotEmpty(t, result.CommandId)
+	assert.NotNil(t, result.ExecutedAt)
+	assert.Equal(t, "OpenTofu has been successfully initialized!\n", result.Stdout)
+
+	calls := fake.CallsTo("init")
+	require.Len(t, calls, 1)
+	assert.Equal(t, []string{"init", "-input=false", "-var=env=prod", "-var=region=us-west-2"}, calls[0].Args)
+
+	// Invalid commands never reach the binary
+	_, err = impl.TFCommand(ctx, &TerraformStation.TFCommandInput{Command: "validate", Variables: map[string]string{"env": "prod"}})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+	assert.Empty(t, fake.CallsTo("validate"))
+}
+
+// recordingExecutor answers every command with a fixed result instead of running OpenTofu.
+// Copies made by WithEnv record their calls, followed by their environment, on the original.
+type recordingExecutor struct {
+	result *TerraformStation.ExecutionResult
+	env    []string
+	calls  [][]string
+	parent *recordingExecutor
+}
+
+func (e *recordingExecutor) Execute(ctx context.Context, workingDir string, args ...string) (*TerraformStation.ExecutionResult, error) {
+	return e.ExecuteStream(ctx, workingDir, nil, args...)
+}
+
+func (e *recordingExecutor) ExecuteStream(ctx context.Context, workingDir string, onLine TerraformStation.LineHandler, args ...string) (*TerraformStation.ExecutionResult, error) {
+	root := e
+	for root.parent != nil {
+		root = root.parent
 	}
+	root.calls = append(root.calls, append(args, e.env...))
+	if onLine != nil {
+		onLine(TerraformStation.StreamStdout, strings.TrimSuffix(e.result.Stdout, "\n"))
+	}
+	return e.result, nil
+}
+
+func (e *recordingExecutor) ValidateWorkingDirectory(dir string) error {
+	return nil
+}
+
+func (e *recordingExecutor) WithEnv(env ...string) TerraformStation.Executor {
+	scoped := *e
+	scoped.env = append(append([]string(nil), e.env...), env...)
+	scoped.parent = e
+	return &scoped
+}
+
+func TestNewWithExecutor(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	cfg := TerraformStation.DefaultConfig()
+	cfg.WorkingDirectory = t.TempDir()
+
+	executor := &recordingExecutor{result: &TerraformStation.ExecutionResult{Output: "OpenTofu v1.8.0\n", Stdout: "OpenTofu v1.8.0\n"}}
+	impl, err := NewWithExecutor(db, cfg, executor)
+	require.NoError(t, err)
+
+	result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "version"})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "OpenTofu v1.8.0\n", result.Stdout)
+	assert.Equal(t, [][]string{{"version", "TF_WORKSPACE=default"}}, executor.calls)
+
+	_, err = NewWithExecutor(db, cfg, nil)
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
 }
 
 func TestValidateWorkingDirectory(t *testing.T) {
@@ -119,3 +189,55 @@ func TestGetConfig(t *testing.T) {
 	retrievedCfg := impl.GetConfig()
 	assert.Equal(t, cfg, retrievedCfg)
 }
+
+func TestTFCommandExitCodeAndStderr(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo \"out\"\necho \"err\" >&2\nexit 3\n")
+
+	result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "version"})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, int32(3), result.ExitCode)
+	assert.Equal(t, "out\n", result.Stdout)
+	assert.Equal(t, "err\n", result.Stderr)
+	assert.Contains(t, result.Result, "out")
+	assert.Contains(t, result.Result, "err")
+	assert.False(t, result.TimedOut)
+	assert.Empty(t, result.Signal)
+
+	operation, err := impl.store.GetOperationByCommandID(result.CommandId)
+	require.NoError(t, err)
+	assert.Equal(t, 3, operation.ExitCode)
+	assert.Equal(t, "err\n", operation.Stderr)
+}
+
+func TestTFCommandDetailedExitCode(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo \"changes present\"\nexit 2\n")
+
+	result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{
+		Command:   "plan",
+		Arguments: []string{"-detailed-exitcode"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, int32(2), result.ExitCode)
+
+	// Exit code 2 is still a failure without -detailed-exitcode
+	result, err = impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "plan"})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+}
+
+func TestTFCommandTimeout(t *testing.T) {
+	impl := newScriptTestImpl(t, "sleep 5\n")
+	impl.executor = TerraformStation.NewOpenTofuExecutor(impl.cfg.OpenTofuPath, 100*time.Millisecond)
+
+	result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "version"})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.True(t, result.TimedOut)
+	// The script is interrupted first, and stops without needing to be killed
+	assert.Equal(t, "interrupt", result.Signal)
+	assert.Equal(t, int32(-1), result.ExitCode)
+	assert.Contains(t, result.ErrorMessage, TerraformStation.ErrCodeTimeout)
+	assert.Less(t, result.DurationMs, int64(5000))
+}
@@ -0,0 +1,477 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// defaultJobListLimit is the number of jobs listed when no limit is given
+const defaultJobListLimit = 50
+
+// jobContextKey carries the ID of the job a command runs for
+type jobContextKey struct{}
+
+// jobRunner tracks the jobs running in this process so they can be cancelled
+type jobRunner struct {
+	mu        sync.Mutex
+	running   map[string]context.CancelFunc
+	cancelled map[string]bool
+	wake      chan struct{}
+}
+
+func newJobRunner() *jobRunner {
+	return &jobRunner{
+		running:   make(map[string]context.CancelFunc),
+		cancelled: make(map[string]bool),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// notify wakes an idle worker to pick up a newly queued job
+func (r *jobRunner) notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// start registers a running job and returns the context it runs under
+func (r *jobRunner) start(ctx context.Context, jobID string) context.Context {
+	ctx, cancel := context.WithCancel(context.WithValue(ctx, jobContextKey{}, jobID))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.running[jobID] = cancel
+	return ctx
+}
+
+// finish unregisters a job, reporting whether it was cancelled while running
+func (r *jobRunner) finish(jobID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cancel, ok := r.running[jobID]; ok {
+		cancel()
+	}
+	cancelled := r.cancelled[jobID]
+	delete(r.running, jobID)
+	delete(r.cancelled, jobID)
+	return cancelled
+}
+
+// cancel stops a running job, reporting whether it was running in this process
+func (r *jobRunner) cancel(jobID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cancel, ok := r.running[jobID]
+	if !ok {
+		return false
+	}
+	r.cancelled[jobID] = true
+	cancel()
+	return true
+}
+
+// StartJobWorkers starts the worker pool, which runs queued jobs until ctx is cancelled, and the
+// recovery of jobs and operations interrupted by a station that stopped or crashed
+func (impl *TerraformStationImpl) StartJobWorkers(ctx context.Context) {
+	go impl.recoverInterrupted(ctx)
+	for i := 0; i < impl.cfg.Jobs.Workers; i++ {
+		go impl.jobWorker(ctx)
+	}
+}
+
+// recoverInterrupted fails running jobs and operations whose station stopped sending heartbeats for
+// locks.ttl, at once and then every ttl until ctx is cancelled. Those of other live stations sharing
+// the database are left alone.
+func (impl *TerraformStationImpl) recoverInterrupted(ctx context.Context) {
+	ticker := time.NewTicker(impl.cfg.Locks.TTL)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		if count, err := impl.store.FailStaleJobs("job was interrupted by a station that stopped", now.Add(-impl.cfg.Locks.TTL), now); err != nil {
+			log.Printf("failed to recover interrupted jobs: %v", err)
+		} else if count > 0 {
+			log.Printf("marked %d interrupted jobs as failed", count)
+		}
+		if count, err := impl.store.FailStaleOperations("operation was interrupted by a station that stopped", now.Add(-impl.cfg.Locks.TTL), now); err != nil {
+			log.Printf("failed to recover interrupted operations: %v", err)
+		} else if count > 0 {
+			log.Printf("marked %d interrupted operations as failed", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// jobWorker runs queued jobs one at a time, waiting for new ones when the queue is empty
+func (impl *TerraformStationImpl) jobWorker(ctx context.Context) {
+	for {
+		job, err := impl.store.ClaimNextJob(impl.owner, time.Now())
+		if err != nil {
+			log.Printf("failed to claim job: %v", err)
+		}
+		if job != nil {
+			impl.runJob(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-impl.jobs.wake:
+		case <-time.After(impl.cfg.Jobs.PollInterval):
+		}
+	}
+}
+
+// runJob runs a claimed job and records its outcome
+func (impl *TerraformStationImpl) runJob(ctx context.Context, job *TerraformStation.TerraformJob) {
+	jobCtx := impl.jobs.start(ctx, job.JobID)
+	stopHeartbeat := impl.heartbeat(func(at time.Time) error {
+		return impl.store.HeartbeatJob(job.JobID, impl.owner, at)
+	}, "job "+job.JobID)
+	result, succeeded, err := impl.dispatchJob(jobCtx, job)
+	stopHeartbeat()
+	cancelled := impl.jobs.finish(job.JobID)
+
+	completedAt := time.Now()
+	job.CompletedAt = &completedAt
+
+	switch {
+	case cancelled:
+		job.Status = TerraformStation.JobStatusCancelled
+		job.ErrorMessage = "job was cancelled"
+	case err != nil:
+		job.Status = TerraformStation.JobStatusFailed
+		job.ErrorMessage = err.Error()
+	case succeeded:
+		job.Status = TerraformStation.JobStatusSucceeded
+	default:
+		job.Status = TerraformStation.JobStatusFailed
+	}
+
+	if result != nil {
+		data, err := protojson.Marshal(result)
+		if err != nil {
+			log.Printf("failed to encode result of job %s: %v", job.JobID, err)
+		}
+		job.Result = string(data)
+		if job.ErrorMessage == "" {
+			job.ErrorMessage = resultErrorMessage(result)
+		}
+	}
+
+	// The command ID is set by the operation while the job runs
+	if stored, err := impl.store.GetJobByJobID(job.JobID); err == nil {
+		job.CommandID = stored.CommandID
+	}
+	if err := impl.store.UpdateJob(job); err != nil {
+		log.Printf("failed to record completion of job %s: %v", job.JobID, err)
+	}
+}
+
+// heartbeat keeps the lease of a running job or operation alive with refresh until the returned
+// function is called
+func (impl *TerraformStationImpl) heartbeat(refresh func(at time.Time) error, what string) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(impl.cfg.Locks.TTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := refresh(time.Now()); err != nil {
+					log.Printf("failed to refresh %s: %v", what, err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// dispatchJob runs the service method for a job's type, reporting whether its result succeeded
+func (impl *TerraformStationImpl) dispatchJob(ctx context.Context, job *TerraformStation.TerraformJob) (proto.Message, bool, error) {
+	input := &TerraformStation.TFCommandInput{}
+	if err := protojson.Unmarshal([]byte(job.Input), input); err != nil {
+		return nil, false, TerraformStation.NewInvalidInputError("failed to decode job input", err.Error())
+	}
+
+	switch job.Type {
+	case TerraformStation.JobTypeCommand:
+		result, err := impl.TFCommand(ctx, input)
+		return result, err == nil && result.Success, err
+	case TerraformStation.JobTypeInit:
+		result, err := impl.TFInit(ctx, input)
+		return result, err == nil && result.Success, err
+	case TerraformStation.JobTypeValidate:
+		result, err := impl.TFValidate(ctx, input)
+		return result, err == nil && result.Success, err
+	case TerraformStation.JobTypePlan:
+		result, err := impl.TFPlan(ctx, input)
+		return result, err == nil && result.Status != TerraformStation.PlanStatusFailed, err
+	case TerraformStation.JobTypeApply:
+		result, err := impl.TFApply(ctx, input)
+		return result, err == nil && result.Success, err
+	case TerraformStation.JobTypeState:
+		result, err := impl.TFState(ctx, input)
+		return result, err == nil, err
+	default:
+		return nil, false, TerraformStation.NewInvalidInputError("unknown job type", job.Type)
+	}
+}
+
+// resultErrorMessage returns the error message carried by a job result, if any
+func resultErrorMessage(result proto.Message) string {
+	switch r := result.(type) {
+	case *TerraformStation.TFCommandResult:
+		return r.ErrorMessage
+	case *TerraformStation.TFPlanResult:
+		return r.ErrorMessage
+	}
+	return ""
+}
+
+// linkJobOperation records the operation started for the job running under ctx, if any
+func (impl *TerraformStationImpl) linkJobOperation(ctx context.Context, commandID string) {
+	jobID, ok := ctx.Value(jobContextKey{}).(string)
+	if !ok {
+		return
+	}
+	if err := impl.store.SetJobCommandID(jobID, commandID); err != nil {
+		log.Printf("failed to link job %s to operation %s: %v", jobID, commandID, err)
+	}
+}
+
+// TFSubmitJob queues a command to run asynchronously and returns the queued job immediately
+func (impl *TerraformStationImpl) TFSubmitJob(ctx context.Context, input *TerraformStation.TFSubmitJobInput) (*TerraformStation.TFJob, error) {
+	if input == nil || input.Input == nil {
+		return nil, TerraformStation.NewInvalidInputError("job input cannot be nil")
+	}
+
+	// Reject what would fail straight away rather than queueing it
+	switch input.Type {
+	case TerraformStation.JobTypeCommand:
+		if err := TerraformStation.ValidateTFCommandInput(input.Input); err != nil {
+			return nil, err
+		}
+		if err := impl.requireReviewedPlan(input.Input); err != nil {
+			return nil, err
+		}
+	case TerraformStation.JobTypePlan, TerraformStation.JobTypeApply, TerraformStation.JobTypeInit,
+		TerraformStation.JobTypeValidate, TerraformStation.JobTypeState:
+	default:
+		return nil, TerraformStation.NewInvalidInputError("unknown job type", input.Type)
+	}
+	if _, err := impl.resolveWorkingDir(input.Input); err != nil {
+		return nil, TerraformStation.NewWorkingDirError("invalid working directory", err.Error())
+	}
+
+	data, err := protojson.Marshal(input.Input)
+	if err != nil {
+		return nil, TerraformStation.NewInvalidInputError("failed to encode job input", err.Error())
+	}
+
+	job := &TerraformStation.TerraformJob{
+		JobID:  TerraformStation.GenerateCommandID(),
+		Type:   input.Type,
+		Status: TerraformStation.JobStatusQueued,
+		Input:  string(data),
+	}
+	if err := impl.store.CreateJob(job); err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to queue job", err.Error())
+	}
+	impl.jobs.notify()
+
+	return jobToProto(job), nil
+}
+
+// TFGetJob returns the status of a job and, once finished, its result
+func (impl *TerraformStationImpl) TFGetJob(ctx context.Context, input *TerraformStation.TFJobInput) (*TerraformStation.TFJob, error) {
+	if input == nil || input.JobId == "" {
+		return nil, TerraformStation.NewInvalidInputError("job id cannot be empty")
+	}
+
+	job, err := impl.loadJob(input.JobId)
+	if err != nil {
+		return nil, err
+	}
+	return jobToProto(job), nil
+}
+
+// TFListJobs lists jobs, newest first
+func (impl *TerraformStationImpl) TFListJobs(ctx context.Context, input *TerraformStation.TFListJobsInput) (*TerraformStation.TFJobList, error) {
+	if input == nil {
+		input = &TerraformStation.TFListJobsInput{}
+	}
+	if input.Limit < 0 || input.Offset < 0 {
+		return nil, TerraformStation.NewInvalidInputError("limit and offset cannot be negative")
+	}
+
+	limit := int(input.Limit)
+	if limit == 0 {
+		limit = defaultJobListLimit
+	}
+
+	jobs, err := impl.store.ListJobs(limit, int(input.Offset), input.Status)
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to list jobs", err.Error())
+	}
+
+	list := &TerraformStation.TFJobList{}
+	for i := range jobs {
+		list.Jobs = append(list.Jobs, jobToProto(&jobs[i]))
+	}
+	return list, nil
+}
+
+// TFGetJobOutput returns the output a job has produced after the given sequence number
+func (impl *TerraformStationImpl) TFGetJobOutput(ctx context.Context, input *TerraformStation.TFJobOutputInput) (*TerraformStation.TFJobOutput, error) {
+	if input == nil || input.JobId == "" {
+		return nil, TerraformStation.NewInvalidInputError("job id cannot be empty")
+	}
+
+	job, err := impl.loadJob(input.JobId)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &TerraformStation.TFJobOutput{
+		JobId:     job.JobID,
+		Status:    job.Status,
+		CommandId: job.CommandID,
+	}
+	if job.CommandID == "" {
+		return output, nil
+	}
+
+	chunks, err := impl.store.ListOutputChunks(job.CommandID, input.AfterSequence)
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to read job output", err.Error())
+	}
+	for i := range chunks {
+		output.Chunks = append(output.Chunks, chunkToProto(&chunks[i]))
+	}
+	return output, nil
+}
+
+// TFCancelJob cancels a queued job, or stops a running one
+func (impl *TerraformStationImpl) TFCancelJob(ctx context.Context, input *TerraformStation.TFJobInput) (*TerraformStation.TFJob, error) {
+	if input == nil || input.JobId == "" {
+		return nil, TerraformStation.NewInvalidInputError("job id cannot be empty")
+	}
+
+	job, err := impl.loadJob(input.JobId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch job.Status {
+	case TerraformStation.JobStatusQueued:
+		cancelled, err := impl.store.TransitionJobStatus(job.JobID, TerraformStation.JobStatusQueued, TerraformStation.JobStatusCancelled)
+		if err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("failed to cancel job", err.Error())
+		}
+		if cancelled {
+			break
+		}
+		// A worker claimed the job in the meantime, so stop it instead
+		if !impl.jobs.cancel(job.JobID) {
+			return nil, TerraformStation.NewInvalidStateError("job has already finished", job.JobID)
+		}
+	case TerraformStation.JobStatusRunning:
+		if !impl.jobs.cancel(job.JobID) {
+			return nil, TerraformStation.NewInvalidStateError("job is not running on this station", job.JobID)
+		}
+	default:
+		return nil, TerraformStation.NewInvalidStateError("job has already finished", job.JobID, job.Status)
+	}
+
+	if job, err = impl.loadJob(input.JobId); err != nil {
+		return nil, err
+	}
+	return jobToProto(job), nil
+}
+
+// loadJob retrieves a job by its job ID
+func (impl *TerraformStationImpl) loadJob(jobID string) (*TerraformStation.TerraformJob, error) {
+	job, err := impl.store.GetJobByJobID(jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, TerraformStation.NewNotFoundError("job not found", jobID)
+		}
+		return nil, TerraformStation.NewExecutionFailedError("failed to read job", err.Error())
+	}
+	return job, nil
+}
+
+// jobToProto converts a persisted job to its API representation
+func jobToProto(job *TerraformStation.TerraformJob) *TerraformStation.TFJob {
+	out := &TerraformStation.TFJob{
+		JobId:        job.JobID,
+		Type:         job.Type,
+		Status:       job.Status,
+		CommandId:    job.CommandID,
+		ErrorMessage: job.ErrorMessage,
+		CreatedAt:    timestamppb.New(job.CreatedAt),
+	}
+	if job.StartedAt != nil {
+		out.StartedAt = timestamppb.New(*job.StartedAt)
+	}
+	if job.CompletedAt != nil {
+		out.CompletedAt = timestamppb.New(*job.CompletedAt)
+	}
+
+	input := &TerraformStation.TFCommandInput{}
+	if err := protojson.Unmarshal([]byte(job.Input), input); err == nil {
+		out.Input = input
+	}
+
+	if job.Result == "" {
+		return out
+	}
+	var err error
+	switch job.Type {
+	case TerraformStation.JobTypePlan:
+		result := &TerraformStation.TFPlanResult{}
+		err = protojson.Unmarshal([]byte(job.Result), result)
+		out.Result = &TerraformStation.TFJob_PlanResult{PlanResult: result}
+	case TerraformStation.JobTypeApply:
+		result := &TerraformStation.TFApplyResult{}
+		err = protojson.Unmarshal([]byte(job.Result), result)
+		out.Result = &TerraformStation.TFJob_ApplyResult{ApplyResult: result}
+	case TerraformStation.JobTypeState:
+		result := &TerraformStation.TFStateInfo{}
+		err = protojson.Unmarshal([]byte(job.Result), result)
+		out.Result = &TerraformStation.TFJob_StateInfo{StateInfo: result}
+	default:
+		result := &TerraformStation.TFCommandResult{}
+		err = protojson.Unmarshal([]byte(job.Result), result)
+		out.Result = &TerraformStation.TFJob_CommandResult{CommandResult: result}
+	}
+	if err != nil {
+		log.Printf("failed to decode result of job %s: %v", job.JobID, err)
+		out.Result = nil
+	}
+	return out
+}
@@ -0,0 +1,188 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startJobWorkers runs the worker pool of impl until the test finishes
+func startJobWorkers(t *testing.T, impl *TerraformStationImpl) {
+	impl.cfg.Jobs.PollInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	impl.StartJobWorkers(ctx)
+}
+
+// waitForJob polls a job until it reaches one of the given statuses
+func waitForJob(t *testing.T, impl *TerraformStationImpl, jobID string, statuses ...string) *TerraformStation.TFJob {
+	var job *TerraformStation.TFJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = impl.TFGetJob(context.Background(), &TerraformStation.TFJobInput{JobId: jobID})
+		require.NoError(t, err)
+		for _, status := range statuses {
+			if job.Status == status {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestSubmitJob(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo \"line one\"\necho \"line two\"\n")
+
+	job, err := impl.TFSubmitJob(context.Background(), &TerraformStation.TFSubmitJobInput{
+		Type:  TerraformStation.JobTypeCommand,
+		Input: &TerraformStation.TFCommandInput{Command: "version"},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, job.JobId)
+	assert.Equal(t, TerraformStation.JobStatusQueued, job.Status)
+
+	startJobWorkers(t, impl)
+	job = waitForJob(t, impl, job.JobId, TerraformStation.JobStatusSucceeded, TerraformStation.JobStatusFailed)
+
+	assert.Equal(t, TerraformStation.JobStatusSucceeded, job.Status)
+	assert.NotEmpty(t, job.CommandId)
+	assert.NotNil(t, job.CompletedAt)
+	require.NotNil(t, job.GetCommandResult())
+	assert.Equal(t, job.CommandId, job.GetCommandResult().CommandId)
+
+	output, err := impl.TFGetJobOutput(context.Background(), &TerraformStation.TFJobOutputInput{JobId: job.JobId, AfterSequence: 1})
+	require.NoError(t, err)
+	require.Len(t, output.Chunks, 1)
+	assert.Equal(t, "line two", output.Chunks[0].Line)
+
+	list, err := impl.TFListJobs(context.Background(), &TerraformStation.TFListJobsInput{Status: TerraformStation.JobStatusSucceeded})
+	require.NoError(t, err)
+	require.Len(t, list.Jobs, 1)
+	assert.Equal(t, job.JobId, list.Jobs[0].JobId)
+}
+
+func TestSubmitPlanJob(t *testing.T) {
+	impl := newSavedPlanTestImpl(t)
+	startJobWorkers(t, impl)
+
+	job, err := impl.TFSubmitJob(context.Background(), &TerraformStation.TFSubmitJobInput{
+		Type:  TerraformStation.JobTypePlan,
+		Input: &TerraformStation.TFCommandInput{},
+	})
+	require.NoError(t, err)
+
+	job = waitForJob(t, impl, job.JobId, TerraformStation.JobStatusSucceeded, TerraformStation.JobStatusFailed)
+	assert.Equal(t, TerraformStation.JobStatusSucceeded, job.Status)
+	require.NotNil(t, job.GetPlanResult())
+	assert.True(t, job.GetPlanResult().HasChanges)
+}
+
+func TestFailedJob(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo \"Error: boom\" >&2\nexit 1\n")
+	startJobWorkers(t, impl)
+
+	job, err := impl.TFSubmitJob(context.Background(), &TerraformStation.TFSubmitJobInput{
+		Type:  TerraformStation.JobTypeValidate,
+		Input: &TerraformStation.TFCommandInput{},
+	})
+	require.NoError(t, err)
+
+	job = waitForJob(t, impl, job.JobId, TerraformStation.JobStatusSucceeded, TerraformStation.JobStatusFailed)
+	assert.Equal(t, TerraformStation.JobStatusFailed, job.Status)
+	assert.NotEmpty(t, job.ErrorMessage)
+}
+
+func TestCancelQueuedJob(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo ok\n")
+
+	job, err := impl.TFSubmitJob(context.Background(), &TerraformStation.TFSubmitJobInput{
+		Type:  TerraformStation.JobTypeInit,
+		Input: &TerraformStation.TFCommandInput{},
+	})
+	require.NoError(t, err)
+
+	job, err = impl.TFCancelJob(context.Background(), &TerraformStation.TFJobInput{JobId: job.JobId})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.JobStatusCancelled, job.Status)
+
+	// A cancelled job is never picked up and cannot be cancelled again
+	startJobWorkers(t, impl)
+	time.Sleep(50 * time.Millisecond)
+	job, err = impl.TFGetJob(context.Background(), &TerraformStation.TFJobInput{JobId: job.JobId})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.JobStatusCancelled, job.Status)
+
+	_, err = impl.TFCancelJob(context.Background(), &TerraformStation.TFJobInput{JobId: job.JobId})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+}
+
+func TestCancelRunningJob(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo started\nsleep 10\n")
+	startJobWorkers(t, impl)
+
+	job, err := impl.TFSubmitJob(context.Background(), &TerraformStation.TFSubmitJobInput{
+		Type:  TerraformStation.JobTypeCommand,
+		Input: &TerraformStation.TFCommandInput{Command: "version"},
+	})
+	require.NoError(t, err)
+	waitForJob(t, impl, job.JobId, TerraformStation.JobStatusRunning)
+
+	_, err = impl.TFCancelJob(context.Background(), &TerraformStation.TFJobInput{JobId: job.JobId})
+	require.NoError(t, err)
+
+	job = waitForJob(t, impl, job.JobId, TerraformStation.JobStatusCancelled, TerraformStation.JobStatusSucceeded, TerraformStation.JobStatusFailed)
+	assert.Equal(t, TerraformStation.JobStatusCancelled, job.Status)
+}
+
+func TestRestartFailsInterruptedJobs(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo ok\n")
+
+	submit := func() string {
+		job, err := impl.TFSubmitJob(context.Background(), &TerraformStation.TFSubmitJobInput{
+			Type:  TerraformStation.JobTypeInit,
+			Input: &TerraformStation.TFCommandInput{},
+		})
+		require.NoError(t, err)
+		return job.JobId
+	}
+
+	// A station that crashed stopped refreshing its job, while another one is still running its job
+	crashed := submit()
+	_, err := impl.store.ClaimNextJob("crashed-station:1", time.Now().Add(-2*impl.cfg.Locks.TTL))
+	require.NoError(t, err)
+	live := submit()
+	_, err = impl.store.ClaimNextJob("live-station:1", time.Now())
+	require.NoError(t, err)
+
+	startJobWorkers(t, impl)
+
+	job := waitForJob(t, impl, crashed, TerraformStation.JobStatusFailed)
+	assert.Contains(t, job.ErrorMessage, "interrupted")
+	job, err = impl.TFGetJob(context.Background(), &TerraformStation.TFJobInput{JobId: live})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.JobStatusRunning, job.Status)
+}
+
+func TestSubmitJobValidation(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo ok\n")
+
+	_, err := impl.TFSubmitJob(context.Background(), &TerraformStation.TFSubmitJobInput{Type: TerraformStation.JobTypePlan})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+
+	_, err = impl.TFSubmitJob(context.Background(), &TerraformStation.TFSubmitJobInput{Type: "refresh", Input: &TerraformStation.TFCommandInput{}})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+
+	_, err = impl.TFSubmitJob(context.Background(), &TerraformStation.TFSubmitJobInput{
+		Type:  TerraformStation.JobTypeCommand,
+		Input: &TerraformStation.TFCommandInput{Command: "rm"},
+	})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+
+	_, err = impl.TFGetJob(context.Background(), &TerraformStation.TFJobInput{JobId: "tofu_missing"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeNotFound)
+}
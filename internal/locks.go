@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// readOnlyCommands never modify state or the working directory, so they run without a lock
+var readOnlyCommands = []string{"show", "output", "validate", "version"}
+
+// readOnlyStateSubcommands are the `state` subcommands that only read state
+var readOnlyStateSubcommands = []string{"list", "show", "pull"}
+
+// heldLock is a working directory lock held by this process, kept alive by heartbeats until released
+type heldLock struct {
+	impl *TerraformStationImpl
+	lock *TerraformStation.TerraformLock
+	stop chan struct{}
+	done chan struct{}
+}
+
+// stationOwner identifies this station process as the owner of the locks it takes
+func stationOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
+
+// needsLock reports whether a command can modify state or the working directory
+func needsLock(input *TerraformStation.TFCommandInput) bool {
+	if contains(readOnlyCommands, input.Command) {
+		return false
+	}
+	if input.Command == "state" && len(input.Arguments) > 0 && contains(readOnlyStateSubcommands, input.Arguments[0]) {
+		return false
+	}
+	if input.Command == "workspace" && (len(input.Arguments) == 0 || contains(readOnlyWorkspaceSubcommands, input.Arguments[0])) {
+		return false
+	}
+	return true
+}
+
+// lockWorkingDir takes the lock on a working directory and workspace for a mutating command,
+// waiting up to the configured timeout while another run holds it. Read-only commands get a nil lock.
+func (impl *TerraformStationImpl) lockWorkingDir(ctx context.Context, workingDir, workspace string, input *TerraformStation.TFCommandInput, commandID string) (*heldLock, error) {
+	if !needsLock(input) {
+		return nil, nil
+	}
+
+	deadline := time.Now().Add(impl.cfg.Locks.WaitTimeout)
+	for {
+		now := time.Now()
+		lock := &TerraformStation.TerraformLock{
+			LockID:      TerraformStation.GenerateLockID(),
+			WorkingDir:  workingDir,
+			Workspace:   workspace,
+			Owner:       impl.owner,
+			CommandID:   commandID,
+			Command:     input.Command,
+			AcquiredAt:  now,
+			HeartbeatAt: now,
+		}
+		taken, err := impl.store.CreateLock(lock)
+		if err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("failed to lock working directory", err.Error())
+		}
+		if taken {
+			return impl.holdLock(lock), nil
+		}
+
+		held, err := impl.store.GetLock(workingDir, workspace)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Released in the meantime, so try again straight away
+			continue
+		}
+		if err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("failed to read working directory lock", err.Error())
+		}
+
+		// Take over locks whose holder stopped sending heartbeats, as it has most likely crashed
+		if removed, err := impl.store.DeleteStaleLock(held.LockID, now.Add(-impl.cfg.Locks.TTL)); err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("failed to release stale lock", err.Error())
+		} else if removed {
+			log.Printf("released stale lock %s on %s held by %s", held.LockID, workingDir, held.Owner)
+			continue
+		}
+
+		if !now.Before(deadline) {
+			return nil, TerraformStation.NewLockedError("working directory is locked", describeLock(held))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, TerraformStation.NewLockedError("stopped waiting for working directory lock", ctx.Err().Error(), describeLock(held))
+		case <-time.After(impl.cfg.Locks.RetryInterval):
+		}
+	}
+}
+
+// holdLock keeps a newly taken lock alive with heartbeats until it is released
+func (impl *TerraformStationImpl) holdLock(lock *TerraformStation.TerraformLock) *heldLock {
+	held := &heldLock{
+		impl: impl,
+		lock: lock,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go held.heartbeat(impl.cfg.Locks.TTL / 3)
+	return held
+}
+
+// heartbeat refreshes the lock until it is released
+func (h *heldLock) heartbeat(interval time.Duration) {
+	defer close(h.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-ticker.C:
+			held, err := h.impl.store.HeartbeatLock(h.lock.LockID, time.Now())
+			if err != nil {
+				log.Printf("failed to refresh lock %s: %v", h.lock.LockID, err)
+			} else if !held {
+				log.Printf("lock %s on %s was force-unlocked while %s was running", h.lock.LockID, h.lock.WorkingDir, h.lock.CommandID)
+				return
+			}
+		}
+	}
+}
+
+// release stops the heartbeats and removes the lock. It is safe to call on a nil lock.
+func (h *heldLock) release() {
+	if h == nil {
+		return
+	}
+	close(h.stop)
+	<-h.done
+
+	if _, err := h.impl.store.DeleteLock(h.lock.LockID); err != nil {
+		log.Printf("failed to release lock %s: %v", h.lock.LockID, err)
+	}
+}
+
+// TFListLocks lists the working directory locks currently held
+func (impl *TerraformStationImpl) TFListLocks(ctx context.Context, input *TerraformStation.TFListLocksInput) (*TerraformStation.TFLockList, error) {
+	var workingDir string
+	if input != nil && input.WorkingDirectory != "" {
+		var err error
+		if workingDir, err = TerraformStation.CanonicalWorkingDirectory(input.WorkingDirectory); err != nil {
+			return nil, err
+		}
+	}
+
+	locks, err := impl.store.ListLocks(workingDir)
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to list locks", err.Error())
+	}
+
+	list := &TerraformStation.TFLockList{}
+	for i := range locks {
+		list.Locks = append(list.Locks, lockToProto(&locks[i]))
+	}
+	return list, nil
+}
+
+// TFForceUnlock releases a lock regardless of its holder, for recovering from runs that left a lock behind
+func (impl *TerraformStationImpl) TFForceUnlock(ctx context.Context, input *TerraformStation.TFForceUnlockInput) (*TerraformStation.TFLock, error) {
+	if input == nil || input.LockId == "" {
+		return nil, TerraformStation.NewInvalidInputError("lock id cannot be empty")
+	}
+
+	lock, err := impl.store.GetLockByLockID(input.LockId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, TerraformStation.NewNotFoundError("lock not found", input.LockId)
+		}
+		return nil, TerraformStation.NewExecutionFailedError("failed to read lock", err.Error())
+	}
+
+	removed, err := impl.store.DeleteLock(lock.LockID)
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to release lock", err.Error())
+	}
+	if !removed {
+		return nil, TerraformStation.NewNotFoundError("lock not found", input.LockId)
+	}
+
+	log.Printf("force-unlocked %s", describeLock(lock))
+	return lockToProto(lock), nil
+}
+
+// describeLock summarizes who holds a lock, for error messages and logs
+func describeLock(lock *TerraformStation.TerraformLock) string {
+	return fmt.Sprintf("lock %s on %s (workspace %s) held by %s for %s %s since %s",
+		lock.LockID, lock.WorkingDir, lock.Workspace, lock.Owner, lock.Command, lock.CommandID, lock.AcquiredAt.Format(time.RFC3339))
+}
+
+// lockToProto converts a persisted lock to its API representation
+func lockToProto(lock *TerraformStation.TerraformLock) *TerraformStation.TFLock {
+	return &TerraformStation.TFLock{
+		LockId:           lock.LockID,
+		WorkingDirectory: lock.WorkingDir,
+		Workspace:        lock.Workspace,
+		Owner:            lock.Owner,
+		CommandId:        lock.CommandID,
+		Command:          lock.Command,
+		AcquiredAt:       timestamppb.New(lock.AcquiredAt),
+		HeartbeatAt:      timestamppb.New(lock.HeartbeatAt),
+	}
+}
+
+// contains reports whether values includes value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLockTestImpl creates an implementation whose opentofu binary takes a while for init and returns at once otherwise
+func newLockTestImpl(t *testing.T) *TerraformStationImpl {
+	impl := newScriptTestImpl(t, `case "$1" in
+init) sleep 1; echo initialized ;;
+*) echo ok ;;
+esac
+`)
+	impl.cfg.Locks.RetryInterval = 10 * time.Millisecond
+	return impl
+}
+
+// runInBackground starts a command and returns a channel that receives its error once it finishes
+func runInBackground(impl *TerraformStationImpl, input *TerraformStation.TFCommandInput) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		_, err := impl.TFCommand(context.Background(), input)
+		done <- err
+	}()
+	return done
+}
+
+// waitForLock waits until a lock is held on the implementation's working directory
+func waitForLock(t *testing.T, impl *TerraformStationImpl) *TerraformStation.TFLock {
+	var locks *TerraformStation.TFLockList
+	require.Eventually(t, func() bool {
+		var err error
+		locks, err = impl.TFListLocks(context.Background(), &TerraformStation.TFListLocksInput{})
+		require.NoError(t, err)
+		return len(locks.Locks) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	return locks.Locks[0]
+}
+
+func TestConflictingRunRejected(t *testing.T) {
+	impl := newLockTestImpl(t)
+	impl.cfg.Locks.WaitTimeout = 0
+
+	done := runInBackground(impl, &TerraformStation.TFCommandInput{Command: "init"})
+	lock := waitForLock(t, impl)
+	assert.Equal(t, "init", lock.Command)
+	assert.Equal(t, TerraformStation.DefaultWorkspace, lock.Workspace)
+	assert.Equal(t, impl.owner, lock.Owner)
+
+	_, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "init"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeLocked)
+
+	// Read-only commands are not blocked by the lock
+	result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "show"})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	require.NoError(t, <-done)
+
+	locks, err := impl.TFListLocks(context.Background(), &TerraformStation.TFListLocksInput{})
+	require.NoError(t, err)
+	assert.Empty(t, locks.Locks)
+}
+
+func TestConflictingRunWaits(t *testing.T) {
+	impl := newLockTestImpl(t)
+	impl.cfg.Locks.WaitTimeout = 10 * time.Second
+
+	done := runInBackground(impl, &TerraformStation.TFCommandInput{Command: "init"})
+	waitForLock(t, impl)
+
+	result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "init"})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	require.NoError(t, <-done)
+}
+
+func TestStaleLockTakenOver(t *testing.T) {
+	impl := newLockTestImpl(t)
+	impl.cfg.Locks.WaitTimeout = 0
+
+	stale := time.Now().Add(-impl.cfg.Locks.TTL - time.Minute)
+	taken, err := impl.store.CreateLock(&TerraformStation.TerraformLock{
+		LockID:      "lock_stale",
+		WorkingDir:  impl.workingDir,
+		Workspace:   TerraformStation.DefaultWorkspace,
+		Owner:       "crashed:1",
+		Command:     "apply",
+		AcquiredAt:  stale,
+		HeartbeatAt: stale,
+	})
+	require.NoError(t, err)
+	require.True(t, taken)
+
+	result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "version"})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	// version is read-only, so the stale lock is only taken over by a mutating command
+	_, err = impl.store.GetLockByLockID("lock_stale")
+	require.NoError(t, err)
+
+	result, err = impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "apply"})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	_, err = impl.store.GetLockByLockID("lock_stale")
+	assert.Error(t, err)
+}
+
+func TestForceUnlock(t *testing.T) {
+	impl := newLockTestImpl(t)
+	impl.cfg.Locks.WaitTimeout = 0
+
+	now := time.Now()
+	_, err := impl.store.CreateLock(&TerraformStation.TerraformLock{
+		LockID:      "lock_left",
+		WorkingDir:  impl.workingDir,
+		Workspace:   TerraformStation.DefaultWorkspace,
+		Owner:       "other:1",
+		Command:     "apply",
+		AcquiredAt:  now,
+		HeartbeatAt: now,
+	})
+	require.NoError(t, err)
+
+	_, err = impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "apply"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeLocked)
+
+	lock, err := impl.TFForceUnlock(context.Background(), &TerraformStation.TFForceUnlockInput{LockId: "lock_left"})
+	require.NoError(t, err)
+	assert.Equal(t, "other:1", lock.Owner)
+
+	_, err = impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "apply"})
+	require.NoError(t, err)
+
+	_, err = impl.TFForceUnlock(context.Background(), &TerraformStation.TFForceUnlockInput{LockId: "lock_left"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeNotFound)
+}
+
+func TestNeedsLock(t *testing.T) {
+	tests := []struct {
+		input *TerraformStation.TFCommandInput
+		want  bool
+	}{
+		{&TerraformStation.TFCommandInput{Command: "apply"}, true},
+		{&TerraformStation.TFCommandInput{Command: "init"}, true},
+		{&TerraformStation.TFCommandInput{Command: "plan"}, true},
+		{&TerraformStation.TFCommandInput{Command: "show"}, false},
+		{&TerraformStation.TFCommandInput{Command: "output"}, false},
+		{&TerraformStation.TFCommandInput{Command: "validate"}, false},
+		{&TerraformStation.TFCommandInput{Command: "state", Arguments: []string{"list"}}, false},
+		{&TerraformStation.TFCommandInput{Command: "state", Arguments: []string{"rm", "local_file.hello"}}, true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, needsLock(tt.input), "%s %v", tt.input.Command, tt.input.Arguments)
+	}
+}
@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/ForestMars/TerraformStation/tofutest"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMain(m *testing.M) {
+	// Lets tests run the service against a fake tofu binary
+	os.Exit(tofutest.Run(m))
+}
+
+// newFakeTestImpl creates a service that runs a fake tofu binary in a temporary working directory
+func newFakeTestImpl(t *testing.T) (*TerraformStationImpl, *tofutest.Fake) {
+	dir := t.TempDir()
+	fake := tofutest.New(t)
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "station.db")), &gorm.Config{})
+	require.NoError(t, err)
+
+	cfg := TerraformStation.DefaultConfig()
+	cfg.OpenTofuPath = fake.Path
+	cfg.WorkingDirectory = dir
+	cfg.PlanDirectory = filepath.Join(dir, "plans")
+
+	impl, err := New(db, cfg)
+	require.NoError(t, err)
+	return impl, fake
+}
@@ -0,0 +1,229 @@
+package internal
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// startOperation records a new operation and marks it as running, masking values in its arguments and variables
+func (impl *TerraformStationImpl) startOperation(commandID, workingDir, workspace string, input *TerraformStation.TFCommandInput, args []string, redactor *TerraformStation.Redactor) (*TerraformStation.TerraformOperation, error) {
+	arguments, err := json.Marshal(args)
+	if err != nil {
+		return nil, TerraformStation.NewInvalidInputError("failed to encode arguments", err.Error())
+	}
+	variables, err := json.Marshal(input.Variables)
+	if err != nil {
+		return nil, TerraformStation.NewInvalidInputError("failed to encode variables", err.Error())
+	}
+	// Only the names of secrets are recorded, never their values
+	secretVariables, err := json.Marshal(input.SecretVariables)
+	if err != nil {
+		return nil, TerraformStation.NewInvalidInputError("failed to encode secret variables", err.Error())
+	}
+	secretEnv, err := json.Marshal(input.SecretEnv)
+	if err != nil {
+		return nil, TerraformStation.NewInvalidInputError("failed to encode secret environment", err.Error())
+	}
+
+	operation := &TerraformStation.TerraformOperation{
+		CommandID:       commandID,
+		Command:         input.Command,
+		WorkingDir:      workingDir,
+		Workspace:       workspace,
+		Arguments:       redactor.Redact(string(arguments)),
+		Variables:       redactor.Redact(string(variables)),
+		SecretVariables: string(secretVariables),
+		SecretEnv:       string(secretEnv),
+		WritesState:     writesState(input),
+		Status:          TerraformStation.OperationStatusPending,
+		Owner:           impl.owner,
+		StartedAt:       time.Now(),
+	}
+	if err := impl.store.CreateOperation(operation); err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to record operation", err.Error())
+	}
+
+	operation.Status = TerraformStation.OperationStatusRunning
+	operation.StartedAt = time.Now()
+	operation.HeartbeatAt = &operation.StartedAt
+	if err := impl.store.UpdateOperation(operation); err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to record operation", err.Error())
+	}
+
+	return operation, nil
+}
+
+// heartbeatOperation keeps the lease of a running operation alive until the returned function is called
+func (impl *TerraformStationImpl) heartbeatOperation(commandID string) func() {
+	return impl.heartbeat(func(at time.Time) error {
+		return impl.store.HeartbeatOperation(commandID, impl.owner, at)
+	}, "operation "+commandID)
+}
+
+// operationStale reports whether a running operation's station stopped sending heartbeats for locks.ttl,
+// in which case it has most likely crashed and the operation will never finish
+func (impl *TerraformStationImpl) operationStale(operation *TerraformStation.TerraformOperation) bool {
+	if operation.Status != TerraformStation.OperationStatusRunning {
+		return false
+	}
+	return operation.HeartbeatAt == nil || operation.HeartbeatAt.Before(time.Now().Add(-impl.cfg.Locks.TTL))
+}
+
+// completeOperation records the outcome of a finished operation
+func (impl *TerraformStationImpl) completeOperation(operation *TerraformStation.TerraformOperation, result *TerraformStation.TFCommandResult) {
+	completedAt := time.Now()
+	operation.CompletedAt = &completedAt
+	operation.Duration = completedAt.Sub(operation.StartedAt)
+	operation.Output = result.Result
+	operation.Stderr = result.Stderr
+	operation.ExitCode = int(result.ExitCode)
+	operation.Signal = result.Signal
+	operation.TimedOut = result.TimedOut
+	operation.ErrorMessage = result.ErrorMessage
+	operation.ErrorCount = int(result.ErrorCount)
+	operation.WarningCount = int(result.WarningCount)
+
+	// Diagnostics are only known for commands run with -json
+	if result.Diagnostics != nil {
+		diagnostics, err := encodeMessages(result.Diagnostics)
+		if err != nil {
+			log.Printf("failed to encode diagnostics of %s: %v", operation.CommandID, err)
+		}
+		operation.Diagnostics = diagnostics
+	}
+
+	switch {
+	case result.Cancelled:
+		operation.Status = TerraformStation.OperationStatusCancelled
+	case result.Success:
+		operation.Status = TerraformStation.OperationStatusSucceeded
+	default:
+		operation.Status = TerraformStation.OperationStatusFailed
+	}
+
+	if err := impl.store.UpdateOperation(operation); err != nil {
+		log.Printf("failed to record completion of %s: %v", operation.CommandID, err)
+	}
+}
+
+// recordPlan stores the plan produced by an operation along with the location and checksum of its plan file
+func (impl *TerraformStationImpl) recordPlan(operation *TerraformStation.TerraformOperation, planResult *TerraformStation.TFPlanResult, planFile, checksum string) error {
+	resourceChanges, err := encodeMessages(planResult.ResourceChanges)
+	if err != nil {
+		log.Printf("failed to encode resource changes of plan %s: %v", planResult.PlanId, err)
+	}
+
+	plan := &TerraformStation.TerraformPlan{
+		PlanID:            planResult.PlanId,
+		OperationID:       operation.ID,
+		HasChanges:        planResult.HasChanges,
+		ResourceCount:     int(planResult.ResourceCount),
+		PlanOutput:        planResult.PlanOutput,
+		ResourceChanges:   resourceChanges,
+		WorkingDir:        operation.WorkingDir,
+		Workspace:         operation.Workspace,
+		Status:            planResult.Status,
+		RequiredApprovals: int(planResult.RequiredApprovals),
+	}
+	if planResult.ApprovalExpiresAt != nil {
+		expiresAt := planResult.ApprovalExpiresAt.AsTime()
+		plan.ApprovalExpiresAt = &expiresAt
+	}
+	if planResult.Status != TerraformStation.PlanStatusFailed {
+		plan.PlanFile = planFile
+		plan.Checksum = checksum
+	}
+
+	// A plan that was not recorded could never be applied, so this is an error rather than a warning
+	if err := impl.store.CreatePlan(plan); err != nil {
+		return TerraformStation.NewExecutionFailedError("failed to record plan", err.Error())
+	}
+	return nil
+}
+
+// recordApply stores the outcome of an apply operation
+func (impl *TerraformStationImpl) recordApply(operation *TerraformStation.TerraformOperation, applyResult *TerraformStation.TFApplyResult) {
+	resourceApplies, err := encodeMessages(applyResult.ResourceApplies)
+	if err != nil {
+		log.Printf("failed to encode resource results of apply %s: %v", applyResult.ApplyId, err)
+	}
+
+	apply := &TerraformStation.TerraformApply{
+		ApplyID:            applyResult.ApplyId,
+		OperationID:        operation.ID,
+		PlanID:             applyResult.PlanId,
+		Success:            applyResult.Success,
+		ResourcesAdded:     int(applyResult.ResourcesAdded),
+		ResourcesChanged:   int(applyResult.ResourcesChanged),
+		ResourcesDestroyed: int(applyResult.ResourcesDestroyed),
+		ResourcesFailed:    int(applyResult.ResourcesFailed),
+		ResourceApplies:    resourceApplies,
+		ApplyOutput:        applyResult.ApplyOutput,
+	}
+	if err := impl.store.CreateApply(apply); err != nil {
+		log.Printf("failed to record apply %s: %v", applyResult.ApplyId, err)
+	}
+}
+
+// recordState stores the state information read by an operation along with a snapshot of the state JSON
+func (impl *TerraformStationImpl) recordState(operation *TerraformStation.TerraformOperation, stateInfo *TerraformStation.TFStateInfo, snapshot string) {
+	state := &TerraformStation.TerraformState{
+		StateID:          stateInfo.StateId,
+		StateFile:        stateInfo.StateFile,
+		WorkingDir:       operation.WorkingDir,
+		ResourceCount:    int(stateInfo.ResourceCount),
+		TerraformVersion: stateInfo.TerraformVersion,
+		Serial:           stateInfo.Serial,
+		Lineage:          stateInfo.Lineage,
+		LastUpdated:      stateInfo.LastUpdated.AsTime(),
+		StateData:        snapshot,
+	}
+	if err := impl.store.CreateState(state); err != nil {
+		log.Printf("failed to record state %s: %v", stateInfo.StateId, err)
+	}
+}
+
+// encodeMessages serializes a list of API messages as a JSON array for storage
+func encodeMessages[T proto.Message](messages []T) (string, error) {
+	items := make([]json.RawMessage, 0, len(messages))
+	for _, message := range messages {
+		data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+		if err != nil {
+			return "", err
+		}
+		items = append(items, data)
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// decodeMessages parses a JSON array written by encodeMessages, using newMessage to allocate each element
+func decodeMessages[T proto.Message](data string, newMessage func() T) ([]T, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(data), &items); err != nil {
+		return nil, err
+	}
+
+	messages := make([]T, 0, len(items))
+	for _, item := range items {
+		message := newMessage()
+		if err := protojson.Unmarshal(item, message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
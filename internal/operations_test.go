@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationLifecycleSucceeded(t *testing.T) {
+	impl := newScriptTestImpl(t, `case "$1" in
+plan) `+writePlanFile+`; echo "Plan: 1 to add, 0 to change, 0 to destroy." ;;
+show) echo '{"resource_changes":[{"address":"local_file.hello","change":{"actions":["create"]}}]}' ;;
+esac
+`)
+
+	input := &TerraformStation.TFCommandInput{Variables: map[string]string{"region": "us-west-2"}}
+	planResult, err := impl.TFPlan(context.Background(), input)
+	require.NoError(t, err)
+
+	operations, err := impl.store.ListOperations(10, 0, "")
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+
+	operation := operations[0]
+	assert.Equal(t, TerraformStation.OperationStatusSucceeded, operation.Status)
+	assert.Equal(t, "plan", operation.Command)
+	assert.Equal(t, impl.workingDir, operation.WorkingDir)
+	assert.Contains(t, operation.Arguments, `"plan"`)
+	assert.JSONEq(t, `{"region":"us-west-2"}`, operation.Variables)
+	assert.Contains(t, operation.Output, "1 to add")
+	assert.Equal(t, 0, operation.ExitCode)
+	require.NotNil(t, operation.CompletedAt)
+	assert.False(t, operation.CompletedAt.Before(operation.StartedAt))
+	assert.Positive(t, operation.Duration)
+
+	var plan TerraformStation.TerraformPlan
+	require.NoError(t, impl.db.Where("plan_id = ?", planResult.PlanId).First(&plan).Error)
+	assert.Equal(t, operation.ID, plan.OperationID)
+	assert.Equal(t, "completed", plan.Status)
+	assert.Equal(t, 1, plan.ResourceCount)
+}
+
+func TestOperationLifecycleFailed(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo \"Error: boom\" >&2\nexit 1\n")
+
+	applyResult, err := impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	assert.False(t, applyResult.Success)
+
+	operations, err := impl.store.ListOperations(10, 0, TerraformStation.OperationStatusFailed)
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	assert.Equal(t, "apply", operations[0].Command)
+	assert.NotEmpty(t, operations[0].ErrorMessage)
+	assert.Contains(t, operations[0].Output, "Error: boom")
+
+	var apply TerraformStation.TerraformApply
+	require.NoError(t, impl.db.Where("apply_id = ?", applyResult.ApplyId).First(&apply).Error)
+	assert.Equal(t, operations[0].ID, apply.OperationID)
+	assert.False(t, apply.Success)
+}
+
+func TestOperationNotRecordedForInvalidInput(t *testing.T) {
+	impl := newScriptTestImpl(t, "exit 0\n")
+
+	_, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "bogus"})
+	require.Error(t, err)
+
+	operations, err := impl.store.ListOperations(10, 0, "")
+	require.NoError(t, err)
+	assert.Empty(t, operations)
+}
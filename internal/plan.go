@@ -0,0 +1,196 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// sensitiveValue replaces values marked sensitive in plan and state JSON
+const sensitiveValue = "(sensitive value)"
+
+// planJSON is the subset of the `tofu show -json` plan representation used by the station
+type planJSON struct {
+	FormatVersion    string               `json:"format_version"`
+	TerraformVersion string               `json:"terraform_version"`
+	ResourceChanges  []resourceChangeJSON `json:"resource_changes"`
+	// ResourceDrift lists changes made outside of OpenTofu, found while refreshing
+	ResourceDrift []resourceChangeJSON `json:"resource_drift"`
+}
+
+type resourceChangeJSON struct {
+	Address       string     `json:"address"`
+	ModuleAddress string     `json:"module_address"`
+	Mode          string     `json:"mode"`
+	Type          string     `json:"type"`
+	Name          string     `json:"name"`
+	ProviderName  string     `json:"provider_name"`
+	Change        changeJSON `json:"change"`
+	ActionReason  string     `json:"action_reason"`
+}
+
+type changeJSON struct {
+	Actions         []string        `json:"actions"`
+	Before          json.RawMessage `json:"before"`
+	After           json.RawMessage `json:"after"`
+	BeforeSensitive json.RawMessage `json:"before_sensitive"`
+	AfterSensitive  json.RawMessage `json:"after_sensitive"`
+	ReplacePaths    json.RawMessage `json:"replace_paths"`
+}
+
+// showPlan reads a saved plan file with `show -json` in the workspace it was created in and returns
+// its resource changes. The redactor learns the values the plan marks sensitive, and masks the
+// details of any error.
+func (impl *TerraformStationImpl) showPlan(ctx context.Context, workingDir, workspace, planFile string, redactor *TerraformStation.Redactor) ([]*TerraformStation.TFResourceChange, error) {
+	execResult, err := impl.executorFor(workingDir, workspace).Execute(ctx, workingDir, "show", "-json", planFile)
+	if err != nil {
+		return nil, redactor.Error(TerraformStation.NewExecutionFailedError("failed to read saved plan", err.Error(), execResult.Stderr))
+	}
+	redactor.AddValues(sensitiveValues([]byte(execResult.Stdout))...)
+	changes, err := parsePlanJSON([]byte(execResult.Stdout))
+	return changes, redactor.Error(err)
+}
+
+// parsePlanJSON converts the JSON plan representation into resource changes
+func parsePlanJSON(data []byte) ([]*TerraformStation.TFResourceChange, error) {
+	var plan planJSON
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to parse plan JSON", err.Error())
+	}
+
+	changes := make([]*TerraformStation.TFResourceChange, 0, len(plan.ResourceChanges))
+	for _, rc := range plan.ResourceChanges {
+		change := &TerraformStation.TFResourceChange{
+			Address:       rc.Address,
+			ModuleAddress: rc.ModuleAddress,
+			Mode:          rc.Mode,
+			Type:          rc.Type,
+			Name:          rc.Name,
+			ProviderName:  rc.ProviderName,
+			Action:        planAction(rc.Change.Actions),
+			ActionReason:  rc.ActionReason,
+		}
+
+		var err error
+		if change.Before, err = maskedValue(rc.Change.Before, rc.Change.BeforeSensitive); err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("invalid before value", rc.Address, err.Error())
+		}
+		if change.After, err = maskedValue(rc.Change.After, rc.Change.AfterSensitive); err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("invalid after value", rc.Address, err.Error())
+		}
+		if change.ReplacePaths, err = replacePaths(rc.Change.ReplacePaths); err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("invalid replace paths", rc.Address, err.Error())
+		}
+
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// planAction collapses the action list of a resource change into a single action
+func planAction(actions []string) string {
+	switch len(actions) {
+	case 1:
+		return actions[0]
+	case 2:
+		// Both create-before-destroy and destroy-before-create are replacements
+		return TerraformStation.ActionReplace
+	default:
+		return TerraformStation.ActionNoOp
+	}
+}
+
+// summarizeChanges derives summary counts from a list of resource changes, counting
+// replacements as both an add and a destroy like OpenTofu does
+func summarizeChanges(changes []*TerraformStation.TFResourceChange) (add, change, destroy, replace int) {
+	for _, rc := range changes {
+		switch rc.Action {
+		case TerraformStation.ActionCreate:
+			add++
+		case TerraformStation.ActionUpdate:
+			change++
+		case TerraformStation.ActionDelete:
+			destroy++
+		case TerraformStation.ActionReplace:
+			add++
+			destroy++
+			replace++
+		}
+	}
+	return
+}
+
+// maskedValue decodes a JSON value, replacing the parts marked sensitive by mask
+func maskedValue(raw, mask json.RawMessage) (*structpb.Value, error) {
+	if len(raw) == 0 {
+		return structpb.NewNullValue(), nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+
+	if len(mask) > 0 {
+		var sensitive interface{}
+		if err := json.Unmarshal(mask, &sensitive); err != nil {
+			return nil, err
+		}
+		value = maskSensitive(value, sensitive)
+	}
+
+	return structpb.NewValue(value)
+}
+
+// maskSensitive walks value alongside its sensitivity mask, where true marks a sensitive value
+func maskSensitive(value, mask interface{}) interface{} {
+	switch m := mask.(type) {
+	case bool:
+		if m && value != nil {
+			return sensitiveValue
+		}
+	case map[string]interface{}:
+		if v, ok := value.(map[string]interface{}); ok {
+			for key, child := range m {
+				if _, exists := v[key]; exists {
+					v[key] = maskSensitive(v[key], child)
+				}
+			}
+		}
+	case []interface{}:
+		if v, ok := value.([]interface{}); ok {
+			for i := range v {
+				if i < len(m) {
+					v[i] = maskSensitive(v[i], m[i])
+				}
+			}
+		}
+	}
+	return value
+}
+
+// replacePaths decodes the list of attribute paths forcing a replacement
+func replacePaths(raw json.RawMessage) ([]*structpb.ListValue, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var paths []json.RawMessage
+	if err := json.Unmarshal(raw, &paths); err != nil {
+		return nil, err
+	}
+
+	result := make([]*structpb.ListValue, 0, len(paths))
+	for _, path := range paths {
+		list := &structpb.ListValue{}
+		if err := protojson.Unmarshal(path, list); err != nil {
+			return nil, fmt.Errorf("invalid path %s: %w", path, err)
+		}
+		result = append(result, list)
+	}
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlanJSON(t *testing.T) {
+	data, err := os.ReadFile("testdata/plan.json")
+	require.NoError(t, err)
+
+	changes, err := parsePlanJSON(data)
+	require.NoError(t, err)
+	require.Len(t, changes, 6)
+
+	create := changes[0]
+	assert.Equal(t, "local_file.hello", create.Address)
+	assert.Equal(t, "local_file", create.Type)
+	assert.Equal(t, "registry.opentofu.org/hashicorp/local", create.ProviderName)
+	assert.Equal(t, TerraformStation.ActionCreate, create.Action)
+	assert.Nil(t, create.Before.AsInterface())
+	assert.Equal(t, "./hello.txt", create.After.GetStructValue().Fields["filename"].GetStringValue())
+
+	replace := changes[1]
+	assert.Equal(t, TerraformStation.ActionReplace, replace.Action)
+	assert.Equal(t, "module.app", replace.ModuleAddress)
+	assert.Equal(t, "replace_because_cannot_update", replace.ActionReason)
+	require.Len(t, replace.ReplacePaths, 1)
+	assert.Equal(t, []interface{}{"ami"}, replace.ReplacePaths[0].AsSlice())
+	assert.Equal(t, sensitiveValue, replace.Before.GetStructValue().Fields["user_data"].GetStringValue())
+	assert.Equal(t, sensitiveValue, replace.After.GetStructValue().Fields["user_data"].GetStringValue())
+	assert.Equal(t, "ami-2", replace.After.GetStructValue().Fields["ami"].GetStringValue())
+
+	assert.Equal(t, TerraformStation.ActionUpdate, changes[2].Action)
+	assert.Equal(t, TerraformStation.ActionDelete, changes[3].Action)
+	assert.Equal(t, TerraformStation.ActionRead, changes[4].Action)
+	assert.Equal(t, TerraformStation.ActionNoOp, changes[5].Action)
+}
+
+func TestSetPlanChanges(t *testing.T) {
+	data, err := os.ReadFile("testdata/plan.json")
+	require.NoError(t, err)
+	changes, err := parsePlanJSON(data)
+	require.NoError(t, err)
+
+	planResult := &TerraformStation.TFPlanResult{}
+	setPlanChanges(planResult, changes)
+
+	// Matches "Plan: 2 to add, 1 to change, 2 to destroy."
+	assert.Equal(t, int32(2), planResult.ToAdd)
+	assert.Equal(t, int32(1), planResult.ToChange)
+	assert.Equal(t, int32(2), planResult.ToDestroy)
+	assert.Equal(t, int32(1), planResult.ToReplace)
+	assert.Equal(t, int32(4), planResult.ResourceCount)
+	assert.True(t, planResult.HasChanges)
+
+	noChanges := &TerraformStation.TFPlanResult{}
+	setPlanChanges(noChanges, changes[4:])
+	assert.False(t, noChanges.HasChanges)
+}
+
+func TestParsePlanJSONInvalid(t *testing.T) {
+	_, err := parsePlanJSON([]byte("Plan: 1 to add"))
+	assert.Error(t, err)
+}
+
+func TestTFPlanReadsSavedPlan(t *testing.T) {
+	impl := newScriptTestImpl(t, `case "$1" in
+plan) `+writePlanFile+`; echo "Plan: 2 to add, 1 to change, 2 to destroy." ;;
+show) cat "`+testdataPath(t, "plan.json")+`" ;;
+esac
+`)
+
+	planResult, err := impl.TFPlan(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "completed", planResult.Status)
+	assert.Len(t, planResult.ResourceChanges, 6)
+	assert.Equal(t, int32(2), planResult.ToAdd)
+
+	var plan TerraformStation.TerraformPlan
+	require.NoError(t, impl.db.Where("plan_id = ?", planResult.PlanId).First(&plan).Error)
+	assert.Equal(t, 4, plan.ResourceCount)
+	assert.Contains(t, plan.ResourceChanges, `"address":"local_file.hello"`)
+}
+
+func TestTFPlanReadsSavedPlanInItsWorkspace(t *testing.T) {
+	impl := newScriptTestImpl(t, `case "$1" in
+plan) `+writePlanFile+` ;;
+show) [ "$TF_WORKSPACE" = staging ] || { echo "wrong workspace" >&2; exit 1; }; cat "`+testdataPath(t, "plan.json")+`" ;;
+esac
+`)
+
+	planResult, err := impl.TFPlan(context.Background(), &TerraformStation.TFCommandInput{Workspace: "staging"})
+	require.NoError(t, err)
+	assert.Equal(t, "completed", planResult.Status, planResult.ErrorMessage)
+	assert.Len(t, planResult.ResourceChanges, 6)
+}
+
+// testdataPath returns the absolute path of a file in testdata
+func testdataPath(t *testing.T, name string) string {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	return wd + "/testdata/" + name
+}
@@ -0,0 +1,192 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// uiStreamCommands are the commands that emit a stream of machine-readable UI messages when run with -json
+var uiStreamCommands = []string{"init", "plan", "apply", "destroy", "refresh"}
+
+// usesUIStream reports whether a command was run with -json and emits machine-readable UI messages
+func usesUIStream(command string, args []string) bool {
+	return contains(uiStreamCommands, command) && hasFlag(args, "-json")
+}
+
+type plannedChangesContextKey struct{}
+
+// withPlannedChanges records how many resources a command is expected to change, for commands
+// such as applying a saved plan that do not report their planned changes before applying them
+func withPlannedChanges(ctx context.Context, count int) context.Context {
+	return context.WithValue(ctx, plannedChangesContextKey{}, count)
+}
+
+// plannedChanges returns the number of resource changes recorded by withPlannedChanges, or 0
+func plannedChanges(ctx context.Context) int {
+	count, _ := ctx.Value(plannedChangesContextKey{}).(int)
+	return count
+}
+
+// progressTracker numbers the resources an operation changes in the order it reaches them
+type progressTracker struct {
+	total   int
+	planned map[string]int
+	started map[string]int
+}
+
+func newProgressTracker(total int) *progressTracker {
+	return &progressTracker{
+		total:   total,
+		planned: make(map[string]int),
+		started: make(map[string]int),
+	}
+}
+
+// observe returns the position of the resource an event refers to among the planned changes and
+// the number of planned changes, or a zero position for events that concern no single resource
+func (p *progressTracker) observe(event *TerraformStation.UIEvent) (index, total int) {
+	switch {
+	case event.Type == TerraformStation.UIEventPlannedChange && event.Change != nil:
+		address := event.Change.Resource.Addr
+		if _, ok := p.planned[address]; !ok {
+			p.planned[address] = len(p.planned) + 1
+		}
+		index = p.planned[address]
+	case event.Hook != nil:
+		address := event.Hook.Resource.Addr
+		if _, ok := p.started[address]; !ok {
+			p.started[address] = len(p.started) + 1
+		}
+		index = p.started[address]
+	}
+
+	// Never report resource N of M with N above M
+	p.total = max(p.total, len(p.planned), index)
+	return index, p.total
+}
+
+// recordEvent stores a machine-readable UI message of the recorded command with the progress it represents.
+// Like record, it is only called by the executor, which serializes calls.
+func (r *outputRecorder) recordEvent(event *TerraformStation.UIEvent, line string) {
+	r.eventSequence++
+	index, total := r.progress.observe(event)
+
+	record := &TerraformStation.TerraformOperationEvent{
+		CommandID:     r.commandID,
+		Sequence:      r.eventSequence,
+		Type:          event.Type,
+		Level:         event.Level,
+		Message:       event.Message,
+		ResourceIndex: index,
+		ResourceTotal: total,
+		Data:          line,
+		EmittedAt:     event.Timestamp,
+	}
+	switch {
+	case event.Hook != nil:
+		record.Address = event.Hook.Resource.Addr
+		record.Action = event.Hook.Action
+		record.ElapsedSeconds = event.Hook.ElapsedSeconds
+	case event.Change != nil:
+		record.Address = event.Change.Resource.Addr
+		record.Action = event.Change.Action
+	case event.Diagnostic != nil:
+		record.Address = event.Diagnostic.Address
+	}
+	if record.EmittedAt.IsZero() {
+		record.EmittedAt = time.Now()
+	}
+
+	if err := r.impl.store.CreateOperationEvent(record); err != nil {
+		log.Printf("failed to persist progress of %s: %v", r.commandID, err)
+	}
+	r.impl.broker.notify(r.commandID)
+}
+
+// TFSubscribeProgress replays the progress events of a command run with -json and follows them until the command
+// finishes, whether it runs on this station or another one sharing the database
+func (impl *TerraformStationImpl) TFSubscribeProgress(ctx context.Context, input *TerraformStation.TFSubscribeInput, handler TerraformStation.ProgressHandler) error {
+	if input == nil || input.CommandId == "" {
+		return TerraformStation.NewInvalidInputError("command id cannot be empty")
+	}
+	if handler == nil {
+		return TerraformStation.NewInvalidInputError("progress handler cannot be nil")
+	}
+
+	if _, err := impl.store.GetOperationByCommandID(input.CommandId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return TerraformStation.NewNotFoundError("operation not found", input.CommandId)
+		}
+		return TerraformStation.NewExecutionFailedError("failed to read operation", err.Error())
+	}
+
+	last := input.AfterSequence
+	for {
+		// Watch before reading so events persisted in between are not missed
+		updated, finished, err := impl.follow(input.CommandId)
+		if err != nil {
+			return err
+		}
+
+		events, err := impl.store.ListOperationEvents(input.CommandId, last)
+		if err != nil {
+			return TerraformStation.NewExecutionFailedError("failed to read command progress", err.Error())
+		}
+		for i := range events {
+			if err := handler(progressEventToProto(&events[i])); err != nil {
+				return err
+			}
+			last = events[i].Sequence
+		}
+
+		if finished {
+			return nil
+		}
+
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// progressEventToProto converts a persisted UI message to its API representation
+func progressEventToProto(event *TerraformStation.TerraformOperationEvent) *TerraformStation.TFProgressEvent {
+	progress := &TerraformStation.TFProgressEvent{
+		CommandId:      event.CommandID,
+		Sequence:       event.Sequence,
+		Type:           event.Type,
+		Level:          event.Level,
+		Message:        event.Message,
+		Address:        event.Address,
+		Action:         event.Action,
+		ElapsedSeconds: event.ElapsedSeconds,
+		ResourceIndex:  int32(event.ResourceIndex),
+		ResourceTotal:  int32(event.ResourceTotal),
+		EmittedAt:      timestamppb.New(event.EmittedAt),
+	}
+
+	// The change summary and diagnostic are only kept in the message itself
+	if ui, err := TerraformStation.ParseUIEvent(event.Data); err == nil {
+		if ui.Changes != nil {
+			progress.Changes = &TerraformStation.TFChangeSummary{
+				Add:       int32(ui.Changes.Add),
+				Change:    int32(ui.Changes.Change),
+				Import:    int32(ui.Changes.Import),
+				Remove:    int32(ui.Changes.Remove),
+				Operation: ui.Changes.Operation,
+			}
+		}
+		if ui.Diagnostic != nil {
+			progress.Diagnostic = diagnosticToProto(ui.Diagnostic)
+		}
+	}
+	return progress
+}
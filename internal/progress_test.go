@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const plannedChangesJSONL = `{"@level":"info","@message":"local_file.a: Plan to create","@module":"tofu.ui","@timestamp":"2025-08-15T10:00:00.500000Z","change":{"resource":{"addr":"local_file.a","module":"","resource":"local_file.a","implied_provider":"local","resource_type":"local_file","resource_name":"a"},"action":"create"},"type":"planned_change"}
+{"@level":"info","@message":"local_file.b: Plan to create","@module":"tofu.ui","@timestamp":"2025-08-15T10:00:00.500000Z","change":{"resource":{"addr":"local_file.b","module":"","resource":"local_file.b","implied_provider":"local","resource_type":"local_file","resource_name":"b"},"action":"create"},"type":"planned_change"}
+{"@level":"info","@message":"aws_instance.web: Plan to replace","@module":"tofu.ui","@timestamp":"2025-08-15T10:00:00.500000Z","change":{"resource":{"addr":"aws_instance.web","module":"","resource":"aws_instance.web","implied_provider":"aws","resource_type":"aws_instance","resource_name":"web"},"action":"replace","reason":"cannot_update"},"type":"planned_change"}
+`
+
+func TestTFSubscribeProgress(t *testing.T) {
+	apply, err := os.ReadFile(testdataPath(t, "apply.jsonl"))
+	require.NoError(t, err)
+
+	impl, fake := newFakeTestImpl(t)
+	// Planned changes follow the version message, ahead of the apply hooks
+	version, hooks, _ := strings.Cut(string(apply), "\n")
+	fake.On("apply").Stdout(version + "\n" + plannedChangesJSONL + hooks).ExitCode(1)
+
+	applyResult, err := impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	assert.False(t, applyResult.Success)
+
+	var operation TerraformStation.TerraformOperation
+	require.NoError(t, impl.db.Where("command = ?", "apply").First(&operation).Error)
+
+	var events []*TerraformStation.TFProgressEvent
+	err = impl.TFSubscribeProgress(context.Background(), &TerraformStation.TFSubscribeInput{CommandId: operation.CommandID},
+		func(event *TerraformStation.TFProgressEvent) error {
+			events = append(events, event)
+			return nil
+		})
+	require.NoError(t, err)
+
+	require.Len(t, events, 14)
+	for i, event := range events {
+		assert.Equal(t, int64(i+1), event.Sequence)
+		assert.Equal(t, operation.CommandID, event.CommandId)
+	}
+
+	replace := events[3]
+	assert.Equal(t, TerraformStation.UIEventPlannedChange, replace.Type)
+	assert.Equal(t, "aws_instance.web", replace.Address)
+	assert.Equal(t, "replace", replace.Action)
+	assert.Equal(t, int32(3), replace.ResourceIndex)
+	assert.Equal(t, int32(3), replace.ResourceTotal)
+
+	started := events[5]
+	assert.Equal(t, TerraformStation.UIEventApplyStart, started.Type)
+	assert.Equal(t, "local_file.b", started.Address)
+	assert.Equal(t, int32(2), started.ResourceIndex)
+	assert.Equal(t, int32(3), started.ResourceTotal)
+
+	completed := events[10]
+	assert.Equal(t, TerraformStation.UIEventApplyComplete, completed.Type)
+	assert.Equal(t, "aws_instance.web", completed.Address)
+	assert.Equal(t, int32(3), completed.ResourceIndex)
+	assert.Equal(t, 30.0, completed.ElapsedSeconds)
+
+	diagnostic := events[12]
+	require.NotNil(t, diagnostic.Diagnostic)
+	assert.Equal(t, "local_file.b", diagnostic.Address)
+	assert.Equal(t, "permission denied", diagnostic.Diagnostic.Detail)
+
+	summary := events[13]
+	require.NotNil(t, summary.Changes)
+	assert.Equal(t, int32(2), summary.Changes.Add)
+	assert.Equal(t, int32(1), summary.Changes.Remove)
+	assert.Zero(t, summary.ResourceIndex)
+
+	// Commands run without -json report no progress
+	result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "version"})
+	require.NoError(t, err)
+	events = nil
+	require.NoError(t, impl.TFSubscribeProgress(context.Background(), &TerraformStation.TFSubscribeInput{CommandId: result.CommandId},
+		func(event *TerraformStation.TFProgressEvent) error {
+			events = append(events, event)
+			return nil
+		}))
+	assert.Empty(t, events)
+}
+
+func TestProgressTrackerPlannedChanges(t *testing.T) {
+	hook := func(address string) *TerraformStation.UIEvent {
+		return &TerraformStation.UIEvent{
+			Type: TerraformStation.UIEventApplyStart,
+			Hook: &TerraformStation.UIHook{Resource: TerraformStation.UIResource{Addr: address}},
+		}
+	}
+
+	// A saved plan sets the total before anything is applied
+	tracker := newProgressTracker(plannedChanges(withPlannedChanges(context.Background(), 2)))
+	index, total := tracker.observe(hook("local_file.a"))
+	assert.Equal(t, 1, index)
+	assert.Equal(t, 2, total)
+
+	index, total = tracker.observe(hook("local_file.a"))
+	assert.Equal(t, 1, index)
+	assert.Equal(t, 2, total)
+
+	// An unexpected resource raises the total rather than going past it
+	tracker.observe(hook("local_file.b"))
+	index, total = tracker.observe(hook("local_file.c"))
+	assert.Equal(t, 3, index)
+	assert.Equal(t, 3, total)
+
+	index, total = tracker.observe(&TerraformStation.UIEvent{Type: TerraformStation.UIEventChangeSummary})
+	assert.Zero(t, index)
+	assert.Equal(t, 3, total)
+}
+
+func TestTFSubscribeProgressUnknownCommand(t *testing.T) {
+	impl, _ := newFakeTestImpl(t)
+
+	err := impl.TFSubscribeProgress(context.Background(), &TerraformStation.TFSubscribeInput{CommandId: "missing"},
+		func(*TerraformStation.TFProgressEvent) error { return nil })
+	assertErrorCode(t, err, TerraformStation.ErrCodeNotFound)
+}
@@ -0,0 +1,214 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newRedactor returns a redactor for the output of a run, masking the configured patterns and
+// provider credentials along with the given secret values
+func (impl *TerraformStationImpl) newRedactor(values ...string) *TerraformStation.Redactor {
+	return TerraformStation.NewRedactor(impl.redaction, append(impl.cfg.Providers.SecretValues(), values...)...)
+}
+
+// emitsValuesJSON reports whether a command prints plan, state or output values as JSON, where
+// OpenTofu marks the values that are sensitive
+func emitsValuesJSON(command string, args []string) bool {
+	return (command == "show" || command == "output") && hasFlag(args, "-json")
+}
+
+// learnSensitiveValues holds stdout back until it forms a JSON document, then has the redactor mask
+// the values the document marks sensitive before passing the lines on. The returned function passes
+// on whatever is still held once the command has finished.
+func learnSensitiveValues(redactor *TerraformStation.Redactor, next TerraformStation.LineHandler) (TerraformStation.LineHandler, func()) {
+	var (
+		held     []string
+		document strings.Builder
+	)
+	flush := func() {
+		for _, line := range held {
+			next(TerraformStation.StreamStdout, line)
+		}
+		held = nil
+		document.Reset()
+	}
+
+	return func(stream, line string) {
+		if stream != TerraformStation.StreamStdout {
+			next(stream, line)
+			return
+		}
+		held = append(held, line)
+		document.WriteString(line)
+		document.WriteByte('\n')
+
+		// A document can only be complete on a line closing an object or array
+		if end := strings.TrimSpace(line); !strings.HasSuffix(end, "}") && !strings.HasSuffix(end, "]") {
+			return
+		}
+		if data := []byte(document.String()); json.Valid(data) {
+			redactor.AddValues(sensitiveValues(data)...)
+			flush()
+		}
+	}, flush
+}
+
+// sensitiveValues returns the string values a plan, state or output JSON document marks sensitive:
+// those under sensitive_values, before_sensitive and after_sensitive masks, outputs flagged
+// sensitive, and the values of input variables the configuration declares sensitive
+func sensitiveValues(data []byte) []string {
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil
+	}
+
+	var values []string
+	collectSensitive(document, &values)
+
+	root, _ := document.(map[string]interface{})
+	variables, _ := root["variables"].(map[string]interface{})
+	declared, _ := lookupJSON(root, "configuration", "root_module", "variables").(map[string]interface{})
+	for name, declaration := range declared {
+		if sensitive, _ := lookupJSON(declaration, "sensitive").(bool); sensitive {
+			collectLeaves(lookupJSON(variables[name], "value"), &values)
+		}
+	}
+	return values
+}
+
+// collectSensitive walks a JSON document for values marked sensitive
+func collectSensitive(node interface{}, values *[]string) {
+	switch n := node.(type) {
+	case []interface{}:
+		for _, child := range n {
+			collectSensitive(child, values)
+		}
+	case map[string]interface{}:
+		for _, pair := range [][2]string{{"values", "sensitive_values"}, {"before", "before_sensitive"}, {"after", "after_sensitive"}} {
+			if mask, ok := n[pair[1]]; ok {
+				collectMasked(n[pair[0]], mask, values)
+			}
+		}
+		if sensitive, _ := n["sensitive"].(bool); sensitive {
+			collectLeaves(n["value"], values)
+		}
+		for _, child := range n {
+			collectSensitive(child, values)
+		}
+	}
+}
+
+// collectMasked walks a value alongside its sensitivity mask, collecting the parts marked true
+func collectMasked(value, mask interface{}, values *[]string) {
+	switch m := mask.(type) {
+	case bool:
+		if m {
+			collectLeaves(value, values)
+		}
+	case map[string]interface{}:
+		if v, ok := value.(map[string]interface{}); ok {
+			for key, child := range m {
+				collectMasked(v[key], child, values)
+			}
+		}
+	case []interface{}:
+		if v, ok := value.([]interface{}); ok {
+			for i := range v {
+				if i < len(m) {
+					collectMasked(v[i], m[i], values)
+				}
+			}
+		}
+	}
+}
+
+// collectLeaves collects the strings within a value that are long enough to mask
+func collectLeaves(value interface{}, values *[]string) {
+	switch v := value.(type) {
+	case string:
+		if len(v) >= TerraformStation.MinRedactedValueLength {
+			*values = append(*values, v)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectLeaves(child, values)
+		}
+	case map[string]interface{}:
+		for _, child := range v {
+			collectLeaves(child, values)
+		}
+	}
+}
+
+// lookupJSON follows a path of object keys through a decoded JSON value, returning nil if it is missing
+func lookupJSON(node interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		node = object[key]
+	}
+	return node
+}
+
+// redactStateInfo masks the values of state information parsed from unmasked output
+func redactStateInfo(redactor *TerraformStation.Redactor, info *TerraformStation.TFStateInfo) {
+	for _, resource := range info.Resources {
+		redactValue(redactor, resource.Index)
+		redactValue(redactor, resource.Values)
+	}
+	for _, output := range info.Outputs {
+		redactValue(redactor, output.Value)
+	}
+}
+
+// redactValue masks the strings within a value parsed from unmasked output
+func redactValue(redactor *TerraformStation.Redactor, value *structpb.Value) {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		kind.StringValue = redactor.Redact(kind.StringValue)
+	case *structpb.Value_ListValue:
+		for _, child := range kind.ListValue.GetValues() {
+			redactValue(redactor, child)
+		}
+	case *structpb.Value_StructValue:
+		for _, child := range kind.StructValue.GetFields() {
+			redactValue(redactor, child)
+		}
+	}
+}
+
+// redactJSON masks the strings within a JSON document read from unmasked output. Masking the document
+// as text could cut through its structure, so it is decoded and its strings are masked one by one.
+func redactJSON(redactor *TerraformStation.Redactor, data []byte) (string, error) {
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(redactLeaves(redactor, document))
+	return string(encoded), err
+}
+
+// redactLeaves masks the strings within a decoded JSON value
+func redactLeaves(redactor *TerraformStation.Redactor, node interface{}) interface{} {
+	switch n := node.(type) {
+	case string:
+		return redactor.Redact(n)
+	case []interface{}:
+		for i, child := range n {
+			n[i] = redactLeaves(redactor, child)
+		}
+	case map[string]interface{}:
+		for key, child := range n {
+			n[key] = redactLeaves(redactor, child)
+		}
+	}
+	return node
+}
@@ -0,0 +1,182 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+)
+
+// stateChangingCommands are the commands that apply changes to infrastructure
+var stateChangingCommands = []string{"apply", "destroy"}
+
+// stateWritingCommands are the commands that write state, invalidating plans made before them
+var stateWritingCommands = []string{"apply", "destroy", "import", "refresh", "taint", "untaint"}
+
+// stateWritingStateSubcommands are the `state` subcommands that write state
+var stateWritingStateSubcommands = []string{"rm", "mv", "push", "replace-provider"}
+
+// stateWritingWorkspaceSubcommands are the `workspace` subcommands that write state
+var stateWritingWorkspaceSubcommands = []string{"delete"}
+
+// writesState reports whether a command writes the state of its workspace
+func writesState(input *TerraformStation.TFCommandInput) bool {
+	switch input.Command {
+	case "state":
+		return len(input.Arguments) > 0 && slices.Contains(stateWritingStateSubcommands, input.Arguments[0])
+	case "workspace":
+		return len(input.Arguments) > 0 && slices.Contains(stateWritingWorkspaceSubcommands, input.Arguments[0])
+	}
+	return slices.Contains(stateWritingCommands, input.Command)
+}
+
+// planFilePath returns the location of the binary plan file for a plan ID, creating the plan directory if needed
+func (impl *TerraformStationImpl) planFilePath(planID string) (string, error) {
+	planDir, err := filepath.Abs(impl.cfg.PlanDirectory)
+	if err != nil {
+		return "", TerraformStation.NewExecutionFailedError("failed to resolve plan directory", err.Error())
+	}
+	if err := os.MkdirAll(planDir, 0700); err != nil {
+		return "", TerraformStation.NewExecutionFailedError("failed to create plan directory", err.Error())
+	}
+	return filepath.Join(planDir, planID+".tfplan"), nil
+}
+
+// claimPlan checks that a saved plan is approved and may be applied from the input's working
+// directory and workspace, and marks it as being applied so it cannot be applied twice
+func (impl *TerraformStationImpl) claimPlan(input *TerraformStation.TFCommandInput) (*TerraformStation.TerraformPlan, error) {
+	if len(input.Variables) > 0 {
+		return nil, TerraformStation.NewInvalidInputError("variables cannot be set when applying a saved plan")
+	}
+	if input.PlanFile != "" {
+		return nil, TerraformStation.NewInvalidInputError("plan_file and plan_id cannot both be set")
+	}
+
+	workingDir, err := impl.resolveWorkingDir(input)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := impl.loadPlan(input.PlanId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := impl.checkPlanApproved(plan); err != nil {
+		return nil, err
+	}
+
+	if plan.WorkingDir != workingDir {
+		return nil, TerraformStation.NewInvalidStateError("plan was created for a different working directory", plan.WorkingDir)
+	}
+	if input.Workspace != "" && input.Workspace != plan.Workspace {
+		return nil, TerraformStation.NewInvalidStateError("plan was created for a different workspace", plan.Workspace)
+	}
+
+	if err := impl.checkPlanFresh(plan); err != nil {
+		return nil, err
+	}
+
+	checksum, err := fileChecksum(plan.PlanFile)
+	if err != nil || checksum != plan.Checksum {
+		return nil, TerraformStation.NewInvalidStateError("plan file has been modified or removed", plan.PlanID)
+	}
+
+	claimed, err := impl.store.TransitionPlanStatus(plan.PlanID, plan.Status, TerraformStation.PlanStatusApplying)
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to update plan", err.Error())
+	}
+	if !claimed {
+		return nil, TerraformStation.NewInvalidStateError("plan has already been applied", plan.PlanID)
+	}
+
+	return plan, nil
+}
+
+type claimedPlanContextKey struct{}
+
+// withClaimedPlan records the saved plan a command applies, so its freshness can be checked again
+// once the command holds the working directory lock
+func withClaimedPlan(ctx context.Context, plan *TerraformStation.TerraformPlan) context.Context {
+	return context.WithValue(ctx, claimedPlanContextKey{}, plan)
+}
+
+// claimedPlan returns the plan recorded by withClaimedPlan, or nil
+func claimedPlan(ctx context.Context) *TerraformStation.TerraformPlan {
+	plan, _ := ctx.Value(claimedPlanContextKey{}).(*TerraformStation.TerraformPlan)
+	return plan
+}
+
+// checkPlanFresh rejects plans that have expired or that predate a change to the working directory's state,
+// whether made by a command writing state or by rolling the state back
+func (impl *TerraformStationImpl) checkPlanFresh(plan *TerraformStation.TerraformPlan) error {
+	if impl.cfg.PlanMaxAge > 0 && time.Since(plan.CreatedAt) > impl.cfg.PlanMaxAge {
+		return TerraformStation.NewInvalidStateError("plan is stale", "created more than "+impl.cfg.PlanMaxAge.String()+" ago")
+	}
+
+	count, err := impl.store.CountStateWritesSince(plan.WorkingDir, plan.Workspace, plan.CreatedAt)
+	if err != nil {
+		return TerraformStation.NewExecutionFailedError("failed to read operations", err.Error())
+	}
+	if count > 0 {
+		return TerraformStation.NewInvalidStateError("plan is stale", "state has changed since the plan was created")
+	}
+
+	state := localState{plan.WorkingDir, plan.Workspace}
+	if count, err = impl.store.CountStateVersionsSince(state.name(), stateOperationRollback, plan.CreatedAt); err != nil {
+		return TerraformStation.NewExecutionFailedError("failed to read state versions", err.Error())
+	}
+	if count > 0 {
+		return TerraformStation.NewInvalidStateError("plan is stale", "state was rolled back since the plan was created")
+	}
+
+	return nil
+}
+
+// releasePlan records the outcome of applying a claimed plan. A plan whose apply never
+// started returns to the status it was claimed from; otherwise it is marked applied and its file removed.
+func (impl *TerraformStationImpl) releasePlan(plan *TerraformStation.TerraformPlan, started bool) {
+	if !started {
+		if _, err := impl.store.TransitionPlanStatus(plan.PlanID, TerraformStation.PlanStatusApplying, plan.Status); err != nil {
+			log.Printf("failed to release plan %s: %v", plan.PlanID, err)
+		}
+		return
+	}
+
+	if err := impl.store.MarkPlanApplied(plan.PlanID, time.Now()); err != nil {
+		log.Printf("failed to mark plan %s as applied: %v", plan.PlanID, err)
+	}
+	discardPlanFile(plan)
+}
+
+// discardPlanFile removes the file of a plan that can no longer be applied
+func discardPlanFile(plan *TerraformStation.TerraformPlan) {
+	if plan.PlanFile == "" {
+		return
+	}
+	if err := os.Remove(plan.PlanFile); err != nil && !os.IsNotExist(err) {
+		log.Printf("failed to remove plan file of %s: %v", plan.PlanID, err)
+	}
+}
+
+// fileChecksum returns the hex-encoded SHA-256 digest of a file
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
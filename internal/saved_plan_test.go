@@ -0,0 +1,242 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePlanFile is a shell snippet that writes the file named by a -out flag, as plan does
+const writePlanFile = `for arg in "$@"; do case "$arg" in -out=*) echo "saved plan" > "${arg#-out=}" ;; esac; done`
+
+// newSavedPlanTestImpl creates an implementation whose opentofu binary can plan, show and apply
+func newSavedPlanTestImpl(t *testing.T) *TerraformStationImpl {
+	return newScriptTestImpl(t, `case "$1" in
+plan) `+writePlanFile+`; echo "Plan: 1 to add, 0 to change, 0 to destroy." ;;
+show) echo '{"resource_changes":[{"address":"local_file.hello","change":{"actions":["create"]}}]}' ;;
+apply) echo "Apply complete! Resources: 1 added, 0 changed, 0 destroyed." ;;
+esac
+`)
+}
+
+// assertErrorCode checks that err is a TerraformError with the given code
+func assertErrorCode(t *testing.T, err error, code string) {
+	var tfErr *TerraformStation.TerraformError
+	require.ErrorAs(t, err, &tfErr)
+	assert.Equal(t, code, tfErr.Code)
+}
+
+func TestApplySavedPlan(t *testing.T) {
+	impl := newSavedPlanTestImpl(t)
+
+	planResult, err := impl.TFPlan(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	require.Equal(t, TerraformStation.PlanStatusCompleted, planResult.Status)
+
+	plan, err := impl.store.GetPlanByPlanID(planResult.PlanId)
+	require.NoError(t, err)
+	assert.FileExists(t, plan.PlanFile)
+	assert.NotEmpty(t, plan.Checksum)
+	assert.Equal(t, impl.workingDir, plan.WorkingDir)
+
+	applyResult, err := impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{PlanId: planResult.PlanId})
+	require.NoError(t, err)
+	assert.True(t, applyResult.Success)
+	assert.Equal(t, planResult.PlanId, applyResult.PlanId)
+
+	var apply TerraformStation.TerraformApply
+	require.NoError(t, impl.db.Where("apply_id = ?", applyResult.ApplyId).First(&apply).Error)
+	assert.Equal(t, planResult.PlanId, apply.PlanID)
+
+	operation, err := impl.store.GetOperationByID(apply.OperationID)
+	require.NoError(t, err)
+	assert.Contains(t, operation.Arguments, plan.PlanFile)
+
+	plan, err = impl.store.GetPlanByPlanID(planResult.PlanId)
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.PlanStatusApplied, plan.Status)
+	assert.NotNil(t, plan.AppliedAt)
+	assert.NoFileExists(t, plan.PlanFile)
+
+	// A plan can only be applied once
+	_, err = impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{PlanId: planResult.PlanId})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+}
+
+func TestApplySavedPlanRejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, impl *TerraformStationImpl, plan *TerraformStation.TerraformPlan) *TerraformStation.TFCommandInput
+		code  string
+	}{
+		{
+			name: "unknown plan",
+			setup: func(t *testing.T, impl *TerraformStationImpl, plan *TerraformStation.TerraformPlan) *TerraformStation.TFCommandInput {
+				return &TerraformStation.TFCommandInput{PlanId: "tofu_missing"}
+			},
+			code: TerraformStation.ErrCodeNotFound,
+		},
+		{
+			name: "different working directory",
+			setup: func(t *testing.T, impl *TerraformStationImpl, plan *TerraformStation.TerraformPlan) *TerraformStation.TFCommandInput {
+				return &TerraformStation.TFCommandInput{PlanId: plan.PlanID, WorkingDirectory: t.TempDir()}
+			},
+			code: TerraformStation.ErrCodeInvalidState,
+		},
+		{
+			name: "different workspace",
+			setup: func(t *testing.T, impl *TerraformStationImpl, plan *TerraformStation.TerraformPlan) *TerraformStation.TFCommandInput {
+				return &TerraformStation.TFCommandInput{PlanId: plan.PlanID, Workspace: "production"}
+			},
+			code: TerraformStation.ErrCodeInvalidState,
+		},
+		{
+			name: "state changed since plan",
+			setup: func(t *testing.T, impl *TerraformStationImpl, plan *TerraformStation.TerraformPlan) *TerraformStation.TFCommandInput {
+				_, err := impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{})
+				require.NoError(t, err)
+				return &TerraformStation.TFCommandInput{PlanId: plan.PlanID}
+			},
+			code: TerraformStation.ErrCodeInvalidState,
+		},
+		{
+			name: "resource imported since plan",
+			setup: func(t *testing.T, impl *TerraformStationImpl, plan *TerraformStation.TerraformPlan) *TerraformStation.TFCommandInput {
+				_, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "import", Arguments: []string{"local_file.hello", "hello.txt"}})
+				require.NoError(t, err)
+				return &TerraformStation.TFCommandInput{PlanId: plan.PlanID}
+			},
+			code: TerraformStation.ErrCodeInvalidState,
+		},
+		{
+			name: "resource removed from state since plan",
+			setup: func(t *testing.T, impl *TerraformStationImpl, plan *TerraformStation.TerraformPlan) *TerraformStation.TFCommandInput {
+				_, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "state", Arguments: []string{"rm", "local_file.hello"}})
+				require.NoError(t, err)
+				return &TerraformStation.TFCommandInput{PlanId: plan.PlanID}
+			},
+			code: TerraformStation.ErrCodeInvalidState,
+		},
+		{
+			name: "state rolled back since plan",
+			setup: func(t *testing.T, impl *TerraformStationImpl, plan *TerraformStation.TerraformPlan) *TerraformStation.TFCommandInput {
+				require.NoError(t, impl.store.CreateStateVersion(&TerraformStation.TerraformStateVersion{
+					Name:      localState{plan.WorkingDir, plan.Workspace}.name(),
+					Version:   2,
+					Lineage:   "lineage",
+					Checksum:  "checksum",
+					Operation: stateOperationRollback,
+				}))
+				return &TerraformStation.TFCommandInput{PlanId: plan.PlanID}
+			},
+			code: TerraformStation.ErrCodeInvalidState,
+		},
+		{
+			name: "expired plan",
+			setup: func(t *testing.T, impl *TerraformStationImpl, plan *TerraformStation.TerraformPlan) *TerraformStation.TFCommandInput {
+				createdAt := time.Now().Add(-impl.cfg.PlanMaxAge - time.Minute)
+				require.NoError(t, impl.db.Model(plan).Update("created_at", createdAt).Error)
+				return &TerraformStation.TFCommandInput{PlanId: plan.PlanID}
+			},
+			code: TerraformStation.ErrCodeInvalidState,
+		},
+		{
+			name: "modified plan file",
+			setup: func(t *testing.T, impl *TerraformStationImpl, plan *TerraformStation.TerraformPlan) *TerraformStation.TFCommandInput {
+				require.NoError(t, os.WriteFile(plan.PlanFile, []byte("tampered"), 0600))
+				return &TerraformStation.TFCommandInput{PlanId: plan.PlanID}
+			},
+			code: TerraformStation.ErrCodeInvalidState,
+		},
+		{
+			name: "variables with saved plan",
+			setup: func(t *testing.T, impl *TerraformStationImpl, plan *TerraformStation.TerraformPlan) *TerraformStation.TFCommandInput {
+				return &TerraformStation.TFCommandInput{PlanId: plan.PlanID, Variables: map[string]string{"region": "eu-west-1"}}
+			},
+			code: TerraformStation.ErrCodeInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impl := newSavedPlanTestImpl(t)
+
+			planResult, err := impl.TFPlan(context.Background(), &TerraformStation.TFCommandInput{})
+			require.NoError(t, err)
+			plan, err := impl.store.GetPlanByPlanID(planResult.PlanId)
+			require.NoError(t, err)
+
+			_, err = impl.TFApply(context.Background(), tt.setup(t, impl, plan))
+			assertErrorCode(t, err, tt.code)
+
+			// A rejected plan is left untouched
+			plan, err = impl.store.GetPlanByPlanID(planResult.PlanId)
+			require.NoError(t, err)
+			assert.Equal(t, TerraformStation.PlanStatusCompleted, plan.Status)
+		})
+	}
+}
+
+func TestApplySavedPlanRechecksFreshnessUnderLock(t *testing.T) {
+	impl := newSavedPlanTestImpl(t)
+
+	planResult, err := impl.TFPlan(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	plan, err := impl.store.GetPlanByPlanID(planResult.PlanId)
+	require.NoError(t, err)
+
+	// Another apply finishes after the plan was claimed, but before its apply takes the lock
+	require.NoError(t, impl.store.CreateOperation(&TerraformStation.TerraformOperation{
+		CommandID:   "tofu_other_apply",
+		Command:     "apply",
+		WorkingDir:  plan.WorkingDir,
+		Workspace:   plan.Workspace,
+		Status:      TerraformStation.OperationStatusSucceeded,
+		WritesState: true,
+		StartedAt:   time.Now(),
+	}))
+
+	ctx := withClaimedPlan(context.Background(), plan)
+	_, _, err = impl.runCommand(ctx, &TerraformStation.TFCommandInput{Command: "apply", Arguments: []string{"-auto-approve"}, PlanFile: plan.PlanFile}, nil)
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+
+	var count int64
+	require.NoError(t, impl.db.Model(&TerraformStation.TerraformOperation{}).Where("command = ?", "apply").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestReadingStateKeepsPlanFresh(t *testing.T) {
+	impl := newSavedPlanTestImpl(t)
+
+	planResult, err := impl.TFPlan(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	_, err = impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "state", Arguments: []string{"list"}})
+	require.NoError(t, err)
+
+	applyResult, err := impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{PlanId: planResult.PlanId})
+	require.NoError(t, err)
+	assert.True(t, applyResult.Success)
+}
+
+func TestApplyFailedPlanRejected(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo \"Error: boom\" >&2\nexit 1\n")
+
+	planResult, err := impl.TFPlan(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.PlanStatusFailed, planResult.Status)
+
+	_, err = impl.TFApply(context.Background(), &TerraformStation.TFCommandInput{PlanId: planResult.PlanId})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+}
+
+func TestTFPlanRejectsOutFlag(t *testing.T) {
+	impl := newSavedPlanTestImpl(t)
+
+	_, err := impl.TFPlan(context.Background(), &TerraformStation.TFCommandInput{Arguments: []string{"-out=elsewhere"}})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+}
@@ -0,0 +1,237 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// secretVarFileName is the name of the var-file holding the secret input variables of a run
+const secretVarFileName = "secrets.tfvars.json"
+
+// TFPutSecret encrypts and stores a secret, replacing the value of any secret with the same name
+func (impl *TerraformStationImpl) TFPutSecret(ctx context.Context, input *TerraformStation.TFPutSecretInput) (*TerraformStation.TFSecret, error) {
+	if input == nil {
+		return nil, TerraformStation.NewInvalidInputError("input cannot be nil")
+	}
+	if err := TerraformStation.ValidateSecretName(input.Name); err != nil {
+		return nil, err
+	}
+	if input.Value == "" {
+		return nil, TerraformStation.NewInvalidInputError("secret value cannot be empty", input.Name)
+	}
+	cipher, err := impl.secretCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := cipher.Encrypt(input.Name, []byte(input.Value))
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to encrypt secret", input.Name)
+	}
+	secret := &TerraformStation.TerraformSecret{
+		Name:       input.Name,
+		Ciphertext: ciphertext,
+		KeyID:      cipher.KeyID(),
+	}
+	if err := impl.store.PutSecret(secret); err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to store secret", err.Error())
+	}
+
+	// Read it back for the creation time of a replaced secret
+	if secret, err = impl.loadSecret(input.Name); err != nil {
+		return nil, err
+	}
+	return secretToProto(secret), nil
+}
+
+// TFListSecrets lists the stored secrets without their values
+func (impl *TerraformStationImpl) TFListSecrets(ctx context.Context, input *TerraformStation.TFListSecretsInput) (*TerraformStation.TFSecretList, error) {
+	secrets, err := impl.store.ListSecrets()
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to list secrets", err.Error())
+	}
+
+	list := &TerraformStation.TFSecretList{}
+	for i := range secrets {
+		list.Secrets = append(list.Secrets, secretToProto(&secrets[i]))
+	}
+	return list, nil
+}
+
+// TFDeleteSecret deletes a secret. Runs that reference it fail from then on.
+func (impl *TerraformStationImpl) TFDeleteSecret(ctx context.Context, input *TerraformStation.TFSecretInput) (*TerraformStation.TFSecret, error) {
+	if input == nil || input.Name == "" {
+		return nil, TerraformStation.NewInvalidInputError("secret name cannot be empty")
+	}
+
+	secret, err := impl.loadSecret(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := impl.store.DeleteSecret(input.Name)
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to delete secret", err.Error())
+	}
+	if !deleted {
+		return nil, TerraformStation.NewNotFoundError("secret not found", input.Name)
+	}
+	return secretToProto(secret), nil
+}
+
+// secretCipher returns the cipher of the secret store, which is only available with a master key
+func (impl *TerraformStationImpl) secretCipher() (*TerraformStation.SecretCipher, error) {
+	if impl.secrets == nil {
+		return nil, TerraformStation.NewInvalidStateError("secret store is not configured, set secrets.master_key_file")
+	}
+	return impl.secrets, nil
+}
+
+// loadSecret retrieves a secret by its name
+func (impl *TerraformStationImpl) loadSecret(name string) (*TerraformStation.TerraformSecret, error) {
+	secret, err := impl.store.GetSecret(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, TerraformStation.NewNotFoundError("secret not found", name)
+		}
+		return nil, TerraformStation.NewExecutionFailedError("failed to read secret", err.Error())
+	}
+	return secret, nil
+}
+
+// secretValue decrypts the value of a secret
+func (impl *TerraformStationImpl) secretValue(cipher *TerraformStation.SecretCipher, name string) (string, error) {
+	secret, err := impl.loadSecret(name)
+	if err != nil {
+		return "", err
+	}
+	if secret.KeyID != cipher.KeyID() {
+		return "", TerraformStation.NewInvalidStateError("secret was encrypted with another master key", name, secret.KeyID)
+	}
+	value, err := cipher.Decrypt(secret.Name, secret.Ciphertext)
+	if err != nil {
+		return "", TerraformStation.NewExecutionFailedError("failed to decrypt secret", name)
+	}
+	return string(value), nil
+}
+
+// runSecrets are the secrets a run uses: the environment variables set from secrets, the var-file
+// holding its secret input variables and the decrypted values to mask in its output.
+// A nil runSecrets stands for a run without secrets.
+type runSecrets struct {
+	env     []string
+	varFile string
+	values  []string
+}
+
+// resolveSecrets decrypts the secrets an input references and writes its secret input variables to
+// a var-file only the station can read. Callers must call cleanup once the run has finished.
+func (impl *TerraformStationImpl) resolveSecrets(input *TerraformStation.TFCommandInput) (*runSecrets, error) {
+	if len(input.SecretVariables) == 0 && len(input.SecretEnv) == 0 {
+		return nil, nil
+	}
+	cipher, err := impl.secretCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := &runSecrets{}
+	variables := make(map[string]string, len(input.SecretVariables))
+	for name, secretName := range input.SecretVariables {
+		value, err := impl.secretValue(cipher, secretName)
+		if err != nil {
+			return nil, err
+		}
+		variables[name] = value
+		secrets.values = append(secrets.values, value)
+	}
+	for name, secretName := range input.SecretEnv {
+		value, err := impl.secretValue(cipher, secretName)
+		if err != nil {
+			return nil, err
+		}
+		secrets.env = append(secrets.env, name+"="+value)
+		secrets.values = append(secrets.values, value)
+	}
+	sort.Strings(secrets.env)
+
+	if len(variables) > 0 {
+		if secrets.varFile, err = writeSecretVarFile(variables); err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("failed to write secret variables", err.Error())
+		}
+	}
+	return secrets, nil
+}
+
+// writeSecretVarFile writes input variables to a var-file in a new temporary directory, readable by the station alone
+func writeSecretVarFile(variables map[string]string) (string, error) {
+	data, err := json.Marshal(variables)
+	if err != nil {
+		return "", err
+	}
+	dir, err := os.MkdirTemp("", "tofu-secrets-")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, secretVarFileName)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err == nil {
+		_, err = file.Write(data)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return path, nil
+}
+
+// varFiles returns the var-files to pass to the command of the run
+func (s *runSecrets) varFiles() []string {
+	if s == nil || s.varFile == "" {
+		return nil
+	}
+	return []string{s.varFile}
+}
+
+// environment returns the environment variables the run sets from secrets
+func (s *runSecrets) environment() []string {
+	if s == nil {
+		return nil
+	}
+	return s.env
+}
+
+// secretValues returns the decrypted values of the secrets the run uses
+func (s *runSecrets) secretValues() []string {
+	if s == nil {
+		return nil
+	}
+	return s.values
+}
+
+// cleanup removes the var-file of the run
+func (s *runSecrets) cleanup() {
+	if s != nil && s.varFile != "" {
+		os.RemoveAll(filepath.Dir(s.varFile))
+	}
+}
+
+// secretToProto converts a stored secret to its API representation, which never includes the value
+func secretToProto(secret *TerraformStation.TerraformSecret) *TerraformStation.TFSecret {
+	return &TerraformStation.TFSecret{
+		Name:      secret.Name,
+		KeyId:     secret.KeyID,
+		CreatedAt: timestamppb.New(secret.CreatedAt),
+		UpdatedAt: timestamppb.New(secret.UpdatedAt),
+	}
+}
@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// enableSecrets gives an implementation a secret store with a fixed master key
+func enableSecrets(t *testing.T, impl *TerraformStationImpl) {
+	cipher, err := TerraformStation.NewSecretCipher(bytes.Repeat([]byte{7}, TerraformStation.MasterKeySize))
+	require.NoError(t, err)
+	impl.secrets = cipher
+}
+
+func TestSecretStore(t *testing.T) {
+	impl, _ := newFakeTestImpl(t)
+	ctx := context.Background()
+
+	_, err := impl.TFPutSecret(ctx, &TerraformStation.TFPutSecretInput{Name: "db-password", Value: "hunter2"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+
+	enableSecrets(t, impl)
+	created, err := impl.TFPutSecret(ctx, &TerraformStation.TFPutSecretInput{Name: "db-password", Value: "hunter2"})
+	require.NoError(t, err)
+	assert.Equal(t, "db-password", created.Name)
+	assert.Equal(t, impl.secrets.KeyID(), created.KeyId)
+
+	// The value is only stored encrypted
+	stored, err := impl.store.GetSecret("db-password")
+	require.NoError(t, err)
+	assert.NotContains(t, stored.Ciphertext, "hunter2")
+
+	updated, err := impl.TFPutSecret(ctx, &TerraformStation.TFPutSecretInput{Name: "db-password", Value: "correct horse"})
+	require.NoError(t, err)
+	assert.Equal(t, created.CreatedAt.AsTime(), updated.CreatedAt.AsTime())
+	value, err := impl.secretValue(impl.secrets, "db-password")
+	require.NoError(t, err)
+	assert.Equal(t, "correct horse", value)
+
+	_, err = impl.TFPutSecret(ctx, &TerraformStation.TFPutSecretInput{Name: "../db", Value: "x"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+	_, err = impl.TFPutSecret(ctx, &TerraformStation.TFPutSecretInput{Name: "empty"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+
+	list, err := impl.TFListSecrets(ctx, &TerraformStation.TFListSecretsInput{})
+	require.NoError(t, err)
+	require.Len(t, list.Secrets, 1)
+	assert.Equal(t, "db-password", list.Secrets[0].Name)
+
+	_, err = impl.TFDeleteSecret(ctx, &TerraformStation.TFSecretInput{Name: "db-password"})
+	require.NoError(t, err)
+	_, err = impl.TFDeleteSecret(ctx, &TerraformStation.TFSecretInput{Name: "db-password"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeNotFound)
+}
+
+func TestSecretsAreNotDecryptedWithAnotherKey(t *testing.T) {
+	impl, _ := newFakeTestImpl(t)
+	enableSecrets(t, impl)
+	_, err := impl.TFPutSecret(context.Background(), &TerraformStation.TFPutSecretInput{Name: "token", Value: "abc"})
+	require.NoError(t, err)
+
+	impl.secrets, err = TerraformStation.NewSecretCipher(bytes.Repeat([]byte{8}, TerraformStation.MasterKeySize))
+	require.NoError(t, err)
+	_, err = impl.secretValue(impl.secrets, "token")
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+}
+
+func TestCommandWithSecrets(t *testing.T) {
+	// Print the permissions and contents of the var-file and the secret environment variable
+	impl := newScriptTestImpl(t, `for arg; do
+  case "$arg" in -var-file=*) f=${arg#-var-file=}; stat -c %a "$f"; cat "$f"; echo ;; esac
+done
+echo "password=$DB_PASSWORD"
+echo "token: correct horse" >&2
+exit 1
+`)
+	enableSecrets(t, impl)
+	ctx := context.Background()
+	_, err := impl.TFPutSecret(ctx, &TerraformStation.TFPutSecretInput{Name: "db", Value: "hunter2"})
+	require.NoError(t, err)
+	_, err = impl.TFPutSecret(ctx, &TerraformStation.TFPutSecretInput{Name: "token", Value: "correct horse"})
+	require.NoError(t, err)
+
+	var streamed strings.Builder
+	result, err := impl.TFCommandStream(ctx, &TerraformStation.TFCommandInput{
+		Command:         "plan",
+		Variables:       map[string]string{"env": "prod"},
+		SecretVariables: map[string]string{"db_password": "db", "api_token": "token"},
+		SecretEnv:       map[string]string{"DB_PASSWORD": "db"},
+	}, func(chunk *TerraformStation.TFOutputChunk) error {
+		streamed.WriteString(chunk.Line)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+
+	// The var-file is readable by the station alone, and secret values are masked everywhere
+	assert.Contains(t, result.Stdout, "600\n")
+	assert.Contains(t, result.Stdout, `{"api_token":"(sensitive value)","db_password":"(sensitive value)"}`)
+	assert.Contains(t, result.Stdout, "password=(sensitive value)\n")
+	assert.Equal(t, "token: (sensitive value)\n", result.Stderr)
+	for _, text := range []string{result.Result, result.Stdout, result.Stderr, result.ErrorMessage, streamed.String()} {
+		assert.NotContains(t, text, "hunter2")
+		assert.NotContains(t, text, "correct horse")
+	}
+
+	operation, err := impl.store.GetOperationByCommandID(result.CommandId)
+	require.NoError(t, err)
+	assert.NotContains(t, operation.Output, "hunter2")
+	assert.NotContains(t, operation.Stderr, "correct horse")
+	assert.JSONEq(t, `{"db_password":"db","api_token":"token"}`, operation.SecretVariables)
+	assert.JSONEq(t, `{"DB_PASSWORD":"db"}`, operation.SecretEnv)
+
+	// Secret values stay off the command line, and the var-file is removed after the run
+	var args []string
+	require.NoError(t, json.Unmarshal([]byte(operation.Arguments), &args))
+	assert.Contains(t, args, "-var=env=prod")
+	varFile := strings.TrimPrefix(args[len(args)-1], "-var-file=")
+	assert.Equal(t, secretVarFileName, varFile[len(varFile)-len(secretVarFileName):])
+	_, err = os.Stat(varFile)
+	assert.True(t, os.IsNotExist(err))
+
+	chunks, err := impl.store.ListOutputChunks(result.CommandId, 0)
+	require.NoError(t, err)
+	for _, chunk := range chunks {
+		assert.NotContains(t, chunk.Line, "hunter2")
+	}
+}
+
+func TestCommandWithUnknownSecret(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo ok\n")
+
+	input := &TerraformStation.TFCommandInput{Command: "plan", SecretVariables: map[string]string{"db_password": "db"}}
+	_, err := impl.TFCommand(context.Background(), input)
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+
+	enableSecrets(t, impl)
+	_, err = impl.TFCommand(context.Background(), input)
+	assertErrorCode(t, err, TerraformStation.ErrCodeNotFound)
+
+	_, err = impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "plan", SecretEnv: map[string]string{"TF_CLI_ARGS": "db"}})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+}
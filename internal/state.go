@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// stateJSON is the subset of the `tofu show -json` state representation used by the station
+type stateJSON struct {
+	FormatVersion    string           `json:"format_version"`
+	TerraformVersion string           `json:"terraform_version"`
+	Values           *stateValuesJSON `json:"values"`
+}
+
+type stateValuesJSON struct {
+	Outputs    map[string]stateOutputJSON `json:"outputs"`
+	RootModule stateModuleJSON            `json:"root_module"`
+}
+
+type stateOutputJSON struct {
+	Sensitive bool            `json:"sensitive"`
+	Value     json.RawMessage `json:"value"`
+	Type      json.RawMessage `json:"type"`
+}
+
+type stateModuleJSON struct {
+	Address      string              `json:"address"`
+	Resources    []stateResourceJSON `json:"resources"`
+	ChildModules []stateModuleJSON   `json:"child_modules"`
+}
+
+type stateResourceJSON struct {
+	Address         string          `json:"address"`
+	Mode            string          `json:"mode"`
+	Type            string          `json:"type"`
+	Name            string          `json:"name"`
+	Index           json.RawMessage `json:"index"`
+	ProviderName    string          `json:"provider_name"`
+	SchemaVersion   int32           `json:"schema_version"`
+	Values          json.RawMessage `json:"values"`
+	SensitiveValues json.RawMessage `json:"sensitive_values"`
+	DependsOn       []string        `json:"depends_on"`
+	Tainted         bool            `json:"tainted"`
+	DeposedKey      string          `json:"deposed_key"`
+}
+
+// parseStateJSON converts the JSON state representation into state information,
+// listing the resources of the root module and all child modules
+func parseStateJSON(data []byte) (*TerraformStation.TFStateInfo, error) {
+	var state stateJSON
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to parse state JSON", err.Error())
+	}
+
+	info := &TerraformStation.TFStateInfo{
+		FormatVersion:    state.FormatVersion,
+		TerraformVersion: state.TerraformVersion,
+	}
+	// An empty state has no values at all
+	if state.Values == nil {
+		return info, nil
+	}
+
+	var err error
+	if info.Resources, err = moduleResources(&state.Values.RootModule); err != nil {
+		return nil, err
+	}
+	info.ResourceCount = int32(len(info.Resources))
+
+	names := make([]string, 0, len(state.Values.Outputs))
+	for name := range state.Values.Outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		output := state.Values.Outputs[name]
+		converted := &TerraformStation.TFStateOutput{Name: name, Sensitive: output.Sensitive}
+		if output.Sensitive {
+			converted.Value = structpb.NewStringValue(sensitiveValue)
+		} else if converted.Value, err = maskedValue(output.Value, nil); err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("invalid output value", name, err.Error())
+		}
+		if converted.Type, err = maskedValue(output.Type, nil); err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("invalid output type", name, err.Error())
+		}
+		info.Outputs = append(info.Outputs, converted)
+	}
+	return info, nil
+}
+
+// moduleResources lists the resources of a module followed by those of its child modules
+func moduleResources(module *stateModuleJSON) ([]*TerraformStation.TFStateResource, error) {
+	var resources []*TerraformStation.TFStateResource
+	for _, r := range module.Resources {
+		resource := &TerraformStation.TFStateResource{
+			Address:       r.Address,
+			ModuleAddress: module.Address,
+			Mode:          r.Mode,
+			Type:          r.Type,
+			Name:          r.Name,
+			ProviderName:  r.ProviderName,
+			SchemaVersion: r.SchemaVersion,
+			DependsOn:     r.DependsOn,
+			Tainted:       r.Tainted,
+			DeposedKey:    r.DeposedKey,
+		}
+
+		var err error
+		if len(r.Index) > 0 {
+			if resource.Index, err = maskedValue(r.Index, nil); err != nil {
+				return nil, TerraformStation.NewExecutionFailedError("invalid resource index", r.Address, err.Error())
+			}
+		}
+		if resource.Values, err = maskedValue(r.Values, r.SensitiveValues); err != nil {
+			return nil, TerraformStation.NewExecutionFailedError("invalid resource values", r.Address, err.Error())
+		}
+		if len(r.SensitiveValues) > 0 {
+			if resource.SensitiveValues, err = maskedValue(r.SensitiveValues, nil); err != nil {
+				return nil, TerraformStation.NewExecutionFailedError("invalid sensitive values", r.Address, err.Error())
+			}
+		}
+
+		resources = append(resources, resource)
+	}
+
+	for i := range module.ChildModules {
+		children, err := moduleResources(&module.ChildModules[i])
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, children...)
+	}
+	return resources, nil
+}
+
+// stateMeta reads the serial and lineage of the state a command would use, which `show -json` does not report.
+// It returns nil if the workspace has no state yet or the state cannot be read.
+func (impl *TerraformStationImpl) stateMeta(ctx context.Context, state localState, stateFile string) *TerraformStation.StateMeta {
+	var data []byte
+	if stateFile != "" {
+		var err error
+		if data, err = os.ReadFile(state.path(stateFile)); err != nil {
+			log.Printf("failed to read state file %s: %v", stateFile, err)
+			return nil
+		}
+	} else {
+		execResult, err := impl.executorFor(state.workingDir, state.workspace).Execute(ctx, state.workingDir, "state", "pull")
+		if err != nil {
+			log.Printf("failed to pull state of %s: %v", state.name(), err)
+			return nil
+		}
+		data = []byte(execResult.Stdout)
+	}
+
+	if strings.TrimSpace(string(data)) == "" {
+		return nil
+	}
+	meta, err := TerraformStation.ParseStateMeta(data)
+	if err != nil {
+		log.Printf("failed to read state of %s: %v", state.name(), err)
+		return nil
+	}
+	return meta
+}
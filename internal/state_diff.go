@@ -0,0 +1,313 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// stateDocument is the part of a version 4 state file describing resources
+type stateDocument struct {
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey            interface{}       `json:"index_key"`
+			Attributes          interface{}       `json:"attributes"`
+			SensitiveAttributes [][]statePathStep `json:"sensitive_attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// statePathStep is one step of an attribute path in a state file
+type statePathStep struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// key returns the attribute name or index key a step selects. Index keys may be
+// wrapped in an object holding the key and its type.
+func (s statePathStep) key() string {
+	value := s.Value
+	if wrapped, ok := value.(map[string]interface{}); ok {
+		value = wrapped["value"]
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// stateInstance holds the flattened attributes of a resource instance, keyed by dotted path,
+// along with the path steps of each attribute and of each sensitive attribute
+type stateInstance struct {
+	attributes map[string]interface{}
+	steps      map[string][]string
+	sensitive  [][]string
+}
+
+func newStateInstance(value interface{}) *stateInstance {
+	instance := &stateInstance{attributes: make(map[string]interface{}), steps: make(map[string][]string)}
+	flattenAttributes(nil, value, func(path []string, leaf interface{}) {
+		key := strings.Join(path, ".")
+		instance.attributes[key] = leaf
+		instance.steps[key] = path
+	})
+	return instance
+}
+
+// parseStateResources reads the resource instances of a state file keyed by address
+func parseStateResources(data []byte) (map[string]*stateInstance, error) {
+	var doc stateDocument
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, TerraformStation.NewInvalidInputError("invalid state file", err.Error())
+	}
+
+	instances := make(map[string]*stateInstance)
+	for _, resource := range doc.Resources {
+		address := resource.Type + "." + resource.Name
+		if resource.Mode == "data" {
+			address = "data." + address
+		}
+		if resource.Module != "" {
+			address = resource.Module + "." + address
+		}
+
+		for _, instance := range resource.Instances {
+			parsed := newStateInstance(instance.Attributes)
+			for _, path := range instance.SensitiveAttributes {
+				parsed.sensitive = append(parsed.sensitive, statePathKeys(path))
+			}
+			instances[address+indexSuffix(instance.IndexKey)] = parsed
+		}
+	}
+	return instances, nil
+}
+
+// indexSuffix formats the index key of a resource instance as it appears in its address
+func indexSuffix(key interface{}) string {
+	switch k := key.(type) {
+	case json.Number:
+		return "[" + k.String() + "]"
+	case string:
+		return fmt.Sprintf("[%q]", k)
+	default:
+		return ""
+	}
+}
+
+// flattenAttributes visits each leaf of an attribute value with its path.
+// Empty objects and lists are leaves, so removing every element is still seen as a change.
+func flattenAttributes(path []string, value interface{}, visit func(path []string, leaf interface{})) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && len(path) > 0 {
+			visit(path, v)
+		}
+		for key, child := range v {
+			flattenAttributes(appendStep(path, key), child, visit)
+		}
+	case []interface{}:
+		if len(v) == 0 && len(path) > 0 {
+			visit(path, v)
+		}
+		for i, child := range v {
+			flattenAttributes(appendStep(path, strconv.Itoa(i)), child, visit)
+		}
+	default:
+		if len(path) > 0 {
+			visit(path, v)
+		}
+	}
+}
+
+// appendStep returns a path extended by one step, leaving the original path untouched
+func appendStep(path []string, key string) []string {
+	return append(path[:len(path):len(path)], key)
+}
+
+// statePathKeys converts an attribute path of a state file to the keys it selects
+func statePathKeys(steps []statePathStep) []string {
+	keys := make([]string, len(steps))
+	for i, step := range steps {
+		keys[i] = step.key()
+	}
+	return keys
+}
+
+// isSensitive reports whether an attribute path is, or is nested under, a sensitive attribute
+func (i *stateInstance) isSensitive(path string) bool {
+	steps := i.steps[path]
+	for _, sensitive := range i.sensitive {
+		if len(sensitive) <= len(steps) && slices.Equal(steps[:len(sensitive)], sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeValue converts an attribute for the API, masking it if it is sensitive and
+// masking secrets the redactor knows within it otherwise
+func (i *stateInstance) attributeValue(redactor *TerraformStation.Redactor, path string) (*structpb.Value, error) {
+	if i.isSensitive(path) {
+		return structpb.NewStringValue(sensitiveValue), nil
+	}
+	value, err := structpb.NewValue(plainValue(i.attributes[path]))
+	if err != nil {
+		return nil, err
+	}
+	redactValue(redactor, value)
+	return value, nil
+}
+
+// plainValue converts numbers decoded as json.Number so the value can be stored in a structpb.Value
+func plainValue(value interface{}) interface{} {
+	if n, ok := value.(json.Number); ok {
+		f, _ := n.Float64()
+		return f
+	}
+	return value
+}
+
+// diffStates compares two state files resource by resource and attribute by attribute
+func diffStates(redactor *TerraformStation.Redactor, fromData, toData []byte) ([]*TerraformStation.TFResourceStateDiff, error) {
+	before, err := parseStateResources(fromData)
+	if err != nil {
+		return nil, err
+	}
+	after, err := parseStateResources(toData)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []*TerraformStation.TFResourceStateDiff
+	for _, address := range unionKeys(before, after) {
+		from, to := before[address], after[address]
+
+		diff := &TerraformStation.TFResourceStateDiff{Address: address, Action: TerraformStation.ActionUpdate}
+		switch {
+		case from == nil:
+			diff.Action = TerraformStation.ActionCreate
+		case to == nil:
+			diff.Action = TerraformStation.ActionDelete
+		}
+
+		if diff.Attributes, err = diffAttributes(redactor, from, to); err != nil {
+			return nil, err
+		}
+
+		if diff.Action != TerraformStation.ActionUpdate || len(diff.Attributes) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs, nil
+}
+
+// diffAttributes lists the attributes that differ between two versions of a resource instance,
+// either of which is nil when the instance does not exist in that version
+func diffAttributes(redactor *TerraformStation.Redactor, from, to *stateInstance) ([]*TerraformStation.TFAttributeDiff, error) {
+	var oldAttributes, newAttributes map[string]interface{}
+	if from != nil {
+		oldAttributes = from.attributes
+	}
+	if to != nil {
+		newAttributes = to.attributes
+	}
+
+	var diffs []*TerraformStation.TFAttributeDiff
+	for _, path := range unionKeys(oldAttributes, newAttributes) {
+		oldValue, inOld := oldAttributes[path]
+		newValue, inNew := newAttributes[path]
+		if inOld && inNew && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		attribute := &TerraformStation.TFAttributeDiff{Path: path}
+		var err error
+		if inOld {
+			if attribute.Before, err = from.attributeValue(redactor, path); err != nil {
+				return nil, err
+			}
+		}
+		if inNew {
+			if attribute.After, err = to.attributeValue(redactor, path); err != nil {
+				return nil, err
+			}
+		}
+		diffs = append(diffs, attribute)
+	}
+	return diffs, nil
+}
+
+// planInstance builds an instance from a before or after value of a plan, with sensitivity given as a mask of the value
+func planInstance(value, mask json.RawMessage) (*stateInstance, error) {
+	decoded, err := decodeNumbers(value)
+	if err != nil {
+		return nil, err
+	}
+	// A resource that no longer exists has a null value
+	if decoded == nil {
+		return nil, nil
+	}
+
+	instance := newStateInstance(decoded)
+
+	sensitive, err := decodeNumbers(mask)
+	if err != nil {
+		return nil, err
+	}
+	flattenAttributes(nil, sensitive, func(path []string, marked interface{}) {
+		if marked == true {
+			instance.sensitive = append(instance.sensitive, path)
+		}
+	})
+	return instance, nil
+}
+
+// decodeNumbers decodes a JSON value keeping numbers exact, returning nil for an empty value
+func decodeNumbers(data json.RawMessage) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// unionKeys returns the keys present in either map, sorted
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	for key := range a {
+		seen[key] = true
+	}
+	for key := range b {
+		seen[key] = true
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStateJSON(t *testing.T) {
+	data, err := os.ReadFile("testdata/state.json")
+	require.NoError(t, err)
+
+	info, err := parseStateJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", info.FormatVersion)
+	assert.Equal(t, "1.8.2", info.TerraformVersion)
+	assert.Equal(t, int32(3), info.ResourceCount)
+	require.Len(t, info.Resources, 3)
+
+	hello := info.Resources[0]
+	assert.Equal(t, "local_file.hello", hello.Address)
+	assert.Empty(t, hello.ModuleAddress)
+	assert.Equal(t, "registry.opentofu.org/hashicorp/local", hello.ProviderName)
+	assert.Equal(t, "Hello, OpenTofu!", hello.Values.GetStructValue().Fields["content"].GetStringValue())
+
+	password := info.Resources[1]
+	assert.True(t, password.Tainted)
+	assert.Equal(t, int32(3), password.SchemaVersion)
+	assert.Equal(t, sensitiveValue, password.Values.GetStructValue().Fields["result"].GetStringValue())
+	assert.True(t, password.SensitiveValues.GetStructValue().Fields["result"].GetBoolValue())
+
+	subnet := info.Resources[2]
+	assert.Equal(t, "module.network.null_resource.subnet[0]", subnet.Address)
+	assert.Equal(t, "module.network", subnet.ModuleAddress)
+	assert.Equal(t, float64(0), subnet.Index.GetNumberValue())
+	assert.Equal(t, []string{"local_file.hello"}, subnet.DependsOn)
+
+	require.Len(t, info.Outputs, 2)
+	assert.Equal(t, "db_password", info.Outputs[0].Name)
+	assert.True(t, info.Outputs[0].Sensitive)
+	assert.Equal(t, sensitiveValue, info.Outputs[0].Value.GetStringValue())
+	assert.Equal(t, "./hello.txt", info.Outputs[1].Value.GetStringValue())
+	assert.Equal(t, "string", info.Outputs[1].Type.GetStringValue())
+}
+
+func TestParseStateJSONEmpty(t *testing.T) {
+	info, err := parseStateJSON([]byte(`{"format_version":"1.0"}`))
+	require.NoError(t, err)
+	assert.Empty(t, info.Resources)
+	assert.Zero(t, info.ResourceCount)
+}
+
+func TestTFStateReadsStateJSON(t *testing.T) {
+	impl := newScriptTestImpl(t, `case "$1" in
+show) cat "`+testdataPath(t, "state.json")+`" ;;
+state) echo '{"version":4,"terraform_version":"1.8.2","serial":7,"lineage":"3f1c2a","resources":[]}' ;;
+esac
+`)
+
+	stateInfo, err := impl.TFState(context.Background(), &TerraformStation.TFCommandInput{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), stateInfo.ResourceCount)
+	assert.Equal(t, "1.8.2", stateInfo.TerraformVersion)
+	assert.Equal(t, int64(7), stateInfo.Serial)
+	assert.Equal(t, "3f1c2a", stateInfo.Lineage)
+	assert.NotEmpty(t, stateInfo.CommandId)
+
+	var state TerraformStation.TerraformState
+	require.NoError(t, impl.db.Where("state_id = ?", stateInfo.StateId).First(&state).Error)
+	assert.Equal(t, 3, state.ResourceCount)
+	assert.Equal(t, int64(7), state.Serial)
+	var snapshot map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(state.StateData), &snapshot))
+	assert.Equal(t, "1.0", snapshot["format_version"])
+}
@@ -0,0 +1,392 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// defaultStateVersionListLimit caps the number of state versions returned when no limit is given
+const defaultStateVersionListLimit = 50
+
+// Files the local backend keeps the state of a workspace in, and the directory holding
+// a subdirectory per workspace other than the default one
+const (
+	localStateFile           = "terraform.tfstate"
+	localStateBackupFile     = "terraform.tfstate.backup"
+	localWorkspacesDirectory = "terraform.tfstate.d"
+)
+
+// stateOperationRollback marks versions written by TFRollbackState
+const stateOperationRollback = "rollback"
+
+// newStateVersion describes state data as a version of the named state
+func newStateVersion(name string, data []byte) (*TerraformStation.TerraformStateVersion, error) {
+	meta, err := TerraformStation.ParseStateMeta(data)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := parseStateResources(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TerraformStation.TerraformStateVersion{
+		Name:             name,
+		Serial:           meta.Serial,
+		Lineage:          meta.Lineage,
+		Checksum:         TerraformStation.StateChecksum(data),
+		TerraformVersion: meta.TerraformVersion,
+		ResourceCount:    len(resources),
+		StateData:        string(data),
+	}, nil
+}
+
+// recordStateVersion stores a version unless its data matches the latest version, returning the version that is now the latest
+func (impl *TerraformStationImpl) recordStateVersion(version *TerraformStation.TerraformStateVersion) (*TerraformStation.TerraformStateVersion, error) {
+	latest, err := impl.store.GetLatestStateVersion(version.Name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, TerraformStation.NewExecutionFailedError("failed to read state", err.Error())
+	}
+	if latest != nil && latest.Checksum == version.Checksum {
+		// OpenTofu writes state again when nothing changed, which would only add a duplicate version
+		return latest, nil
+	}
+
+	if err := impl.store.CreateStateVersion(version); err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to store state", err.Error())
+	}
+	return version, nil
+}
+
+// localState identifies the state the local backend keeps for a workspace of a working directory
+type localState struct {
+	workingDir string
+	workspace  string
+}
+
+// name returns the name versions of the state are stored under: the working directory for the default
+// workspace, and the directory the local backend keeps the state in for other workspaces
+func (s localState) name() string {
+	if s.workspace == TerraformStation.DefaultWorkspace {
+		return s.workingDir
+	}
+	return filepath.Join(s.workingDir, localWorkspacesDirectory, s.workspace)
+}
+
+// path returns the state file the local backend uses, or stateFile if a command overrides it with -state
+func (s localState) path(stateFile string) string {
+	switch {
+	case stateFile == "" && s.workspace == TerraformStation.DefaultWorkspace:
+		return filepath.Join(s.workingDir, localStateFile)
+	case stateFile == "":
+		return filepath.Join(s.workingDir, localWorkspacesDirectory, s.workspace, localStateFile)
+	case filepath.IsAbs(stateFile):
+		return stateFile
+	}
+	return filepath.Join(s.workingDir, stateFile)
+}
+
+// captureStateVersion records the local state of a workspace as a new version if a command changed it.
+// Working directories using a remote backend keep no local state file and are skipped.
+func (impl *TerraformStationImpl) captureStateVersion(state localState, input *TerraformStation.TFCommandInput, commandID string) {
+	data, err := os.ReadFile(state.path(input.StateFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("failed to read state of %s: %v", state.name(), err)
+		}
+		return
+	}
+
+	version, err := newStateVersion(state.name(), data)
+	if err != nil {
+		log.Printf("failed to read state of %s: %v", state.name(), err)
+		return
+	}
+	version.Operation = stateOperation(input)
+	version.CommandID = commandID
+
+	if _, err := impl.recordStateVersion(version); err != nil {
+		log.Printf("failed to record state version of %s: %v", state.name(), err)
+	}
+}
+
+// stateOperation names the command that wrote a state version, including the subcommand of state commands
+func stateOperation(input *TerraformStation.TFCommandInput) string {
+	if input.Command == "state" && len(input.Arguments) > 0 {
+		return input.Command + " " + input.Arguments[0]
+	}
+	return input.Command
+}
+
+// stateVersionName returns the name the versions of a state are stored under: the http backend name,
+// or the name of the local state of a workspace, defaulting to the configured working directory and
+// the default workspace. For local state, the state is returned as well.
+func (impl *TerraformStationImpl) stateVersionName(name, workingDirectory, workspace string) (string, *localState, error) {
+	switch {
+	case name != "" && (workingDirectory != "" || workspace != ""):
+		return "", nil, TerraformStation.NewInvalidInputError("set either name or working_directory and workspace, not both")
+	case name != "":
+		return name, nil, validateStateName(name)
+	case workingDirectory == "":
+		workingDirectory = impl.workingDir
+	}
+
+	if workspace == "" {
+		workspace = TerraformStation.DefaultWorkspace
+	} else if err := TerraformStation.ValidateWorkspaceName(workspace); err != nil {
+		return "", nil, err
+	}
+
+	workingDir, err := TerraformStation.CanonicalWorkingDirectory(workingDirectory)
+	if err != nil {
+		return "", nil, err
+	}
+	state := &localState{workingDir: workingDir, workspace: workspace}
+	return state.name(), state, nil
+}
+
+// TFListStateVersions lists the versions of a state, newest first
+func (impl *TerraformStationImpl) TFListStateVersions(ctx context.Context, input *TerraformStation.TFStateVersionsInput) (*TerraformStation.TFStateVersionList, error) {
+	if input == nil {
+		input = &TerraformStation.TFStateVersionsInput{}
+	}
+	if input.Limit < 0 || input.Offset < 0 {
+		return nil, TerraformStation.NewInvalidInputError("limit and offset cannot be negative")
+	}
+
+	name, _, err := impl.stateVersionName(input.Name, input.WorkingDirectory, input.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(input.Limit)
+	if limit == 0 {
+		limit = defaultStateVersionListLimit
+	}
+
+	versions, err := impl.store.ListStateVersions(name, limit, int(input.Offset))
+	if err != nil {
+		return nil, TerraformStation.NewExecutionFailedError("failed to list state versions", err.Error())
+	}
+
+	list := &TerraformStation.TFStateVersionList{}
+	for i := range versions {
+		list.Versions = append(list.Versions, stateVersionToProto(&versions[i]))
+	}
+	return list, nil
+}
+
+// TFDiffStateVersions compares two versions of a state. Without to_version the latest version is used,
+// and without from_version the version before it.
+func (impl *TerraformStationImpl) TFDiffStateVersions(ctx context.Context, input *TerraformStation.TFStateDiffInput) (*TerraformStation.TFStateDiff, error) {
+	if input == nil {
+		input = &TerraformStation.TFStateDiffInput{}
+	}
+	if input.FromVersion < 0 || input.ToVersion < 0 {
+		return nil, TerraformStation.NewInvalidInputError("versions cannot be negative")
+	}
+
+	name, _, err := impl.stateVersionName(input.Name, input.WorkingDirectory, input.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	var to *TerraformStation.TerraformStateVersion
+	if input.ToVersion == 0 {
+		to, err = impl.loadLatestStateVersion(name)
+	} else {
+		to, err = impl.loadStateVersion(name, int(input.ToVersion))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fromVersion := int(input.FromVersion)
+	if fromVersion == 0 {
+		fromVersion = to.Version - 1
+	}
+	from, err := impl.loadStateVersion(name, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := diffStates(impl.newRedactor(), []byte(from.StateData), []byte(to.StateData))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TerraformStation.TFStateDiff{
+		Name:        name,
+		FromVersion: int32(from.Version),
+		ToVersion:   int32(to.Version),
+		Resources:   resources,
+	}, nil
+}
+
+// TFRollbackState restores an older version of a state by storing its data again as the newest
+// version, with the serial raised above the current one so OpenTofu accepts it
+func (impl *TerraformStationImpl) TFRollbackState(ctx context.Context, input *TerraformStation.TFStateRollbackInput) (*TerraformStation.TFStateVersion, error) {
+	if input == nil || input.Version <= 0 {
+		return nil, TerraformStation.NewInvalidInputError("version must be positive")
+	}
+
+	name, local, err := impl.stateVersionName(input.Name, input.WorkingDirectory, input.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if local != nil {
+		err = impl.refuseUnreviewedStateWrite(local.workingDir, stateOperationRollback)
+	} else {
+		err = impl.refuseBackendStateWrite(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if local != nil {
+		// Hold the working directory lock so no run writes the state while it is replaced
+		pushInput := &TerraformStation.TFCommandInput{Command: "state", Arguments: []string{"push"}}
+		lock, err := impl.lockWorkingDir(ctx, local.workingDir, local.workspace, pushInput, "")
+		if err != nil {
+			return nil, err
+		}
+		defer lock.release()
+
+		// Pick up changes made outside the station, so the new serial is above the one on disk
+		impl.captureStateVersion(*local, &TerraformStation.TFCommandInput{}, "")
+	} else if err := impl.checkStateLock(name, ""); err != nil {
+		return nil, err
+	}
+
+	target, err := impl.loadStateVersion(name, int(input.Version))
+	if err != nil {
+		return nil, err
+	}
+	latest, err := impl.loadLatestStateVersion(name)
+	if err != nil {
+		return nil, err
+	}
+	if target.Version == latest.Version {
+		return nil, TerraformStation.NewInvalidStateError("version is already the latest", name)
+	}
+	if target.Lineage != latest.Lineage {
+		return nil, TerraformStation.NewInvalidStateError("version belongs to a different state lineage", target.Lineage, latest.Lineage)
+	}
+
+	data, err := setStateSerial([]byte(target.StateData), latest.Serial+1)
+	if err != nil {
+		return nil, err
+	}
+	version, err := newStateVersion(name, data)
+	if err != nil {
+		return nil, err
+	}
+	version.Operation = stateOperationRollback
+
+	if local != nil {
+		if err := writeLocalState(local.path(""), data); err != nil {
+			return nil, err
+		}
+	}
+
+	if version, err = impl.recordStateVersion(version); err != nil {
+		return nil, err
+	}
+	log.Printf("rolled back state %s to version %d as version %d", name, target.Version, version.Version)
+	return stateVersionToProto(version), nil
+}
+
+// writeLocalState replaces a local state file, keeping the previous state as the backup next to it like OpenTofu does
+func writeLocalState(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if current, err := os.ReadFile(path); err == nil {
+		if err := os.WriteFile(filepath.Join(dir, localStateBackupFile), current, 0644); err != nil {
+			return TerraformStation.NewExecutionFailedError("failed to back up state", err.Error())
+		}
+	}
+
+	tmp, err := os.CreateTemp(dir, ".terraform.tfstate-*")
+	if err != nil {
+		return TerraformStation.NewExecutionFailedError("failed to write state", err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return TerraformStation.NewExecutionFailedError("failed to write state", err.Error())
+	}
+	if err := tmp.Close(); err != nil {
+		return TerraformStation.NewExecutionFailedError("failed to write state", err.Error())
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return TerraformStation.NewExecutionFailedError("failed to write state", err.Error())
+	}
+	return nil
+}
+
+// setStateSerial returns state data with its serial replaced
+func setStateSerial(data []byte, serial int64) ([]byte, error) {
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, TerraformStation.NewInvalidInputError("invalid state file", err.Error())
+	}
+
+	encoded, err := json.Marshal(serial)
+	if err != nil {
+		return nil, err
+	}
+	state["serial"] = encoded
+
+	return json.MarshalIndent(state, "", "  ")
+}
+
+// loadStateVersion fetches a version of a state, translating a missing record into a not found error
+func (impl *TerraformStationImpl) loadStateVersion(name string, version int) (*TerraformStation.TerraformStateVersion, error) {
+	stateVersion, err := impl.store.GetStateVersion(name, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, TerraformStation.NewNotFoundError("state version not found", fmt.Sprintf("%s version %d", name, version))
+		}
+		return nil, TerraformStation.NewExecutionFailedError("failed to read state version", err.Error())
+	}
+	return stateVersion, nil
+}
+
+// loadLatestStateVersion fetches the newest version of a state, translating a missing record into a not found error
+func (impl *TerraformStationImpl) loadLatestStateVersion(name string) (*TerraformStation.TerraformStateVersion, error) {
+	stateVersion, err := impl.store.GetLatestStateVersion(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, TerraformStation.NewNotFoundError("state has no versions", name)
+		}
+		return nil, TerraformStation.NewExecutionFailedError("failed to read state version", err.Error())
+	}
+	return stateVersion, nil
+}
+
+// stateVersionToProto converts a persisted state version to its API representation
+func stateVersionToProto(version *TerraformStation.TerraformStateVersion) *TerraformStation.TFStateVersion {
+	return &TerraformStation.TFStateVersion{
+		Name:             version.Name,
+		Version:          int32(version.Version),
+		Serial:           version.Serial,
+		Lineage:          version.Lineage,
+		Checksum:         version.Checksum,
+		Operation:        version.Operation,
+		CommandId:        version.CommandID,
+		LockId:           version.LockID,
+		TerraformVersion: version.TerraformVersion,
+		ResourceCount:    int32(version.ResourceCount),
+		CreatedAt:        timestamppb.New(version.CreatedAt),
+	}
+}
@@ -0,0 +1,227 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newStateTestImpl creates an implementation whose apply writes local state with the next serial,
+// storing the serial as the content of local_file.hello
+func newStateTestImpl(t *testing.T) *TerraformStationImpl {
+	return newScriptTestImpl(t, `[ "$1" = apply ] || { echo ok; exit 0; }
+n=$(cat serial 2>/dev/null || echo 0)
+n=$((n+1))
+echo $n > serial
+cat > terraform.tfstate <<EOF
+{"version":4,"terraform_version":"1.8.0","serial":$n,"lineage":"3f1c2a","outputs":{},"resources":[
+{"mode":"managed","type":"local_file","name":"hello","provider":"provider[\"registry.opentofu.org/hashicorp/local\"]",
+"instances":[{"schema_version":0,"attributes":{"content":"v$n","filename":"hello.txt"},"sensitive_attributes":[]}]}]}
+EOF
+echo applied
+`)
+}
+
+func TestStateVersionsRecordedByRuns(t *testing.T) {
+	impl := newStateTestImpl(t)
+	ctx := context.Background()
+
+	first, err := impl.TFCommand(ctx, &TerraformStation.TFCommandInput{Command: "apply"})
+	require.NoError(t, err)
+	_, err = impl.TFCommand(ctx, &TerraformStation.TFCommandInput{Command: "apply"})
+	require.NoError(t, err)
+	// Commands that leave the state unchanged add no version
+	_, err = impl.TFCommand(ctx, &TerraformStation.TFCommandInput{Command: "plan"})
+	require.NoError(t, err)
+
+	list, err := impl.TFListStateVersions(ctx, &TerraformStation.TFStateVersionsInput{})
+	require.NoError(t, err)
+	require.Len(t, list.Versions, 2)
+	assert.Equal(t, int32(2), list.Versions[0].Version)
+	assert.Equal(t, int64(2), list.Versions[0].Serial)
+	assert.Equal(t, int32(1), list.Versions[1].Version)
+	assert.Equal(t, "apply", list.Versions[1].Operation)
+	assert.Equal(t, first.CommandId, list.Versions[1].CommandId)
+	assert.Equal(t, int32(1), list.Versions[1].ResourceCount)
+	assert.Equal(t, "1.8.0", list.Versions[1].TerraformVersion)
+
+	diff, err := impl.TFDiffStateVersions(ctx, &TerraformStation.TFStateDiffInput{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), diff.FromVersion)
+	assert.Equal(t, int32(2), diff.ToVersion)
+	require.Len(t, diff.Resources, 1)
+	assert.Equal(t, "local_file.hello", diff.Resources[0].Address)
+	assert.Equal(t, TerraformStation.ActionUpdate, diff.Resources[0].Action)
+	require.Len(t, diff.Resources[0].Attributes, 1)
+	assert.Equal(t, "content", diff.Resources[0].Attributes[0].Path)
+	assert.Equal(t, "v1", diff.Resources[0].Attributes[0].Before.GetStringValue())
+	assert.Equal(t, "v2", diff.Resources[0].Attributes[0].After.GetStringValue())
+}
+
+func TestRollbackLocalState(t *testing.T) {
+	impl := newStateTestImpl(t)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := impl.TFCommand(ctx, &TerraformStation.TFCommandInput{Command: "apply"})
+		require.NoError(t, err)
+	}
+
+	version, err := impl.TFRollbackState(ctx, &TerraformStation.TFStateRollbackInput{Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), version.Version)
+	assert.Equal(t, int64(3), version.Serial)
+	assert.Equal(t, "rollback", version.Operation)
+
+	// The restored state is on disk with the raised serial, and the replaced one is kept as the backup
+	var state struct {
+		Serial    int64 `json:"serial"`
+		Resources []struct {
+			Instances []struct {
+				Attributes map[string]interface{} `json:"attributes"`
+			} `json:"instances"`
+		} `json:"resources"`
+	}
+	data, err := os.ReadFile(filepath.Join(impl.workingDir, "terraform.tfstate"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &state))
+	assert.Equal(t, int64(3), state.Serial)
+	assert.Equal(t, "v1", state.Resources[0].Instances[0].Attributes["content"])
+
+	backup, err := os.ReadFile(filepath.Join(impl.workingDir, "terraform.tfstate.backup"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(backup, &state))
+	assert.Equal(t, int64(2), state.Serial)
+
+	_, err = impl.TFRollbackState(ctx, &TerraformStation.TFStateRollbackInput{Version: 3})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+	_, err = impl.TFRollbackState(ctx, &TerraformStation.TFStateRollbackInput{Version: 9})
+	assertErrorCode(t, err, TerraformStation.ErrCodeNotFound)
+}
+
+func TestRollbackBackendState(t *testing.T) {
+	impl := newScriptTestImpl(t, "echo ok\n")
+	ctx := context.Background()
+
+	require.NoError(t, impl.PutBackendState(ctx, "production", "", stateFile(1)))
+	require.NoError(t, impl.PutBackendState(ctx, "production", "", stateFile(2)))
+
+	// A client in the middle of an operation holds the lock, so the state cannot be replaced
+	_, err := impl.LockBackendState(ctx, "production", &TerraformStation.StateLockInfo{ID: "a1"})
+	require.NoError(t, err)
+	_, err = impl.TFRollbackState(ctx, &TerraformStation.TFStateRollbackInput{Name: "production", Version: 1})
+	assertErrorCode(t, err, TerraformStation.ErrCodeLocked)
+	_, err = impl.UnlockBackendState(ctx, "production", nil)
+	require.NoError(t, err)
+
+	version, err := impl.TFRollbackState(ctx, &TerraformStation.TFStateRollbackInput{Name: "production", Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), version.Version)
+	assert.Equal(t, int64(3), version.Serial)
+
+	data, err := impl.GetBackendState(ctx, "production")
+	require.NoError(t, err)
+	meta, err := TerraformStation.ParseStateMeta(data)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), meta.Serial)
+
+	// Versions from another lineage cannot be restored
+	require.NoError(t, impl.PutBackendState(ctx, "production", "", []byte(`{"version":4,"serial":1,"lineage":"9e8d7c","resources":[]}`)))
+	_, err = impl.TFRollbackState(ctx, &TerraformStation.TFStateRollbackInput{Name: "production", Version: 3})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidState)
+}
+
+func TestDiffStates(t *testing.T) {
+	from := []byte(`{"version":4,"serial":1,"lineage":"l","resources":[
+{"mode":"managed","type":"aws_db_instance","name":"main","instances":[{"attributes":{"password":"old","tags":{"env":"dev"},"port":5432},
+ "sensitive_attributes":[[{"type":"get_attr","value":"password"}]]}]},
+{"mode":"managed","type":"aws_instance","name":"web","instances":[{"index_key":0,"attributes":{"id":"i-1"}},{"index_key":1,"attributes":{"id":"i-2"}}]},
+{"module":"module.net","mode":"data","type":"aws_vpc","name":"main","instances":[{"attributes":{"id":"vpc-1"}}]}]}`)
+	to := []byte(`{"version":4,"serial":2,"lineage":"l","resources":[
+{"mode":"managed","type":"aws_db_instance","name":"main","instances":[{"attributes":{"password":"new","tags":{},"port":5432},
+ "sensitive_attributes":[[{"type":"get_attr","value":"password"}]]}]},
+{"mode":"managed","type":"aws_instance","name":"web","instances":[{"index_key":0,"attributes":{"id":"i-1"}}]},
+{"mode":"managed","type":"aws_s3_bucket","name":"logs","instances":[{"index_key":"eu","attributes":{"bucket":"logs-eu"}}]},
+{"module":"module.net","mode":"data","type":"aws_vpc","name":"main","instances":[{"attributes":{"id":"vpc-1"}}]}]}`)
+
+	diffs, err := diffStates(TerraformStation.NewRedactor(nil), from, to)
+	require.NoError(t, err)
+	require.Len(t, diffs, 3)
+
+	assert.Equal(t, "aws_db_instance.main", diffs[0].Address)
+	assert.Equal(t, TerraformStation.ActionUpdate, diffs[0].Action)
+	require.Len(t, diffs[0].Attributes, 3)
+	assert.Equal(t, "password", diffs[0].Attributes[0].Path)
+	assert.Equal(t, sensitiveValue, diffs[0].Attributes[0].Before.GetStringValue())
+	assert.Equal(t, sensitiveValue, diffs[0].Attributes[0].After.GetStringValue())
+	assert.Equal(t, "tags", diffs[0].Attributes[1].Path)
+	assert.Nil(t, diffs[0].Attributes[1].Before)
+	assert.Equal(t, "tags.env", diffs[0].Attributes[2].Path)
+	assert.Equal(t, "dev", diffs[0].Attributes[2].Before.GetStringValue())
+	assert.Nil(t, diffs[0].Attributes[2].After)
+
+	assert.Equal(t, "aws_instance.web[1]", diffs[1].Address)
+	assert.Equal(t, TerraformStation.ActionDelete, diffs[1].Action)
+
+	assert.Equal(t, `aws_s3_bucket.logs["eu"]`, diffs[2].Address)
+	assert.Equal(t, TerraformStation.ActionCreate, diffs[2].Action)
+	require.Len(t, diffs[2].Attributes, 1)
+	assert.Equal(t, "logs-eu", diffs[2].Attributes[0].After.GetStringValue())
+}
+
+func TestDiffStatesMasksNestedSensitiveValues(t *testing.T) {
+	from := []byte(`{"version":4,"serial":1,"lineage":"l","resources":[
+{"mode":"managed","type":"aws_instance","name":"web","instances":[{"attributes":{"tags":{"token":"old-token","env":"dev"},"disks":[{"key":"old-key"}],"user_data":"db_pass=hunter22"},
+ "sensitive_attributes":[[{"type":"get_attr","value":"tags"},{"type":"index","value":{"value":"token","type":"string"}}],
+  [{"type":"get_attr","value":"disks"},{"type":"index","value":{"value":0,"type":"number"}},{"type":"get_attr","value":"key"}]]}]}]}`)
+	to := []byte(`{"version":4,"serial":2,"lineage":"l","resources":[
+{"mode":"managed","type":"aws_instance","name":"web","instances":[{"attributes":{"tags":{"token":"new-token","env":"prod"},"disks":[{"key":"new-key"}],"user_data":"db_pass=hunter23"},
+ "sensitive_attributes":[[{"type":"get_attr","value":"tags"},{"type":"index","value":{"value":"token","type":"string"}}],
+  [{"type":"get_attr","value":"disks"},{"type":"index","value":{"value":0,"type":"number"}},{"type":"get_attr","value":"key"}]]}]}]}`)
+
+	diffs, err := diffStates(TerraformStation.NewRedactor(nil, "hunter22", "hunter23"), from, to)
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+
+	values := make(map[string][2]string)
+	for _, attribute := range diffs[0].Attributes {
+		values[attribute.Path] = [2]string{attribute.Before.GetStringValue(), attribute.After.GetStringValue()}
+	}
+	assert.Equal(t, [2]string{sensitiveValue, sensitiveValue}, values["tags.token"])
+	assert.Equal(t, [2]string{sensitiveValue, sensitiveValue}, values["disks.0.key"])
+	assert.Equal(t, [2]string{"dev", "prod"}, values["tags.env"])
+	assert.Equal(t, [2]string{"db_pass=" + TerraformStation.RedactedValue, "db_pass=" + TerraformStation.RedactedValue}, values["user_data"])
+}
+
+func TestCreateStateVersionRetriesTakenNumber(t *testing.T) {
+	impl := newStateTestImpl(t)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, impl.store.CreateStateVersion(&TerraformStation.TerraformStateVersion{Name: "production", Lineage: "l", Checksum: "c"}))
+	}
+
+	// The first attempt loses a race to another writer and reuses a number that is already taken
+	raced := false
+	require.NoError(t, impl.db.Callback().Create().Before("gorm:create").Register("test:race", func(tx *gorm.DB) {
+		if version, ok := tx.Statement.Dest.(*TerraformStation.TerraformStateVersion); ok && !raced {
+			raced = true
+			version.Version = 2
+		}
+	}))
+	t.Cleanup(func() { impl.db.Callback().Create().Remove("test:race") })
+
+	version := &TerraformStation.TerraformStateVersion{Name: "production", Lineage: "l", Checksum: "c"}
+	require.NoError(t, impl.store.CreateStateVersion(version))
+	assert.True(t, raced)
+	assert.Equal(t, 3, version.Version)
+
+	versions, err := impl.store.ListStateVersions("production", -1, 0)
+	require.NoError(t, err)
+	assert.Len(t, versions, 3)
+}
@@ -0,0 +1,212 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// outputBroker wakes subscribers when running commands produce new output
+type outputBroker struct {
+	mu      sync.Mutex
+	running map[string]chan struct{}
+}
+
+func newOutputBroker() *outputBroker {
+	return &outputBroker{running: make(map[string]chan struct{})}
+}
+
+// start marks a command as running
+func (b *outputBroker) start(commandID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.running[commandID] = make(chan struct{})
+}
+
+// notify wakes subscribers waiting for output from a command
+func (b *outputBroker) notify(commandID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if updated, ok := b.running[commandID]; ok {
+		close(updated)
+		b.running[commandID] = make(chan struct{})
+	}
+}
+
+// finish marks a command as finished and wakes its subscribers
+func (b *outputBroker) finish(commandID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if updated, ok := b.running[commandID]; ok {
+		close(updated)
+		delete(b.running, commandID)
+	}
+}
+
+// watch returns a channel that is closed on the next update and whether the command is still running
+func (b *outputBroker) watch(commandID string) (<-chan struct{}, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	updated, ok := b.running[commandID]
+	return updated, ok
+}
+
+// outputRecorder persists command output line by line and forwards it to an optional handler
+type outputRecorder struct {
+	impl       *TerraformStationImpl
+	commandID  string
+	sequence   int64
+	handler    TerraformStation.OutputHandler
+	handlerErr error
+	// progress numbers the resources reached by machine-readable UI messages, which are sequenced separately
+	progress      *progressTracker
+	eventSequence int64
+}
+
+func (impl *TerraformStationImpl) newOutputRecorder(ctx context.Context, commandID string, handler TerraformStation.OutputHandler) *outputRecorder {
+	return &outputRecorder{
+		impl:      impl,
+		commandID: commandID,
+		handler:   handler,
+		progress:  newProgressTracker(plannedChanges(ctx)),
+	}
+}
+
+// record stores a line of output. The executor serializes calls, so no locking is needed here.
+func (r *outputRecorder) record(stream, line string) {
+	r.sequence++
+	chunk := &TerraformStation.TerraformOutputChunk{
+		CommandID: r.commandID,
+		Sequence:  r.sequence,
+		Stream:    stream,
+		Line:      line,
+		CreatedAt: time.Now(),
+	}
+
+	if err := r.impl.store.CreateOutputChunk(chunk); err != nil {
+		log.Printf("failed to persist output of %s: %v", r.commandID, err)
+	}
+	r.impl.broker.notify(r.commandID)
+
+	// Stop delivering to a handler once it fails, but keep the command running
+	if r.handler != nil && r.handlerErr == nil {
+		r.handlerErr = r.handler(chunkToProto(chunk))
+	}
+}
+
+// TFCommandStream executes a generic OpenTofu command, delivering its output to handler as it is produced.
+// The final chunk carries the command result.
+func (impl *TerraformStationImpl) TFCommandStream(ctx context.Context, input *TerraformStation.TFCommandInput, handler TerraformStation.OutputHandler) (*TerraformStation.TFCommandResult, error) {
+	if handler == nil {
+		return nil, TerraformStation.NewInvalidInputError("output handler cannot be nil")
+	}
+	if err := impl.requireReviewedPlan(input); err != nil {
+		return nil, err
+	}
+
+	result, _, err := impl.runCommand(ctx, input, handler)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := handler(&TerraformStation.TFOutputChunk{
+		CommandId: result.CommandId,
+		EmittedAt: timestamppb.Now(),
+		Result:    result,
+	}); err != nil {
+		log.Printf("failed to deliver result of %s: %v", result.CommandId, err)
+	}
+
+	return result, nil
+}
+
+// remotePollInterval is how often subscribers read the database for the output of a command running
+// on another station, whose updates never reach the broker of this one
+const remotePollInterval = time.Second
+
+// follow returns a channel that is closed on the next update of a command, and whether the command has
+// finished. Commands running on this station are followed through the broker. Those running on another
+// station are polled, and have finished once their operation has a final status or that station
+// stopped sending heartbeats for it.
+func (impl *TerraformStationImpl) follow(commandID string) (<-chan struct{}, bool, error) {
+	if updated, running := impl.broker.watch(commandID); running {
+		return updated, false, nil
+	}
+
+	operation, err := impl.loadOperation(commandID)
+	if err != nil {
+		return nil, false, err
+	}
+	// An operation whose station crashed never finishes, so following it ends with the output it left
+	if operationFinished(operation) || impl.operationStale(operation) {
+		return nil, true, nil
+	}
+	poll := make(chan struct{})
+	time.AfterFunc(remotePollInterval, func() { close(poll) })
+	return poll, false, nil
+}
+
+// TFSubscribeOutput replays the persisted output of a command and follows it until the command finishes,
+// whether it runs on this station or another one sharing the database
+func (impl *TerraformStationImpl) TFSubscribeOutput(ctx context.Context, input *TerraformStation.TFSubscribeInput, handler TerraformStation.OutputHandler) error {
+	if input == nil || input.CommandId == "" {
+		return TerraformStation.NewInvalidInputError("command id cannot be empty")
+	}
+	if handler == nil {
+		return TerraformStation.NewInvalidInputError("output handler cannot be nil")
+	}
+
+	if _, err := impl.store.GetOperationByCommandID(input.CommandId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return TerraformStation.NewNotFoundError("operation not found", input.CommandId)
+		}
+		return TerraformStation.NewExecutionFailedError("failed to read operation", err.Error())
+	}
+
+	last := input.AfterSequence
+	for {
+		// Watch before reading so output persisted in between is not missed
+		updated, finished, err := impl.follow(input.CommandId)
+		if err != nil {
+			return err
+		}
+
+		chunks, err := impl.store.ListOutputChunks(input.CommandId, last)
+		if err != nil {
+			return TerraformStation.NewExecutionFailedError("failed to read command output", err.Error())
+		}
+		for i := range chunks {
+			if err := handler(chunkToProto(&chunks[i])); err != nil {
+				return err
+			}
+			last = chunks[i].Sequence
+		}
+
+		if finished {
+			return nil
+		}
+
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// chunkToProto converts a persisted output chunk to its API representation
+func chunkToProto(chunk *TerraformStation.TerraformOutputChunk) *TerraformStation.TFOutputChunk {
+	return &TerraformStation.TFOutputChunk{
+		CommandId: chunk.CommandID,
+		Sequence:  chunk.Sequence,
+		Stream:    chunk.Stream,
+		Line:      chunk.Line,
+		EmittedAt: timestamppb.New(chunk.CreatedAt),
+	}
+}
@@ -0,0 +1,206 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// newStreamTestImpl creates an implementation backed by a script that prints to stdout and stderr
+func newStreamTestImpl(t *testing.T) *TerraformStationImpl {
+	return newScriptTestImpl(t, "echo \"line one\"\necho \"warning\" >&2\necho \"line two\"\n")
+}
+
+// newScriptTestImpl creates an implementation whose opentofu binary is the given shell script
+func newScriptTestImpl(t *testing.T, body string) *TerraformStationImpl {
+	dir := t.TempDir()
+
+	script := filepath.Join(dir, "tofu")
+	err := os.WriteFile(script, []byte("#!/bin/sh\n"+body), 0755)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "station.db")), &gorm.Config{})
+	require.NoError(t, err)
+
+	cfg := TerraformStation.DefaultConfig()
+	cfg.OpenTofuPath = script
+	cfg.WorkingDirectory = dir
+	cfg.PlanDirectory = filepath.Join(dir, "plans")
+
+	impl, err := New(db, cfg)
+	require.NoError(t, err)
+	return impl
+}
+
+func TestTFCommandStream(t *testing.T) {
+	impl := newStreamTestImpl(t)
+
+	var chunks []*TerraformStation.TFOutputChunk
+	result, err := impl.TFCommandStream(context.Background(), &TerraformStation.TFCommandInput{Command: "version"},
+		func(chunk *TerraformStation.TFOutputChunk) error {
+			chunks = append(chunks, chunk)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	// Three output lines followed by the result
+	require.Len(t, chunks, 4)
+	stdout := []string{}
+	for _, chunk := range chunks[:3] {
+		assert.Equal(t, result.CommandId, chunk.CommandId)
+		if chunk.Stream == TerraformStation.StreamStdout {
+			stdout = append(stdout, chunk.Line)
+		} else {
+			assert.Equal(t, "warning", chunk.Line)
+		}
+	}
+	assert.Equal(t, []string{"line one", "line two"}, stdout)
+	assert.Equal(t, result, chunks[3].Result)
+}
+
+func TestTFSubscribeOutputReplay(t *testing.T) {
+	impl := newStreamTestImpl(t)
+
+	result, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "version"})
+	require.NoError(t, err)
+
+	var sequences []int64
+	err = impl.TFSubscribeOutput(context.Background(), &TerraformStation.TFSubscribeInput{CommandId: result.CommandId},
+		func(chunk *TerraformStation.TFOutputChunk) error {
+			sequences = append(sequences, chunk.Sequence)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, sequences)
+
+	// Resuming skips chunks already seen
+	sequences = nil
+	err = impl.TFSubscribeOutput(context.Background(), &TerraformStation.TFSubscribeInput{CommandId: result.CommandId, AfterSequence: 2},
+		func(chunk *TerraformStation.TFOutputChunk) error {
+			sequences = append(sequences, chunk.Sequence)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, sequences)
+}
+
+func TestTFSubscribeOutputRequiresCommandID(t *testing.T) {
+	impl := newStreamTestImpl(t)
+
+	err := impl.TFSubscribeOutput(context.Background(), &TerraformStation.TFSubscribeInput{},
+		func(chunk *TerraformStation.TFOutputChunk) error { return nil })
+	assert.Error(t, err)
+}
+
+func TestTFSubscribeOutputUnknownCommand(t *testing.T) {
+	impl := newStreamTestImpl(t)
+
+	err := impl.TFSubscribeOutput(context.Background(), &TerraformStation.TFSubscribeInput{CommandId: "tofu_missing"},
+		func(chunk *TerraformStation.TFOutputChunk) error { return nil })
+
+	var tfErr *TerraformStation.TerraformError
+	require.ErrorAs(t, err, &tfErr)
+	assert.Equal(t, TerraformStation.ErrCodeNotFound, tfErr.Code)
+}
+
+func TestTFSubscribeOutputFollowsOtherStations(t *testing.T) {
+	impl := newStreamTestImpl(t)
+
+	// An operation running on another station only shows through the database
+	now := time.Now()
+	operation := &TerraformStation.TerraformOperation{CommandID: "tofu_remote", Command: "apply", Status: TerraformStation.OperationStatusRunning,
+		Owner: "other-station", HeartbeatAt: &now, StartedAt: now}
+	require.NoError(t, impl.store.CreateOperation(operation))
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		assert.NoError(t, impl.store.CreateOutputChunk(&TerraformStation.TerraformOutputChunk{CommandID: "tofu_remote", Sequence: 1, Stream: TerraformStation.StreamStdout, Line: "Apply complete!"}))
+		operation.Status = TerraformStation.OperationStatusSucceeded
+		assert.NoError(t, impl.store.UpdateOperation(operation))
+	}()
+
+	var lines []string
+	err := impl.TFSubscribeOutput(context.Background(), &TerraformStation.TFSubscribeInput{CommandId: "tofu_remote"},
+		func(chunk *TerraformStation.TFOutputChunk) error {
+			lines = append(lines, chunk.Line)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Apply complete!"}, lines)
+}
+
+func TestTFSubscribeOutputStopsForCrashedStations(t *testing.T) {
+	impl := newStreamTestImpl(t)
+
+	// The station running the operation stopped sending heartbeats, so the operation never finishes
+	stale := time.Now().Add(-2 * impl.cfg.Locks.TTL)
+	operation := &TerraformStation.TerraformOperation{CommandID: "tofu_crashed", Command: "apply", Status: TerraformStation.OperationStatusRunning,
+		Owner: "crashed-station", HeartbeatAt: &stale, StartedAt: stale}
+	require.NoError(t, impl.store.CreateOperation(operation))
+	require.NoError(t, impl.store.CreateOutputChunk(&TerraformStation.TerraformOutputChunk{CommandID: "tofu_crashed", Sequence: 1, Stream: TerraformStation.StreamStdout, Line: "Creating..."}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var lines []string
+	err := impl.TFSubscribeOutput(ctx, &TerraformStation.TFSubscribeInput{CommandId: "tofu_crashed"},
+		func(chunk *TerraformStation.TFOutputChunk) error {
+			lines = append(lines, chunk.Line)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Creating..."}, lines)
+}
+
+func TestFailStaleOperations(t *testing.T) {
+	impl := newStreamTestImpl(t)
+
+	now := time.Now()
+	stale := now.Add(-2 * impl.cfg.Locks.TTL)
+	for commandID, heartbeat := range map[string]*time.Time{"tofu_crashed": &stale, "tofu_live": &now} {
+		require.NoError(t, impl.store.CreateOperation(&TerraformStation.TerraformOperation{CommandID: commandID, Command: "apply",
+			Status: TerraformStation.OperationStatusRunning, Owner: "station", HeartbeatAt: heartbeat, StartedAt: stale}))
+	}
+
+	count, err := impl.store.FailStaleOperations("interrupted", now.Add(-impl.cfg.Locks.TTL), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	crashed, err := impl.loadOperation("tofu_crashed")
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.OperationStatusFailed, crashed.Status)
+	assert.Equal(t, "interrupted", crashed.ErrorMessage)
+	require.NotNil(t, crashed.CompletedAt)
+
+	live, err := impl.loadOperation("tofu_live")
+	require.NoError(t, err)
+	assert.Equal(t, TerraformStation.OperationStatusRunning, live.Status)
+}
+
+func TestTFSubscribeOutputFromStart(t *testing.T) {
+	impl := newScriptTestImpl(t, "sleep 0.3\necho done\n")
+
+	// Subscribe as soon as the operation is recorded, before it has produced any output
+	go func() {
+		_, err := impl.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "version"})
+		assert.NoError(t, err)
+	}()
+	var operation TerraformStation.TerraformOperation
+	require.Eventually(t, func() bool { return impl.db.First(&operation).Error == nil }, 5*time.Second, time.Millisecond)
+
+	var lines []string
+	err := impl.TFSubscribeOutput(context.Background(), &TerraformStation.TFSubscribeInput{CommandId: operation.CommandID},
+		func(chunk *TerraformStation.TFOutputChunk) error {
+			lines = append(lines, chunk.Line)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"done"}, lines)
+}
@@ -0,0 +1,177 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ForestMars/TerraformStation"
+)
+
+// workspaceEnvironmentFile is where OpenTofu records the workspace selected in a working directory
+const workspaceEnvironmentFile = ".terraform/environment"
+
+// readOnlyWorkspaceSubcommands are the `workspace` subcommands that change nothing
+var readOnlyWorkspaceSubcommands = []string{"list", "show"}
+
+// resolveWorkspace returns the workspace a command acts on: the workspace named by the input,
+// the one named as the argument of a workspace subcommand, or else the one OpenTofu would select
+func resolveWorkspace(workingDir string, input *TerraformStation.TFCommandInput) string {
+	if input.Workspace != "" {
+		return input.Workspace
+	}
+	if input.Command == "workspace" && len(input.Arguments) > 1 && !contains(readOnlyWorkspaceSubcommands, input.Arguments[0]) {
+		for _, arg := range input.Arguments[1:] {
+			if !strings.HasPrefix(arg, "-") {
+				return arg
+			}
+		}
+	}
+	return selectedWorkspace(workingDir)
+}
+
+// selectedWorkspace returns the workspace selected in a working directory, which commands run in
+// when none is given
+func selectedWorkspace(workingDir string) string {
+	data, err := os.ReadFile(filepath.Join(workingDir, workspaceEnvironmentFile))
+	if err != nil {
+		return TerraformStation.DefaultWorkspace
+	}
+	if workspace := strings.TrimSpace(string(data)); workspace != "" {
+		return workspace
+	}
+	return TerraformStation.DefaultWorkspace
+}
+
+// executorFor returns the executor for commands run in a working directory, with the environment
+// configured for it and its workspace. The workspace, or the one selected in the directory when none
+// is given, is passed in TF_WORKSPACE.
+func (impl *TerraformStationImpl) executorFor(workingDir, workspace string) TerraformStation.Executor {
+	if workspace == "" {
+		workspace = selectedWorkspace(workingDir)
+	}
+	return impl.executor.WithEnv(append(impl.cfg.CommandEnv(workingDir, workspace), "TF_WORKSPACE="+workspace)...)
+}
+
+// workspaceCommandExecutor returns the executor for `workspace` subcommands. TF_WORKSPACE would
+// override the workspace they select or delete, so it is left unset.
+func (impl *TerraformStationImpl) workspaceCommandExecutor(workingDir string) TerraformStation.Executor {
+	env := impl.cfg.CommandEnv(workingDir, selectedWorkspace(workingDir))
+	if len(env) == 0 {
+		return impl.executor
+	}
+	return impl.executor.WithEnv(env...)
+}
+
+// TFListWorkspaces lists the workspaces of a working directory and the one currently selected
+func (impl *TerraformStationImpl) TFListWorkspaces(ctx context.Context, input *TerraformStation.TFWorkspaceInput) (*TerraformStation.TFWorkspaceList, error) {
+	result, err := impl.runWorkspaceCommand(ctx, input, "list")
+	if err != nil {
+		return nil, err
+	}
+
+	list := &TerraformStation.TFWorkspaceList{
+		WorkingDirectory: result.workingDir,
+		CommandId:        result.CommandId,
+	}
+	// The selected workspace is marked with an asterisk, as in "* production"
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		name := strings.TrimSpace(line)
+		if strings.HasPrefix(name, "*") {
+			name = strings.TrimSpace(strings.TrimPrefix(name, "*"))
+			list.Current = name
+		}
+		if name != "" {
+			list.Workspaces = append(list.Workspaces, name)
+		}
+	}
+	return list, nil
+}
+
+// TFShowWorkspace returns the workspace currently selected in a working directory
+func (impl *TerraformStationImpl) TFShowWorkspace(ctx context.Context, input *TerraformStation.TFWorkspaceInput) (*TerraformStation.TFWorkspace, error) {
+	result, err := impl.runWorkspaceCommand(ctx, input, "show")
+	if err != nil {
+		return nil, err
+	}
+	return &TerraformStation.TFWorkspace{
+		Name:             strings.TrimSpace(result.Stdout),
+		WorkingDirectory: result.workingDir,
+		CommandId:        result.CommandId,
+	}, nil
+}
+
+// TFNewWorkspace creates a workspace, which OpenTofu also selects
+func (impl *TerraformStationImpl) TFNewWorkspace(ctx context.Context, input *TerraformStation.TFWorkspaceInput) (*TerraformStation.TFWorkspace, error) {
+	return impl.changeWorkspace(ctx, input, "new")
+}
+
+// TFSelectWorkspace selects the workspace commands without a workspace of their own run in
+func (impl *TerraformStationImpl) TFSelectWorkspace(ctx context.Context, input *TerraformStation.TFWorkspaceInput) (*TerraformStation.TFWorkspace, error) {
+	return impl.changeWorkspace(ctx, input, "select")
+}
+
+// TFDeleteWorkspace deletes a workspace. Unless force is set, OpenTofu refuses to delete
+// a workspace whose state still tracks resources.
+func (impl *TerraformStationImpl) TFDeleteWorkspace(ctx context.Context, input *TerraformStation.TFWorkspaceInput) (*TerraformStation.TFWorkspace, error) {
+	return impl.changeWorkspace(ctx, input, "delete")
+}
+
+// changeWorkspace runs a workspace subcommand that acts on the named workspace
+func (impl *TerraformStationImpl) changeWorkspace(ctx context.Context, input *TerraformStation.TFWorkspaceInput, subcommand string) (*TerraformStation.TFWorkspace, error) {
+	if input == nil {
+		input = &TerraformStation.TFWorkspaceInput{}
+	}
+	if err := TerraformStation.ValidateWorkspaceName(input.Name); err != nil {
+		return nil, err
+	}
+
+	result, err := impl.runWorkspaceCommand(ctx, input, subcommand)
+	if err != nil {
+		return nil, err
+	}
+	return &TerraformStation.TFWorkspace{
+		Name:             input.Name,
+		WorkingDirectory: result.workingDir,
+		CommandId:        result.CommandId,
+	}, nil
+}
+
+// workspaceResult is the result of a workspace subcommand along with the working directory it ran in
+type workspaceResult struct {
+	*TerraformStation.TFCommandResult
+	workingDir string
+}
+
+// runWorkspaceCommand runs `workspace <subcommand>`, returning an error if OpenTofu fails
+func (impl *TerraformStationImpl) runWorkspaceCommand(ctx context.Context, input *TerraformStation.TFWorkspaceInput, subcommand string) (*workspaceResult, error) {
+	if input == nil {
+		input = &TerraformStation.TFWorkspaceInput{}
+	}
+
+	args := []string{subcommand}
+	if subcommand == "delete" && input.Force {
+		args = append(args, "-force")
+	}
+	if input.Name != "" && !contains(readOnlyWorkspaceSubcommands, subcommand) {
+		args = append(args, input.Name)
+	}
+
+	commandInput := &TerraformStation.TFCommandInput{
+		Command:          "workspace",
+		WorkingDirectory: input.WorkingDirectory,
+		Arguments:        args,
+	}
+	if err := impl.requireReviewedPlan(commandInput); err != nil {
+		return nil, err
+	}
+	result, operation, err := impl.runCommand(ctx, commandInput, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		return nil, TerraformStation.NewExecutionFailedError("workspace "+subcommand+" failed", result.ErrorMessage, result.Stderr)
+	}
+	return &workspaceResult{TFCommandResult: result, workingDir: operation.WorkingDir}, nil
+}
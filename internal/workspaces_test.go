@@ -0,0 +1,220 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWorkspaceTestImpl creates an implementation whose tofu keeps workspaces the way the local backend does,
+// and whose apply writes the state of the workspace it runs in
+func newWorkspaceTestImpl(t *testing.T) *TerraformStationImpl {
+	return newScriptTestImpl(t, `current=$(cat .terraform/environment 2>/dev/null || echo default)
+ws=${TF_WORKSPACE:-$current}
+case "$1 $2" in
+"workspace list")
+  for w in default $(ls terraform.tfstate.d 2>/dev/null); do
+    if [ "$w" = "$current" ]; then echo "* $w"; else echo "  $w"; fi
+  done ;;
+"workspace show") echo "$ws" ;;
+"workspace new")
+  [ -d "terraform.tfstate.d/$3" ] && { echo "Workspace \"$3\" already exists" >&2; exit 1; }
+  mkdir -p "terraform.tfstate.d/$3" .terraform && echo "$3" > .terraform/environment ;;
+"workspace select")
+  [ "$3" = default ] || [ -d "terraform.tfstate.d/$3" ] || { echo "Workspace \"$3\" doesn't exist." >&2; exit 1; }
+  mkdir -p .terraform && echo "$3" > .terraform/environment ;;
+"workspace delete")
+  [ "$3" = -force ] && shift
+  [ "$3" = "$current" ] && { echo "Workspace \"$3\" is your active workspace." >&2; exit 1; }
+  rm -r "terraform.tfstate.d/$3" ;;
+apply*)
+  state=terraform.tfstate
+  [ "$ws" = default ] || state="terraform.tfstate.d/$ws/terraform.tfstate"
+  echo '{"version":4,"serial":1,"lineage":"'$ws'","resources":[]}' > "$state"
+  echo "applied in $ws" ;;
+*) echo ok ;;
+esac
+`)
+}
+
+func TestWorkspaceLifecycle(t *testing.T) {
+	impl := newWorkspaceTestImpl(t)
+	ctx := context.Background()
+
+	created, err := impl.TFNewWorkspace(ctx, &TerraformStation.TFWorkspaceInput{Name: "staging"})
+	require.NoError(t, err)
+	assert.Equal(t, "staging", created.Name)
+	assert.Equal(t, impl.workingDir, created.WorkingDirectory)
+	assert.NotEmpty(t, created.CommandId)
+
+	_, err = impl.TFNewWorkspace(ctx, &TerraformStation.TFWorkspaceInput{Name: "staging"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeExecutionFailed)
+
+	list, err := impl.TFListWorkspaces(ctx, &TerraformStation.TFWorkspaceInput{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "staging"}, list.Workspaces)
+	assert.Equal(t, "staging", list.Current)
+
+	_, err = impl.TFSelectWorkspace(ctx, &TerraformStation.TFWorkspaceInput{Name: "default"})
+	require.NoError(t, err)
+	shown, err := impl.TFShowWorkspace(ctx, &TerraformStation.TFWorkspaceInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "default", shown.Name)
+
+	_, err = impl.TFSelectWorkspace(ctx, &TerraformStation.TFWorkspaceInput{Name: "production"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeExecutionFailed)
+
+	_, err = impl.TFDeleteWorkspace(ctx, &TerraformStation.TFWorkspaceInput{Name: "staging", Force: true})
+	require.NoError(t, err)
+	list, err = impl.TFListWorkspaces(ctx, &TerraformStation.TFWorkspaceInput{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default"}, list.Workspaces)
+
+	_, err = impl.TFNewWorkspace(ctx, &TerraformStation.TFWorkspaceInput{Name: "../prod"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+}
+
+func TestWorkspaceDeleteInvalidatesPlans(t *testing.T) {
+	impl := newWorkspaceTestImpl(t)
+	ctx := context.Background()
+
+	_, err := impl.TFNewWorkspace(ctx, &TerraformStation.TFWorkspaceInput{Name: "staging"})
+	require.NoError(t, err)
+	_, err = impl.TFSelectWorkspace(ctx, &TerraformStation.TFWorkspaceInput{Name: "default"})
+	require.NoError(t, err)
+
+	plan := &TerraformStation.TerraformPlan{PlanID: "tofu_1", WorkingDir: impl.workingDir, Workspace: "staging", CreatedAt: time.Now()}
+	require.NoError(t, impl.checkPlanFresh(plan))
+
+	_, err = impl.TFDeleteWorkspace(ctx, &TerraformStation.TFWorkspaceInput{Name: "staging", Force: true})
+	require.NoError(t, err)
+	assertErrorCode(t, impl.checkPlanFresh(plan), TerraformStation.ErrCodeInvalidState)
+}
+
+func TestCommandsScopedToWorkspace(t *testing.T) {
+	impl := newWorkspaceTestImpl(t)
+	ctx := context.Background()
+
+	_, err := impl.TFNewWorkspace(ctx, &TerraformStation.TFWorkspaceInput{Name: "staging"})
+	require.NoError(t, err)
+	_, err = impl.TFSelectWorkspace(ctx, &TerraformStation.TFWorkspaceInput{Name: "default"})
+	require.NoError(t, err)
+
+	// The workspace reaches OpenTofu through TF_WORKSPACE, leaving the selected workspace alone
+	result, err := impl.TFCommand(ctx, &TerraformStation.TFCommandInput{Command: "apply", Workspace: "staging"})
+	require.NoError(t, err)
+	assert.Equal(t, "applied in staging\n", result.Stdout)
+
+	var operation TerraformStation.TerraformOperation
+	require.NoError(t, impl.db.Where("command_id = ?", result.CommandId).First(&operation).Error)
+	assert.Equal(t, "staging", operation.Workspace)
+
+	// Commands without a workspace run in, and are recorded against, the selected one
+	result, err = impl.TFCommand(ctx, &TerraformStation.TFCommandInput{Command: "apply"})
+	require.NoError(t, err)
+	assert.Equal(t, "applied in default\n", result.Stdout)
+	var unscoped TerraformStation.TerraformOperation
+	require.NoError(t, impl.db.Where("command_id = ?", result.CommandId).First(&unscoped).Error)
+	assert.Equal(t, TerraformStation.DefaultWorkspace, unscoped.Workspace)
+
+	// Each workspace keeps its own state versions
+	versions, err := impl.TFListStateVersions(ctx, &TerraformStation.TFStateVersionsInput{Workspace: "staging"})
+	require.NoError(t, err)
+	require.Len(t, versions.Versions, 1)
+	assert.Equal(t, "staging", versions.Versions[0].Lineage)
+	assert.Equal(t, filepath.Join(impl.workingDir, "terraform.tfstate.d", "staging"), versions.Versions[0].Name)
+
+	versions, err = impl.TFListStateVersions(ctx, &TerraformStation.TFStateVersionsInput{})
+	require.NoError(t, err)
+	require.Len(t, versions.Versions, 1)
+	assert.Equal(t, "default", versions.Versions[0].Lineage)
+}
+
+func TestResolveWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, "default", resolveWorkspace(dir, &TerraformStation.TFCommandInput{Command: "plan"}))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".terraform"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".terraform", "environment"), []byte("production"), 0644))
+	assert.Equal(t, "production", resolveWorkspace(dir, &TerraformStation.TFCommandInput{Command: "plan"}))
+	assert.Equal(t, "staging", resolveWorkspace(dir, &TerraformStation.TFCommandInput{Command: "plan", Workspace: "staging"}))
+
+	// Workspace subcommands lock the workspace they act on
+	assert.Equal(t, "qa", resolveWorkspace(dir, &TerraformStation.TFCommandInput{Command: "workspace", Arguments: []string{"delete", "-force", "qa"}}))
+	assert.Equal(t, "production", resolveWorkspace(dir, &TerraformStation.TFCommandInput{Command: "workspace", Arguments: []string{"list"}}))
+}
+
+func TestWorkspaceInputValidation(t *testing.T) {
+	err := TerraformStation.ValidateTFCommandInput(&TerraformStation.TFCommandInput{Command: "workspace", Arguments: []string{"select", "qa"}, Workspace: "qa"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+
+	err = TerraformStation.ValidateTFCommandInput(&TerraformStation.TFCommandInput{Command: "plan", Workspace: "prod/eu"})
+	assertErrorCode(t, err, TerraformStation.ErrCodeInvalidInput)
+
+	require.NoError(t, TerraformStation.ValidateTFCommandInput(&TerraformStation.TFCommandInput{Command: "workspace", Arguments: []string{"list"}}))
+}
+
+func TestCommandEnvironment(t *testing.T) {
+	t.Setenv("STATION_SECRET", "hunter2")
+	t.Setenv("TF_WORKSPACE", "staging")
+	impl, fake := newFakeTestImpl(t)
+	fake.On("plan").Stdout("ok\n")
+	fake.On("workspace").Stdout("* default\n")
+	ctx := context.Background()
+
+	impl.cfg.Providers.AWS = TerraformStation.AWSProviderConfig{Region: "us-west-2", AccessKeyID: "AKIA123"}
+	impl.cfg.Environment.Providers = []string{TerraformStation.ProviderAWS}
+	impl.cfg.Environment.TFVars = map[string]string{"region": "us-west-2"}
+	impl.cfg.Environment.Scopes = []TerraformStation.EnvironmentScope{
+		{
+			WorkingDirectory: impl.workingDir,
+			Workspace:        "production",
+			EnvironmentVariables: TerraformStation.EnvironmentVariables{
+				Env:     map[string]string{"AWS_PROFILE": "production"},
+				TFVars:  map[string]string{"region": "eu-west-1"},
+				CLIArgs: "-parallelism=4",
+			},
+		},
+	}
+
+	_, err := impl.TFCommand(ctx, &TerraformStation.TFCommandInput{Command: "plan"})
+	require.NoError(t, err)
+	_, err = impl.TFCommand(ctx, &TerraformStation.TFCommandInput{Command: "plan", Workspace: "production"})
+	require.NoError(t, err)
+
+	calls := fake.CallsTo("plan")
+	require.Len(t, calls, 2)
+
+	// The station's own environment is stripped apart from the allowlist
+	for _, call := range calls {
+		assert.Empty(t, call.Getenv("STATION_SECRET"))
+		assert.Equal(t, os.Getenv("PATH"), call.Getenv("PATH"))
+		assert.Equal(t, "AKIA123", call.Getenv("AWS_ACCESS_KEY_ID"))
+	}
+
+	assert.Equal(t, "us-west-2", calls[0].Getenv("TF_VAR_region"))
+	assert.Empty(t, calls[0].Getenv("AWS_PROFILE"))
+	assert.Empty(t, calls[0].Getenv("TF_CLI_ARGS"))
+
+	// Only runs in the production workspace get its variables
+	assert.Equal(t, "eu-west-1", calls[1].Getenv("TF_VAR_region"))
+	assert.Equal(t, "production", calls[1].Getenv("AWS_PROFILE"))
+	assert.Equal(t, "-parallelism=4", calls[1].Getenv("TF_CLI_ARGS"))
+	assert.Equal(t, "production", calls[1].Getenv("TF_WORKSPACE"))
+
+	// The station's TF_WORKSPACE never reaches commands, which run in the selected workspace
+	assert.Equal(t, "default", calls[0].Getenv("TF_WORKSPACE"))
+
+	// Workspace subcommands run without TF_WORKSPACE, so it cannot change what they act on
+	_, err = impl.TFListWorkspaces(ctx, &TerraformStation.TFWorkspaceInput{})
+	require.NoError(t, err)
+	workspaceCalls := fake.CallsTo("workspace")
+	require.Len(t, workspaceCalls, 1)
+	assert.Empty(t, workspaceCalls[0].Getenv("TF_WORKSPACE"))
+}
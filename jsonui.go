@@ -0,0 +1,193 @@
+package TerraformStation
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Message types of the OpenTofu machine-readable UI
+const (
+	UIEventVersion       = "version"
+	UIEventDiagnostic    = "diagnostic"
+	UIEventPlannedChange = "planned_change"
+	UIEventChangeSummary = "change_summary"
+	UIEventApplyStart    = "apply_start"
+	UIEventApplyProgress = "apply_progress"
+	UIEventApplyComplete = "apply_complete"
+	UIEventApplyErrored  = "apply_errored"
+)
+
+// UIEvent is a single message of the machine-readable UI emitted with -json
+type UIEvent struct {
+	Level      string           `json:"@level"`
+	Message    string           `json:"@message"`
+	Module     string           `json:"@module"`
+	Timestamp  time.Time        `json:"@timestamp"`
+	Type       string           `json:"type"`
+	Hook       *UIHook          `json:"hook,omitempty"`
+	Change     *UIPlannedChange `json:"change,omitempty"`
+	Changes    *UIChangeSummary `json:"changes,omitempty"`
+	Diagnostic *UIDiagnostic    `json:"diagnostic,omitempty"`
+}
+
+// UIHook describes progress of an operation on a single resource
+type UIHook struct {
+	Resource       UIResource `json:"resource"`
+	Action         string     `json:"action"`
+	IDKey          string     `json:"id_key,omitempty"`
+	IDValue        string     `json:"id_value,omitempty"`
+	ElapsedSeconds float64    `json:"elapsed_seconds"`
+}
+
+// UIPlannedChange describes a change to a single resource found while planning
+type UIPlannedChange struct {
+	Resource UIResource `json:"resource"`
+	Action   string     `json:"action"`
+	Reason   string     `json:"reason,omitempty"`
+}
+
+// UIResource identifies the resource a hook refers to
+type UIResource struct {
+	Addr            string `json:"addr"`
+	Module          string `json:"module"`
+	Resource        string `json:"resource"`
+	ResourceType    string `json:"resource_type"`
+	ResourceName    string `json:"resource_name"`
+	ImpliedProvider string `json:"implied_provider"`
+}
+
+// UIChangeSummary holds the resource counts reported at the end of a plan or apply
+type UIChangeSummary struct {
+	Add       int    `json:"add"`
+	Change    int    `json:"change"`
+	Import    int    `json:"import"`
+	Remove    int    `json:"remove"`
+	Operation string `json:"operation"`
+}
+
+// UIDiagnostic is an error or warning reported by OpenTofu
+type UIDiagnostic struct {
+	Severity string               `json:"severity"`
+	Summary  string               `json:"summary"`
+	Detail   string               `json:"detail"`
+	Address  string               `json:"address,omitempty"`
+	Range    *UIDiagnosticRange   `json:"range,omitempty"`
+	Snippet  *UIDiagnosticSnippet `json:"snippet,omitempty"`
+}
+
+// UIDiagnosticRange is the part of a configuration file a diagnostic refers to
+type UIDiagnosticRange struct {
+	Filename string      `json:"filename"`
+	Start    UISourcePos `json:"start"`
+	End      UISourcePos `json:"end"`
+}
+
+// UISourcePos is a position in a configuration file, with lines and columns counted from 1
+type UISourcePos struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Byte   int `json:"byte"`
+}
+
+// UIDiagnosticSnippet is the source code a diagnostic refers to
+type UIDiagnosticSnippet struct {
+	Context              *string             `json:"context"`
+	Code                 string              `json:"code"`
+	StartLine            int                 `json:"start_line"`
+	HighlightStartOffset int                 `json:"highlight_start_offset"`
+	HighlightEndOffset   int                 `json:"highlight_end_offset"`
+	Values               []UIExpressionValue `json:"values"`
+}
+
+// UIExpressionValue describes the value of an expression involved in a diagnostic
+type UIExpressionValue struct {
+	Traversal string `json:"traversal"`
+	Statement string `json:"statement"`
+}
+
+// ValidateOutput is the document printed by `validate -json`
+type ValidateOutput struct {
+	FormatVersion string          `json:"format_version"`
+	Valid         bool            `json:"valid"`
+	ErrorCount    int             `json:"error_count"`
+	WarningCount  int             `json:"warning_count"`
+	Diagnostics   []*UIDiagnostic `json:"diagnostics"`
+}
+
+// ParseValidateOutput decodes the output of `validate -json`
+func ParseValidateOutput(output string) (*ValidateOutput, error) {
+	var validate ValidateOutput
+	if err := json.Unmarshal([]byte(output), &validate); err != nil {
+		return nil, err
+	}
+	return &validate, nil
+}
+
+// ParseUIEvent decodes a single line of machine-readable UI output
+func ParseUIEvent(line string) (*UIEvent, error) {
+	var event UIEvent
+	if err := json.Unmarshal([]byte(line), &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// ParseUIEvents decodes every machine-readable UI message in output, skipping lines that are not JSON
+func ParseUIEvents(output string) []*UIEvent {
+	var events []*UIEvent
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		if event, err := ParseUIEvent(line); err == nil && event.Type != "" {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// UIEventHandler receives a machine-readable UI message as soon as it is produced, along with the line it was read from
+type UIEventHandler func(event *UIEvent, line string)
+
+// ParseUIStream returns a line handler for ExecuteStream that decodes the machine-readable UI
+// messages on stdout, passing each to onEvent, and forwards every line to next if it is set
+func ParseUIStream(next LineHandler, onEvent UIEventHandler) LineHandler {
+	return func(stream, line string) {
+		if next != nil {
+			next(stream, line)
+		}
+		if stream != StreamStdout {
+			return
+		}
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "{") {
+			return
+		}
+		if event, err := ParseUIEvent(trimmed); err == nil && event.Type != "" {
+			onEvent(event, trimmed)
+		}
+	}
+}
+
+// RenderUIOutput replaces each machine-readable UI message in output with its
+// human-readable message, leaving any other lines untouched
+func RenderUIOutput(output string) string {
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "{") {
+			if event, err := ParseUIEvent(trimmed); err == nil && event.Type != "" {
+				b.WriteString(event.Message)
+				b.WriteString("\n")
+				continue
+			}
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package TerraformStation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// logLevels maps the log_level setting to the level of the process logger
+var logLevels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+// ConfigureLogging routes the process log output through a logger that drops
+// messages below the configured log_level. Messages written with the log
+// package report failures and state changes the operator should see, so they
+// are logged as warnings and only hidden when log_level is error.
+func ConfigureLogging(cfg *Config) {
+	level := logLevels[cfg.LogLevel]
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+	slog.SetLogLoggerLevel(slog.LevelWarn)
+}
+
+// gormLogLevel maps the log_level setting to the database logger. SQL
+// statements are only logged at debug, slow statements from info up. Bound
+// values are never logged, as they include state, outputs and variables.
+func gormLogLevel(level string) logger.LogLevel {
+	switch level {
+	case "debug":
+		return logger.Info
+	case "error":
+		return logger.Error
+	default:
+		return logger.Warn
+	}
+}
+
+// newGormLogger creates the database logger for the configured log_level
+func newGormLogger(cfg *Config) logger.Interface {
+	return logger.New(gormWriter{level: logLevels[cfg.LogLevel]}, logger.Config{
+		SlowThreshold:             200 * time.Millisecond,
+		LogLevel:                  gormLogLevel(cfg.LogLevel),
+		IgnoreRecordNotFoundError: true,
+		ParameterizedQueries:      true,
+	})
+}
+
+// gormWriter hands database log lines to the process logger. GORM already
+// filtered them by level, so they are written at the configured level.
+type gormWriter struct {
+	level slog.Level
+}
+
+func (w gormWriter) Printf(format string, args ...interface{}) {
+	slog.Log(context.Background(), w.level, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,44 @@
+package TerraformStation
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGormLogLevel(t *testing.T) {
+	assert.Equal(t, logger.Info, gormLogLevel("debug"))
+	assert.Equal(t, logger.Warn, gormLogLevel("info"))
+	assert.Equal(t, logger.Warn, gormLogLevel("warn"))
+	assert.Equal(t, logger.Error, gormLogLevel("error"))
+}
+
+func TestLogLevelsCoverValidLevels(t *testing.T) {
+	for _, level := range []string{"debug", "info", "warn", "error"} {
+		_, ok := logLevels[level]
+		assert.True(t, ok, level)
+	}
+}
+
+func TestGormLoggerOmitsValues(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: newGormLogger(&Config{LogLevel: "debug"})})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&TerraformSecret{}))
+
+	var secrets []TerraformSecret
+	require.NoError(t, db.Where("name = ?", "hunter2-token").Find(&secrets).Error)
+
+	assert.Contains(t, buf.String(), "terraform_secrets")
+	assert.NotContains(t, buf.String(), "hunter2-token")
+}
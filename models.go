@@ -6,18 +6,113 @@ import (
 	"gorm.io/gorm"
 )
 
+// Operation lifecycle statuses
+const (
+	OperationStatusPending   = "pending"
+	OperationStatusRunning   = "running"
+	OperationStatusSucceeded = "succeeded"
+	OperationStatusFailed    = "failed"
+	OperationStatusCancelled = "cancelled"
+)
+
+// Plan statuses
+const (
+	PlanStatusCompleted        = "completed"
+	PlanStatusFailed           = "failed"
+	PlanStatusAwaitingApproval = "awaiting_approval"
+	PlanStatusApproved         = "approved"
+	PlanStatusRejected         = "rejected"
+	PlanStatusExpired          = "expired"
+	PlanStatusApplying         = "applying"
+	PlanStatusApplied          = "applied"
+)
+
+// Plan review decisions
+const (
+	ApprovalDecisionApproved = "approved"
+	ApprovalDecisionRejected = "rejected"
+)
+
+// Job statuses
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+// Job types, each running the service method of the same name
+const (
+	JobTypeCommand  = "command"
+	JobTypePlan     = "plan"
+	JobTypeApply    = "apply"
+	JobTypeInit     = "init"
+	JobTypeValidate = "validate"
+	JobTypeState    = "state"
+)
+
+// Drift report statuses
+const (
+	DriftStatusClean   = "clean"
+	DriftStatusDrifted = "drifted"
+	DriftStatusFailed  = "failed"
+)
+
+// Drift events, emitted when a working directory moves between clean and drifted
+const (
+	DriftEventDetected = "drift_detected"
+	DriftEventResolved = "drift_resolved"
+)
+
+// Drift check triggers
+const (
+	DriftTriggerSchedule = "schedule"
+	DriftTriggerManual   = "manual"
+)
+
+// DefaultWorkspace is the workspace commands run in unless another is selected
+const DefaultWorkspace = "default"
+
+// Resource change actions reported in plans
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionReplace = "replace"
+	ActionRead    = "read"
+	ActionNoOp    = "no-op"
+)
+
 // TerraformOperation represents a Terraform operation in the database
 type TerraformOperation struct {
 	ID            uint           `gorm:"primaryKey" json:"id"`
 	CommandID     string         `gorm:"uniqueIndex;not null" json:"command_id"`
 	Command       string         `gorm:"not null" json:"command"`
 	WorkingDir    string         `gorm:"not null" json:"working_dir"`
+	Workspace     string         `gorm:"not null;default:'default'" json:"workspace"`
 	Arguments     string         `gorm:"type:text" json:"arguments"`
 	Variables     string         `gorm:"type:text" json:"variables"`
+	// SecretVariables and SecretEnv map input and environment variables to the names of the secrets
+	// they were set from, as JSON objects. Secret values are never stored with the operation.
+	SecretVariables string        `gorm:"type:text" json:"secret_variables"`
+	SecretEnv     string         `gorm:"type:text" json:"secret_env"`
+	// WritesState is set for commands that write state, which makes plans made before them stale
+	WritesState   bool           `gorm:"default:false;index" json:"writes_state"`
 	Status        string         `gorm:"not null;default:'pending'" json:"status"`
+	// Owner is the station running the operation, which refreshes HeartbeatAt until the operation finishes
+	Owner         string         `gorm:"index" json:"owner"`
+	HeartbeatAt   *time.Time     `json:"heartbeat_at"`
 	ExitCode      int            `gorm:"default:0" json:"exit_code"`
 	Output        string         `gorm:"type:text" json:"output"`
+	Stderr        string         `gorm:"type:text" json:"stderr"`
+	Signal        string         `json:"signal"`
+	TimedOut      bool           `gorm:"default:false" json:"timed_out"`
 	ErrorMessage  string         `gorm:"type:text" json:"error_message"`
+	// Diagnostics holds the errors and warnings of commands run with -json as a JSON array
+	Diagnostics   string         `gorm:"type:text" json:"diagnostics"`
+	ErrorCount    int            `gorm:"default:0" json:"error_count"`
+	WarningCount  int            `gorm:"default:0" json:"warning_count"`
 	StartedAt     time.Time      `gorm:"not null" json:"started_at"`
 	CompletedAt   *time.Time     `json:"completed_at"`
 	Duration      time.Duration  `json:"duration"`
@@ -35,12 +130,31 @@ type TerraformPlan struct {
 	HasChanges    bool           `gorm:"not null" json:"has_changes"`
 	ResourceCount int            `gorm:"default:0" json:"resource_count"`
 	PlanOutput    string         `gorm:"type:text" json:"plan_output"`
+	ResourceChanges string       `gorm:"type:text" json:"resource_changes"`
+	WorkingDir    string         `json:"working_dir"`
+	Workspace     string         `gorm:"not null;default:'default'" json:"workspace"`
+	PlanFile      string         `json:"plan_file"`
+	Checksum      string         `json:"checksum"`
 	Status        string         `gorm:"not null;default:'pending'" json:"status"`
+	RequiredApprovals int        `gorm:"default:0" json:"required_approvals"`
+	ApprovalExpiresAt *time.Time `json:"approval_expires_at"`
+	Approvals     []TerraformPlanApproval `gorm:"foreignKey:PlanID;references:PlanID" json:"approvals"`
+	AppliedAt     *time.Time     `json:"applied_at"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// TerraformPlanApproval records a reviewer's decision on a plan
+type TerraformPlanApproval struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	PlanID    string    `gorm:"uniqueIndex:idx_plan_approver,priority:1;not null" json:"plan_id"`
+	Approver  string    `gorm:"uniqueIndex:idx_plan_approver,priority:2;not null" json:"approver"`
+	Decision  string    `gorm:"not null" json:"decision"`
+	Comment   string    `gorm:"type:text" json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // TerraformApply represents a Terraform apply operation
 type TerraformApply struct {
 	ID                uint           `gorm:"primaryKey" json:"id"`
@@ -52,6 +166,8 @@ type TerraformApply struct {
 	ResourcesAdded    int            `gorm:"default:0" json:"resources_added"`
 	ResourcesChanged  int            `gorm:"default:0" json:"resources_changed"`
 	ResourcesDestroyed int           `gorm:"default:0" json:"resources_destroyed"`
+	ResourcesFailed   int            `gorm:"default:0" json:"resources_failed"`
+	ResourceApplies   string         `gorm:"type:text" json:"resource_applies"`
 	ApplyOutput       string         `gorm:"type:text" json:"apply_output"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
@@ -66,6 +182,8 @@ type TerraformState struct {
 	WorkingDir        string         `gorm:"not null" json:"working_dir"`
 	ResourceCount     int            `gorm:"default:0" json:"resource_count"`
 	TerraformVersion  string         `json:"terraform_version"`
+	Serial            int64          `gorm:"default:0" json:"serial"`
+	Lineage           string         `json:"lineage"`
 	LastUpdated       time.Time      `gorm:"not null" json:"last_updated"`
 	StateData         string         `gorm:"type:text" json:"state_data"`
 	CreatedAt         time.Time      `json:"created_at"`
@@ -73,6 +191,118 @@ type TerraformState struct {
 	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// TerraformOutputChunk represents a single line of output produced by an operation
+type TerraformOutputChunk struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CommandID string    `gorm:"index:idx_output_command_sequence,priority:1;not null" json:"command_id"`
+	Sequence  int64     `gorm:"index:idx_output_command_sequence,priority:2;not null" json:"sequence"`
+	Stream    string    `gorm:"not null" json:"stream"`
+	Line      string    `gorm:"type:text" json:"line"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TerraformOperationEvent is a machine-readable UI message emitted by an operation run with -json,
+// along with the progress through the planned changes it represents
+type TerraformOperationEvent struct {
+	ID             uint    `gorm:"primaryKey" json:"id"`
+	CommandID      string  `gorm:"index:idx_event_command_sequence,priority:1;not null" json:"command_id"`
+	Sequence       int64   `gorm:"index:idx_event_command_sequence,priority:2;not null" json:"sequence"`
+	Type           string  `gorm:"not null" json:"type"`
+	Level          string  `json:"level"`
+	Message        string  `gorm:"type:text" json:"message"`
+	Address        string  `json:"address"`
+	Action         string  `json:"action"`
+	ElapsedSeconds float64 `json:"elapsed_seconds"`
+	ResourceIndex  int     `json:"resource_index"`
+	ResourceTotal  int     `json:"resource_total"`
+	// Data holds the message as OpenTofu emitted it
+	Data      string    `gorm:"type:text" json:"data"`
+	EmittedAt time.Time `json:"emitted_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TerraformJob is a queued or finished asynchronous job. The station running a job refreshes
+// HeartbeatAt, so jobs left running by a crashed station can be told apart from live ones.
+type TerraformJob struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	JobID        string         `gorm:"uniqueIndex;not null" json:"job_id"`
+	Type         string         `gorm:"not null" json:"type"`
+	Status       string         `gorm:"index;not null;default:'queued'" json:"status"`
+	Input        string         `gorm:"type:text" json:"input"`
+	Result       string         `gorm:"type:text" json:"result"`
+	CommandID    string         `gorm:"index" json:"command_id"`
+	ErrorMessage string         `gorm:"type:text" json:"error_message"`
+	Owner        string         `json:"owner"`
+	HeartbeatAt  *time.Time     `gorm:"index" json:"heartbeat_at"`
+	StartedAt    *time.Time     `json:"started_at"`
+	CompletedAt  *time.Time     `json:"completed_at"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TerraformLock records which operation holds the lock on a working directory and workspace.
+// Holders refresh HeartbeatAt while they run, so locks left by crashed processes can be taken over.
+type TerraformLock struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	LockID      string    `gorm:"uniqueIndex;not null" json:"lock_id"`
+	WorkingDir  string    `gorm:"uniqueIndex:idx_lock_target,priority:1;not null" json:"working_dir"`
+	Workspace   string    `gorm:"uniqueIndex:idx_lock_target,priority:2;not null" json:"workspace"`
+	Owner       string    `gorm:"not null" json:"owner"`
+	CommandID   string    `json:"command_id"`
+	Command     string    `json:"command"`
+	AcquiredAt  time.Time `gorm:"not null" json:"acquired_at"`
+	HeartbeatAt time.Time `gorm:"not null" json:"heartbeat_at"`
+}
+
+// TerraformStateVersion is one version of a state, either stored through the http backend under
+// its name or read from the local state of a working directory, named by the working directory.
+// Versions are never modified; each change of the state data adds the next version.
+type TerraformStateVersion struct {
+	ID               uint           `gorm:"primaryKey" json:"id"`
+	Name             string         `gorm:"uniqueIndex:idx_state_version,priority:1;not null" json:"name"`
+	Version          int            `gorm:"uniqueIndex:idx_state_version,priority:2;not null" json:"version"`
+	Serial           int64          `gorm:"not null" json:"serial"`
+	Lineage          string         `gorm:"not null" json:"lineage"`
+	Checksum         string         `gorm:"not null" json:"checksum"`
+	Operation        string         `json:"operation"`
+	CommandID        string         `gorm:"index" json:"command_id"`
+	LockID           string         `json:"lock_id"`
+	TerraformVersion string         `json:"terraform_version"`
+	ResourceCount    int            `gorm:"default:0" json:"resource_count"`
+	StateData        string         `gorm:"type:text" json:"state_data"`
+	CreatedAt        time.Time      `json:"created_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TerraformStateLock records the lock an OpenTofu client holds on a state stored through the http backend
+type TerraformStateLock struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"uniqueIndex;not null" json:"name"`
+	LockID    string    `gorm:"not null" json:"lock_id"`
+	Operation string    `json:"operation"`
+	Who       string    `json:"who"`
+	Info      string    `gorm:"type:text" json:"info"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TerraformDriftReport records the outcome of a refresh-only plan checking a working directory for drift
+type TerraformDriftReport struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	ReportID      string     `gorm:"uniqueIndex;not null" json:"report_id"`
+	WorkingDir    string     `gorm:"index;not null" json:"working_dir"`
+	Workspace     string     `gorm:"not null;default:'default'" json:"workspace"`
+	Status        string     `gorm:"index;not null" json:"status"`
+	Trigger       string     `gorm:"not null" json:"trigger"`
+	Event         string     `json:"event"`
+	CommandID     string     `gorm:"index" json:"command_id"`
+	ResourceCount int        `gorm:"default:0" json:"resource_count"`
+	ResourceDrift string     `gorm:"type:text" json:"resource_drift"`
+	ErrorMessage  string     `gorm:"type:text" json:"error_message"`
+	CompletedAt   *time.Time `json:"completed_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
 // TableName specifies the table name for TerraformOperation
 func (TerraformOperation) TableName() string {
 	return "terraform_operations"
@@ -83,6 +313,23 @@ func (TerraformPlan) TableName() string {
 	return "terraform_plans"
 }
 
+// TableName specifies the table name for TerraformPlanApproval
+func (TerraformPlanApproval) TableName() string {
+	return "terraform_plan_approvals"
+}
+
+// TerraformSecret is a sensitive value encrypted with the station's master key. Runs reference it
+// by name, and its value is only decrypted to pass it to the commands that use it.
+type TerraformSecret struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `gorm:"uniqueIndex;not null" json:"name"`
+	// Ciphertext is the nonce followed by the AES-256-GCM sealed value, base64 encoded
+	Ciphertext string    `gorm:"type:text;not null" json:"-"`
+	KeyID      string    `gorm:"not null" json:"key_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
 // TableName specifies the table name for TerraformApply
 func (TerraformApply) TableName() string {
 	return "terraform_applies"
@@ -92,3 +339,38 @@ func (TerraformApply) TableName() string {
 func (TerraformState) TableName() string {
 	return "terraform_states"
 }
+
+// TableName specifies the table name for TerraformOutputChunk
+func (TerraformOutputChunk) TableName() string {
+	return "terraform_output_chunks"
+}
+
+// TableName specifies the table name for TerraformJob
+func (TerraformJob) TableName() string {
+	return "terraform_jobs"
+}
+
+// TableName specifies the table name for TerraformLock
+func (TerraformLock) TableName() string {
+	return "terraform_locks"
+}
+
+// TableName specifies the table name for TerraformStateVersion
+func (TerraformStateVersion) TableName() string {
+	return "terraform_state_versions"
+}
+
+// TableName specifies the table name for TerraformStateLock
+func (TerraformStateLock) TableName() string {
+	return "terraform_state_locks"
+}
+
+// TableName specifies the table name for TerraformDriftReport
+func (TerraformDriftReport) TableName() string {
+	return "terraform_drift_reports"
+}
+
+// TableName specifies the table name for TerraformSecret
+func (TerraformSecret) TableName() string {
+	return "terraform_secrets"
+}
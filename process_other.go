@@ -0,0 +1,21 @@
+//go:build !unix
+
+package TerraformStation
+
+import (
+	"os"
+	"os/exec"
+)
+
+// startInProcessGroup leaves the command in the station's process group, since process groups are unix only
+func startInProcessGroup(cmd *exec.Cmd) {}
+
+// interruptProcessGroup sends an interrupt to a started command
+func interruptProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Signal(os.Interrupt)
+}
+
+// killProcessGroup kills a started command
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
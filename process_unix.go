@@ -0,0 +1,24 @@
+//go:build unix
+
+package TerraformStation
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// startInProcessGroup makes the command the leader of a new process group, so signals reach
+// the provider plugins it starts as well
+func startInProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// interruptProcessGroup sends SIGINT to the process group of a started command
+func interruptProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGINT)
+}
+
+// killProcessGroup sends SIGKILL to the process group of a started command
+func killProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
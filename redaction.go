@@ -0,0 +1,222 @@
+package TerraformStation
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RedactedValue replaces the values masked in command output and the records of operations
+const RedactedValue = "(sensitive value)"
+
+// MinRedactedValueLength is the length below which values are not masked wherever they appear,
+// since masking every occurrence of a short string would garble unrelated output
+const MinRedactedValueLength = 4
+
+// DefaultRedactionPatterns match common credentials in command output: AWS access key IDs, AWS
+// secret access keys following their name, bearer tokens and passwords in URLs. Where a pattern
+// has capturing groups only the text they capture is masked, so the surrounding labels stay readable.
+var DefaultRedactionPatterns = []string{
+	`\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`,
+	`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`,
+	`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]{8,}=*)`,
+	`://[^/\s:@]+:([^/\s@]+)@`,
+}
+
+// Private key blocks are always masked, whether they arrive whole or line by line
+var (
+	privateKeyBlock = regexp.MustCompile(`-----BEGIN [A-Z0-9 ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z0-9 ]*PRIVATE KEY-----`)
+	privateKeyBegin = regexp.MustCompile(`-----BEGIN [A-Z0-9 ]*PRIVATE KEY-----.*`)
+	privateKeyEnd   = regexp.MustCompile(`^.*?-----END [A-Z0-9 ]*PRIVATE KEY-----`)
+)
+
+// RedactionConfig controls how command output is masked before it is streamed, logged, stored or returned
+type RedactionConfig struct {
+	// Patterns are regular expressions matching further values to mask
+	Patterns []string `json:"patterns" yaml:"patterns"`
+	// DisableDefaultPatterns drops DefaultRedactionPatterns, leaving Patterns and known secret values
+	DisableDefaultPatterns bool `json:"disable_default_patterns" yaml:"disable_default_patterns"`
+}
+
+// Compile returns the regular expressions to mask, the default patterns first
+func (c RedactionConfig) Compile() ([]*regexp.Regexp, error) {
+	var sources []string
+	if !c.DisableDefaultPatterns {
+		sources = append(sources, DefaultRedactionPatterns...)
+	}
+	sources = append(sources, c.Patterns...)
+
+	patterns := make([]*regexp.Regexp, 0, len(sources))
+	for _, source := range sources {
+		pattern, err := regexp.Compile(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", source, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// validateRedaction checks that the redaction patterns compile and cannot match empty text
+func validateRedaction(configErr *ConfigError, c RedactionConfig) {
+	for i, source := range c.Patterns {
+		field := fmt.Sprintf("redaction.patterns[%d]", i)
+		pattern, err := regexp.Compile(source)
+		if err != nil {
+			configErr.add(field, "must be a regular expression: %v", err)
+		} else if pattern.MatchString("") {
+			configErr.add(field, "must not match empty text, got %q", source)
+		}
+	}
+}
+
+// Redactor masks known secret values and the matches of redaction patterns. Values can be added
+// while it is in use, as sensitive values are found in the output it masks. A nil Redactor leaves
+// text unchanged.
+type Redactor struct {
+	patterns []*regexp.Regexp
+
+	mu     sync.RWMutex
+	values map[string]bool
+	known  *strings.Replacer
+}
+
+// NewRedactor returns a redactor masking the matches of patterns and the given values
+func NewRedactor(patterns []*regexp.Regexp, values ...string) *Redactor {
+	r := &Redactor{patterns: patterns, values: make(map[string]bool)}
+	r.AddValues(values...)
+	return r
+}
+
+// AddValues masks further values, including as they appear escaped in JSON strings and, for values
+// spanning several lines, each of their lines as output arrives line by line. Values and lines shorter
+// than MinRedactedValueLength are left alone.
+func (r *Redactor) AddValues(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	added := false
+	add := func(value string) {
+		if len(value) >= MinRedactedValueLength && !r.values[value] {
+			r.values[value] = true
+			added = true
+		}
+	}
+	for _, value := range values {
+		add(value)
+		if escaped, err := json.Marshal(value); err == nil {
+			add(string(escaped[1 : len(escaped)-1]))
+		}
+		if strings.Contains(value, "\n") {
+			for _, line := range strings.Split(value, "\n") {
+				add(strings.TrimSpace(line))
+			}
+		}
+	}
+	if !added {
+		return
+	}
+
+	// The replacer prefers earlier arguments, so longer values are masked whole rather than in part
+	candidates := make([]string, 0, len(r.values))
+	for value := range r.values {
+		candidates = append(candidates, value)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i]) != len(candidates[j]) {
+			return len(candidates[i]) > len(candidates[j])
+		}
+		return candidates[i] < candidates[j]
+	})
+	pairs := make([]string, 0, 2*len(candidates))
+	for _, candidate := range candidates {
+		pairs = append(pairs, candidate, RedactedValue)
+	}
+	r.known = strings.NewReplacer(pairs...)
+}
+
+// Redact masks known values, private key blocks and pattern matches in text
+func (r *Redactor) Redact(text string) string {
+	if r == nil || text == "" {
+		return text
+	}
+	r.mu.RLock()
+	known := r.known
+	r.mu.RUnlock()
+
+	if known != nil {
+		text = known.Replace(text)
+	}
+	text = privateKeyBlock.ReplaceAllLiteralString(text, RedactedValue)
+	for _, pattern := range r.patterns {
+		text = maskPattern(pattern, text)
+	}
+	return text
+}
+
+// Lines masks each line before passing it on. Lines inside a private key block, which only shows
+// as a whole across lines, are masked entirely.
+func (r *Redactor) Lines(next LineHandler) LineHandler {
+	if r == nil {
+		return next
+	}
+	inKey := make(map[string]bool)
+	return func(stream, line string) {
+		switch {
+		case inKey[stream]:
+			if end := privateKeyEnd.FindStringIndex(line); end != nil {
+				inKey[stream] = false
+				line = RedactedValue + line[end[1]:]
+			} else {
+				line = RedactedValue
+			}
+		case !privateKeyBlock.MatchString(line) && privateKeyBegin.MatchString(line):
+			inKey[stream] = true
+			line = privateKeyBegin.ReplaceAllLiteralString(line, RedactedValue)
+		}
+		next(stream, r.Redact(line))
+	}
+}
+
+// Error masks the message and details of an error
+func (r *Redactor) Error(err error) error {
+	if r == nil || err == nil {
+		return err
+	}
+	if tfErr, ok := err.(*TerraformError); ok {
+		return &TerraformError{Code: tfErr.Code, Message: r.Redact(tfErr.Message), Details: r.Redact(tfErr.Details)}
+	}
+	if redacted := r.Redact(err.Error()); redacted != err.Error() {
+		return fmt.Errorf("%s", redacted)
+	}
+	return err
+}
+
+// maskPattern replaces the matches of a pattern, or only the text its groups capture if it has any
+func maskPattern(pattern *regexp.Regexp, text string) string {
+	if pattern.NumSubexp() == 0 {
+		return pattern.ReplaceAllLiteralString(text, RedactedValue)
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		for group := 1; group <= pattern.NumSubexp(); group++ {
+			start, end := match[2*group], match[2*group+1]
+			if start < last || start == end {
+				continue
+			}
+			b.WriteString(text[last:start])
+			b.WriteString(RedactedValue)
+			last = end
+		}
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -0,0 +1,123 @@
+package TerraformStation
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+)
+
+// MasterKeySize is the size in bytes of the master key secrets are encrypted with, using AES-256-GCM
+const MasterKeySize = 32
+
+// secretNamePattern matches valid secret names
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,127}$`)
+
+// ValidateSecretName checks that a secret name is 1 to 128 letters, digits, '.', '_' and '-',
+// starting with a letter or digit
+func ValidateSecretName(name string) error {
+	if !secretNamePattern.MatchString(name) {
+		return NewInvalidInputError("invalid secret name: must be 1 to 128 letters, digits, '.', '_' and '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// SecretCipher encrypts and decrypts secrets with the station's master key
+type SecretCipher struct {
+	aead  cipher.AEAD
+	keyID string
+}
+
+// LoadSecretCipher reads the master key from a file and returns a cipher using it. The file holds
+// the 32 byte key either raw, base64 or hex encoded, and must not be readable by group or others.
+func LoadSecretCipher(path string) (*SecretCipher, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read master key: %w", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("master key file %s must not be accessible by group or others, has mode %s", path, info.Mode().Perm())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read master key: %w", err)
+	}
+	key, err := decodeMasterKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid master key in %s: %w", path, err)
+	}
+	return NewSecretCipher(key)
+}
+
+// decodeMasterKey accepts a raw, base64 or hex encoded key of MasterKeySize bytes
+func decodeMasterKey(data []byte) ([]byte, error) {
+	if len(data) == MasterKeySize {
+		return data, nil
+	}
+	text := string(bytes.TrimSpace(data))
+	if key, err := base64.StdEncoding.DecodeString(text); err == nil && len(key) == MasterKeySize {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(text); err == nil && len(key) == MasterKeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("must be %d bytes, raw or base64 or hex encoded", MasterKeySize)
+}
+
+// NewSecretCipher returns a cipher using a master key of MasterKeySize bytes
+func NewSecretCipher(key []byte) (*SecretCipher, error) {
+	if len(key) != MasterKeySize {
+		return nil, fmt.Errorf("master key must be %d bytes, got %d", MasterKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(key)
+	return &SecretCipher{aead: aead, keyID: hex.EncodeToString(sum[:8])}, nil
+}
+
+// KeyID identifies the master key without revealing it
+func (c *SecretCipher) KeyID() string {
+	return c.keyID
+}
+
+// Encrypt seals the value of a secret. The name is authenticated along with the value, so a
+// ciphertext cannot be moved to another secret.
+func (c *SecretCipher) Encrypt(name string, value []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, value, []byte(name))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens the value of a secret sealed by Encrypt
+func (c *SecretCipher) Decrypt(name, ciphertext string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("malformed ciphertext: %w", err)
+	}
+	if len(sealed) < c.aead.NonceSize() {
+		return nil, fmt.Errorf("malformed ciphertext: too short")
+	}
+	nonce, sealed := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	value, err := c.aead.Open(nil, nonce, sealed, []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret %s: %w", name, err)
+	}
+	return value, nil
+}
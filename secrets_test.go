@@ -0,0 +1,89 @@
+package TerraformStation
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretCipher(t *testing.T) {
+	cipher, err := NewSecretCipher(bytes.Repeat([]byte{1}, MasterKeySize))
+	require.NoError(t, err)
+
+	ciphertext, err := cipher.Encrypt("db", []byte("hunter2"))
+	require.NoError(t, err)
+	assert.NotContains(t, ciphertext, "hunter2")
+	again, err := cipher.Encrypt("db", []byte("hunter2"))
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, again)
+
+	value, err := cipher.Decrypt("db", ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", string(value))
+
+	// The ciphertext is bound to its secret and key
+	_, err = cipher.Decrypt("other", ciphertext)
+	assert.Error(t, err)
+	other, err := NewSecretCipher(bytes.Repeat([]byte{2}, MasterKeySize))
+	require.NoError(t, err)
+	assert.NotEqual(t, cipher.KeyID(), other.KeyID())
+	_, err = other.Decrypt("db", ciphertext)
+	assert.Error(t, err)
+
+	_, err = NewSecretCipher([]byte("short"))
+	assert.Error(t, err)
+}
+
+func TestLoadSecretCipher(t *testing.T) {
+	key := bytes.Repeat([]byte{3}, MasterKeySize)
+	want, err := NewSecretCipher(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{
+		"raw":    key,
+		"base64": []byte(base64.StdEncoding.EncodeToString(key) + "\n"),
+		"hex":    []byte(hex.EncodeToString(key)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(path, data, 0600))
+			cipher, err := LoadSecretCipher(path)
+			require.NoError(t, err)
+			assert.Equal(t, want.KeyID(), cipher.KeyID())
+		})
+	}
+
+	t.Run("readable by others", func(t *testing.T) {
+		path := filepath.Join(dir, "shared")
+		require.NoError(t, os.WriteFile(path, key, 0644))
+		require.NoError(t, os.Chmod(path, 0644))
+		_, err := LoadSecretCipher(path)
+		assert.ErrorContains(t, err, "must not be accessible")
+	})
+
+	t.Run("wrong size", func(t *testing.T) {
+		path := filepath.Join(dir, "short")
+		require.NoError(t, os.WriteFile(path, []byte("c2hvcnQ="), 0600))
+		_, err := LoadSecretCipher(path)
+		assert.ErrorContains(t, err, "invalid master key")
+	})
+
+	_, err = LoadSecretCipher(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestValidateSecretName(t *testing.T) {
+	for _, name := range []string{"db", "db-password", "prod.db_password", "0"} {
+		assert.NoError(t, ValidateSecretName(name), name)
+	}
+	for _, name := range []string{"", "-db", ".db", "db/password", "db password", string(bytes.Repeat([]byte("a"), 129))} {
+		assert.Error(t, ValidateSecretName(name), name)
+	}
+}
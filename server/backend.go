@@ -0,0 +1,138 @@
+package server
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/ForestMars/TerraformStation"
+)
+
+// maxStateBodySize limits the size of state files written through the http backend
+const maxStateBodySize = 64 << 20
+
+// Methods OpenTofu's http backend uses by default for lock_method and unlock_method
+const (
+	methodLock   = "LOCK"
+	methodUnlock = "UNLOCK"
+)
+
+// backendRoutes registers the endpoints of the OpenTofu http state backend protocol
+func (s *HTTPServer) backendRoutes() {
+	s.mux.HandleFunc("GET /v1/tfstate/{name...}", s.handleGetBackendState)
+	s.mux.HandleFunc("POST /v1/tfstate/{name...}", s.handlePutBackendState)
+	s.mux.HandleFunc("DELETE /v1/tfstate/{name...}", s.handleDeleteBackendState)
+	s.mux.HandleFunc(methodLock+" /v1/tfstate/{name...}", s.handleLockBackendState)
+	s.mux.HandleFunc(methodUnlock+" /v1/tfstate/{name...}", s.handleUnlockBackendState)
+}
+
+func (s *HTTPServer) handleGetBackendState(w http.ResponseWriter, r *http.Request) {
+	data, err := s.service.GetBackendState(r.Context(), r.PathValue("name"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if data == nil {
+		// OpenTofu treats an empty response as a state that does not exist yet
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func (s *HTTPServer) handlePutBackendState(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxStateBodySize))
+	if err != nil {
+		writeError(w, TerraformStation.NewInvalidInputError("failed to read request body", err.Error()))
+		return
+	}
+	if expected := r.Header.Get("Content-MD5"); expected != "" {
+		sum := md5.Sum(data)
+		if base64.StdEncoding.EncodeToString(sum[:]) != expected {
+			writeError(w, TerraformStation.NewInvalidInputError("state does not match Content-MD5"))
+			return
+		}
+	}
+
+	err = s.service.PutBackendState(r.Context(), r.PathValue("name"), r.URL.Query().Get("ID"), data)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *HTTPServer) handleDeleteBackendState(w http.ResponseWriter, r *http.Request) {
+	err := s.service.DeleteBackendState(r.Context(), r.PathValue("name"), r.URL.Query().Get("ID"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *HTTPServer) handleLockBackendState(w http.ResponseWriter, r *http.Request) {
+	info, ok := decodeLockInfo(w, r)
+	if !ok {
+		return
+	}
+	if info == nil {
+		writeError(w, TerraformStation.NewInvalidInputError("lock info cannot be empty"))
+		return
+	}
+	holder, err := s.service.LockBackendState(r.Context(), r.PathValue("name"), info)
+	writeLockResult(w, holder, err)
+}
+
+func (s *HTTPServer) handleUnlockBackendState(w http.ResponseWriter, r *http.Request) {
+	info, ok := decodeLockInfo(w, r)
+	if !ok {
+		return
+	}
+	holder, err := s.service.UnlockBackendState(r.Context(), r.PathValue("name"), info)
+	writeLockResult(w, holder, err)
+}
+
+// decodeLockInfo reads the lock description sent by OpenTofu, which is empty for force-unlock
+func decodeLockInfo(w http.ResponseWriter, r *http.Request) (*TerraformStation.StateLockInfo, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		writeError(w, TerraformStation.NewInvalidInputError("failed to read request body", err.Error()))
+		return nil, false
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, true
+	}
+
+	info := &TerraformStation.StateLockInfo{}
+	if err := json.Unmarshal(body, info); err != nil {
+		writeError(w, TerraformStation.NewInvalidInputError("invalid lock info", err.Error()))
+		return nil, false
+	}
+	return info, true
+}
+
+// writeLockResult answers a lock or unlock request. Conflicts are reported with 423 Locked
+// and the current holder as the body, which OpenTofu shows to the user.
+func writeLockResult(w http.ResponseWriter, holder *TerraformStation.StateLockInfo, err error) {
+	var tfErr *TerraformStation.TerraformError
+	if errors.As(err, &tfErr) && tfErr.Code == TerraformStation.ErrCodeLocked && holder != nil {
+		writeJSON(w, http.StatusLocked, holder)
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if holder == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	writeJSON(w, http.StatusOK, holder)
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpStatus maps an error to the HTTP status code returned to clients
+func httpStatus(err error) int {
+	var tfErr *TerraformStation.TerraformError
+	if !errors.As(err, &tfErr) {
+		return http.StatusInternalServerError
+	}
+
+	switch tfErr.Code {
+	case TerraformStation.ErrCodeInvalidInput, TerraformStation.ErrCodeWorkingDirError:
+		return http.StatusBadRequest
+	case TerraformStation.ErrCodePermissionDenied:
+		return http.StatusForbidden
+	case TerraformStation.ErrCodeNotFound:
+		return http.StatusNotFound
+	case TerraformStation.ErrCodeInvalidState, TerraformStation.ErrCodeLocked:
+		return http.StatusConflict
+	case TerraformStation.ErrCodeTimeout:
+		return http.StatusGatewayTimeout
+	case TerraformStation.ErrCodeTerraformNotFound:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// grpcStatus maps an error to the gRPC status returned to clients
+func grpcStatus(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err)
+	}
+
+	var tfErr *TerraformStation.TerraformError
+	if !errors.As(err, &tfErr) {
+		return status.New(codes.Internal, err.Error())
+	}
+
+	var code codes.Code
+	switch tfErr.Code {
+	case TerraformStation.ErrCodeInvalidInput, TerraformStation.ErrCodeWorkingDirError:
+		code = codes.InvalidArgument
+	case TerraformStation.ErrCodePermissionDenied:
+		code = codes.PermissionDenied
+	case TerraformStation.ErrCodeNotFound:
+		code = codes.NotFound
+	case TerraformStation.ErrCodeInvalidState:
+		code = codes.FailedPrecondition
+	case TerraformStation.ErrCodeLocked:
+		code = codes.Aborted
+	case TerraformStation.ErrCodeTimeout:
+		code = codes.DeadlineExceeded
+	case TerraformStation.ErrCodeTerraformNotFound:
+		code = codes.Unavailable
+	default:
+		code = codes.Internal
+	}
+	return status.New(code, tfErr.Error())
+}
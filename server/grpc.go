@@ -0,0 +1,241 @@
+package server
+
+import (
+	"context"
+	"net"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/grpc"
+)
+
+// GRPCServer adapts a TerraformStationService to the generated gRPC service
+type GRPCServer struct {
+	TerraformStation.UnimplementedTerraformStationServiceServer
+
+	service TerraformStation.TerraformStationService
+	cfg     *TerraformStation.Config
+}
+
+var _ TerraformStation.TerraformStationServiceServer = (*GRPCServer)(nil)
+
+// NewGRPCServer creates a new gRPC server for the given service
+func NewGRPCServer(service TerraformStation.TerraformStationService, cfg *TerraformStation.Config) *GRPCServer {
+	return &GRPCServer{
+		service: service,
+		cfg:     cfg,
+	}
+}
+
+// Register registers the service with a gRPC server
+func (s *GRPCServer) Register(registrar grpc.ServiceRegistrar) {
+	TerraformStation.RegisterTerraformStationServiceServer(registrar, s)
+}
+
+// ListenAndServe serves the gRPC API on the configured host and port until ctx is cancelled
+func (s *GRPCServer) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.cfg.Host, s.cfg.GRPCPort))
+	if err != nil {
+		return err
+	}
+
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(errorInterceptor),
+		grpc.StreamInterceptor(streamErrorInterceptor),
+	)
+	s.Register(srv)
+
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	return srv.Serve(listener)
+}
+
+// TFCommand executes a generic OpenTofu command
+func (s *GRPCServer) TFCommand(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFCommandResult, error) {
+	return s.service.TFCommand(ctx, input)
+}
+
+// TFPlan executes opentofu plan
+func (s *GRPCServer) TFPlan(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFPlanResult, error) {
+	return s.service.TFPlan(ctx, input)
+}
+
+// TFApply executes opentofu apply
+func (s *GRPCServer) TFApply(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFApplyResult, error) {
+	return s.service.TFApply(ctx, input)
+}
+
+// TFInit executes opentofu init
+func (s *GRPCServer) TFInit(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFCommandResult, error) {
+	return s.service.TFInit(ctx, input)
+}
+
+// TFValidate executes opentofu validate
+func (s *GRPCServer) TFValidate(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFCommandResult, error) {
+	return s.service.TFValidate(ctx, input)
+}
+
+// TFState retrieves opentofu state information
+func (s *GRPCServer) TFState(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFStateInfo, error) {
+	return s.service.TFState(ctx, input)
+}
+
+// TFCommandStream executes a command and streams its output as it is produced
+func (s *GRPCServer) TFCommandStream(input *TerraformStation.TFCommandInput, stream grpc.ServerStreamingServer[TerraformStation.TFOutputChunk]) error {
+	_, err := s.service.TFCommandStream(stream.Context(), input, stream.Send)
+	return err
+}
+
+// TFSubscribeOutput replays and follows the output of a command
+func (s *GRPCServer) TFSubscribeOutput(input *TerraformStation.TFSubscribeInput, stream grpc.ServerStreamingServer[TerraformStation.TFOutputChunk]) error {
+	return s.service.TFSubscribeOutput(stream.Context(), input, stream.Send)
+}
+
+// TFSubscribeProgress replays and follows the progress events of a command
+func (s *GRPCServer) TFSubscribeProgress(input *TerraformStation.TFSubscribeInput, stream grpc.ServerStreamingServer[TerraformStation.TFProgressEvent]) error {
+	return s.service.TFSubscribeProgress(stream.Context(), input, stream.Send)
+}
+
+// TFCancelOperation interrupts a running command
+func (s *GRPCServer) TFCancelOperation(ctx context.Context, input *TerraformStation.TFOperationInput) (*TerraformStation.TFOperation, error) {
+	return s.service.TFCancelOperation(ctx, input)
+}
+
+// TFApprovePlan records an approval of a plan
+func (s *GRPCServer) TFApprovePlan(ctx context.Context, input *TerraformStation.TFPlanReviewInput) (*TerraformStation.TFPlanApprovalStatus, error) {
+	return s.service.TFApprovePlan(ctx, input)
+}
+
+// TFRejectPlan records a rejection of a plan
+func (s *GRPCServer) TFRejectPlan(ctx context.Context, input *TerraformStation.TFPlanReviewInput) (*TerraformStation.TFPlanApprovalStatus, error) {
+	return s.service.TFRejectPlan(ctx, input)
+}
+
+// TFGetPlanApproval returns the approval status of a plan
+func (s *GRPCServer) TFGetPlanApproval(ctx context.Context, input *TerraformStation.TFPlanApprovalInput) (*TerraformStation.TFPlanApprovalStatus, error) {
+	return s.service.TFGetPlanApproval(ctx, input)
+}
+
+// TFSubmitJob queues a command to run asynchronously
+func (s *GRPCServer) TFSubmitJob(ctx context.Context, input *TerraformStation.TFSubmitJobInput) (*TerraformStation.TFJob, error) {
+	return s.service.TFSubmitJob(ctx, input)
+}
+
+// TFGetJob returns the status of a job
+func (s *GRPCServer) TFGetJob(ctx context.Context, input *TerraformStation.TFJobInput) (*TerraformStation.TFJob, error) {
+	return s.service.TFGetJob(ctx, input)
+}
+
+// TFListJobs lists jobs
+func (s *GRPCServer) TFListJobs(ctx context.Context, input *TerraformStation.TFListJobsInput) (*TerraformStation.TFJobList, error) {
+	return s.service.TFListJobs(ctx, input)
+}
+
+// TFGetJobOutput returns the output of a job
+func (s *GRPCServer) TFGetJobOutput(ctx context.Context, input *TerraformStation.TFJobOutputInput) (*TerraformStation.TFJobOutput, error) {
+	return s.service.TFGetJobOutput(ctx, input)
+}
+
+// TFCancelJob cancels a job
+func (s *GRPCServer) TFCancelJob(ctx context.Context, input *TerraformStation.TFJobInput) (*TerraformStation.TFJob, error) {
+	return s.service.TFCancelJob(ctx, input)
+}
+
+// TFListLocks lists the working directory locks currently held
+func (s *GRPCServer) TFListLocks(ctx context.Context, input *TerraformStation.TFListLocksInput) (*TerraformStation.TFLockList, error) {
+	return s.service.TFListLocks(ctx, input)
+}
+
+// TFForceUnlock releases a working directory lock regardless of its holder
+func (s *GRPCServer) TFForceUnlock(ctx context.Context, input *TerraformStation.TFForceUnlockInput) (*TerraformStation.TFLock, error) {
+	return s.service.TFForceUnlock(ctx, input)
+}
+
+// TFListStateVersions lists the versions of a state, newest first
+func (s *GRPCServer) TFListStateVersions(ctx context.Context, input *TerraformStation.TFStateVersionsInput) (*TerraformStation.TFStateVersionList, error) {
+	return s.service.TFListStateVersions(ctx, input)
+}
+
+// TFDiffStateVersions compares two versions of a state
+func (s *GRPCServer) TFDiffStateVersions(ctx context.Context, input *TerraformStation.TFStateDiffInput) (*TerraformStation.TFStateDiff, error) {
+	return s.service.TFDiffStateVersions(ctx, input)
+}
+
+// TFRollbackState restores an older version of a state as the newest one
+func (s *GRPCServer) TFRollbackState(ctx context.Context, input *TerraformStation.TFStateRollbackInput) (*TerraformStation.TFStateVersion, error) {
+	return s.service.TFRollbackState(ctx, input)
+}
+
+// TFDetectDrift checks a working directory for drift straight away
+func (s *GRPCServer) TFDetectDrift(ctx context.Context, input *TerraformStation.TFDriftInput) (*TerraformStation.TFDriftReport, error) {
+	return s.service.TFDetectDrift(ctx, input)
+}
+
+// TFListDriftReports lists drift reports, newest first
+func (s *GRPCServer) TFListDriftReports(ctx context.Context, input *TerraformStation.TFListDriftReportsInput) (*TerraformStation.TFDriftReportList, error) {
+	return s.service.TFListDriftReports(ctx, input)
+}
+
+// TFGetDriftReport returns a single drift report
+func (s *GRPCServer) TFGetDriftReport(ctx context.Context, input *TerraformStation.TFDriftReportInput) (*TerraformStation.TFDriftReport, error) {
+	return s.service.TFGetDriftReport(ctx, input)
+}
+
+// TFListWorkspaces lists the workspaces of a working directory
+func (s *GRPCServer) TFListWorkspaces(ctx context.Context, input *TerraformStation.TFWorkspaceInput) (*TerraformStation.TFWorkspaceList, error) {
+	return s.service.TFListWorkspaces(ctx, input)
+}
+
+// TFShowWorkspace returns the workspace selected in a working directory
+func (s *GRPCServer) TFShowWorkspace(ctx context.Context, input *TerraformStation.TFWorkspaceInput) (*TerraformStation.TFWorkspace, error) {
+	return s.service.TFShowWorkspace(ctx, input)
+}
+
+// TFNewWorkspace creates a workspace
+func (s *GRPCServer) TFNewWorkspace(ctx context.Context, input *TerraformStation.TFWorkspaceInput) (*TerraformStation.TFWorkspace, error) {
+	return s.service.TFNewWorkspace(ctx, input)
+}
+
+// TFSelectWorkspace selects a workspace
+func (s *GRPCServer) TFSelectWorkspace(ctx context.Context, input *TerraformStation.TFWorkspaceInput) (*TerraformStation.TFWorkspace, error) {
+	return s.service.TFSelectWorkspace(ctx, input)
+}
+
+// TFDeleteWorkspace deletes a workspace
+func (s *GRPCServer) TFDeleteWorkspace(ctx context.Context, input *TerraformStation.TFWorkspaceInput) (*TerraformStation.TFWorkspace, error) {
+	return s.service.TFDeleteWorkspace(ctx, input)
+}
+
+// TFPutSecret stores a secret
+func (s *GRPCServer) TFPutSecret(ctx context.Context, input *TerraformStation.TFPutSecretInput) (*TerraformStation.TFSecret, error) {
+	return s.service.TFPutSecret(ctx, input)
+}
+
+// TFListSecrets lists the stored secrets without their values
+func (s *GRPCServer) TFListSecrets(ctx context.Context, input *TerraformStation.TFListSecretsInput) (*TerraformStation.TFSecretList, error) {
+	return s.service.TFListSecrets(ctx, input)
+}
+
+// TFDeleteSecret deletes a secret
+func (s *GRPCServer) TFDeleteSecret(ctx context.Context, input *TerraformStation.TFSecretInput) (*TerraformStation.TFSecret, error) {
+	return s.service.TFDeleteSecret(ctx, input)
+}
+
+// errorInterceptor converts errors returned by handlers into gRPC status errors
+func errorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, grpcStatus(err).Err()
+	}
+	return resp, nil
+}
+
+// streamErrorInterceptor converts errors returned by streaming handlers into gRPC status errors
+func streamErrorInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, ss); err != nil {
+		return grpcStatus(err).Err()
+	}
+	return nil
+}
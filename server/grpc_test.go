@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestGRPCClient(t *testing.T, svc *stubService) TerraformStation.TerraformStationServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(errorInterceptor))
+	NewGRPCServer(svc, TerraformStation.DefaultConfig()).Register(srv)
+	go srv.Serve(listener)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return TerraformStation.NewTerraformStationServiceClient(conn)
+}
+
+func TestGRPCCommand(t *testing.T) {
+	svc := &stubService{}
+	client := newTestGRPCClient(t, svc)
+
+	result, err := client.TFCommand(context.Background(), &TerraformStation.TFCommandInput{Command: "version"})
+	require.NoError(t, err)
+	assert.Equal(t, "tofu_1", result.CommandId)
+	assert.Equal(t, "version", svc.last.Command)
+}
+
+func TestGRPCErrorMapping(t *testing.T) {
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{TerraformStation.NewInvalidInputError("bad"), codes.InvalidArgument},
+		{TerraformStation.NewTimeoutError("slow"), codes.DeadlineExceeded},
+		{TerraformStation.NewPermissionDeniedError("nope"), codes.PermissionDenied},
+		{TerraformStation.NewTerraformNotFoundError("no tofu"), codes.Unavailable},
+		{TerraformStation.NewExecutionFailedError("boom"), codes.Internal},
+	}
+
+	for _, tc := range cases {
+		client := newTestGRPCClient(t, &stubService{err: tc.err})
+
+		_, err := client.TFPlan(context.Background(), &TerraformStation.TFCommandInput{})
+		require.Error(t, err)
+		assert.Equal(t, tc.code, status.Code(err), tc.err.Error())
+	}
+}
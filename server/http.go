@@ -0,0 +1,657 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxRequestBodySize limits the size of JSON request bodies
+const maxRequestBodySize = 1 << 20
+
+// HTTPServer exposes a TerraformStationService as a JSON REST API
+type HTTPServer struct {
+	service TerraformStation.TerraformStationService
+	cfg     *TerraformStation.Config
+	mux     *http.ServeMux
+}
+
+// NewHTTPServer creates a new HTTP server for the given service
+func NewHTTPServer(service TerraformStation.TerraformStationService, cfg *TerraformStation.Config) *HTTPServer {
+	s := &HTTPServer{
+		service: service,
+		cfg:     cfg,
+		mux:     http.NewServeMux(),
+	}
+	s.routes()
+	return s
+}
+
+// routes registers the API endpoints
+func (s *HTTPServer) routes() {
+	if s.cfg.Monitoring.EnableHealthCheck {
+		s.mux.HandleFunc("GET /health", s.handleHealth)
+	}
+
+	s.mux.HandleFunc("POST /v1/command", s.handleCommand)
+	s.mux.HandleFunc("POST /v1/plan", s.handlePlan)
+	s.mux.HandleFunc("POST /v1/apply", s.handleApply)
+	s.mux.HandleFunc("POST /v1/init", s.handleInit)
+	s.mux.HandleFunc("POST /v1/validate", s.handleValidate)
+	s.mux.HandleFunc("POST /v1/state", s.handleState)
+
+	s.mux.HandleFunc("POST /v1/command/stream", s.handleCommandStream)
+	s.mux.HandleFunc("GET /v1/operations/{command_id}/output", s.handleSubscribeOutput)
+	s.mux.HandleFunc("GET /v1/operations/{command_id}/progress", s.handleSubscribeProgress)
+	s.mux.HandleFunc("POST /v1/operations/{command_id}/cancel", s.handleCancelOperation)
+
+	s.mux.HandleFunc("POST /v1/plans/{plan_id}/approve", s.handleApprovePlan)
+	s.mux.HandleFunc("POST /v1/plans/{plan_id}/reject", s.handleRejectPlan)
+	s.mux.HandleFunc("GET /v1/plans/{plan_id}/approval", s.handleGetPlanApproval)
+
+	s.mux.HandleFunc("POST /v1/jobs", s.handleSubmitJob)
+	s.mux.HandleFunc("GET /v1/jobs", s.handleListJobs)
+	s.mux.HandleFunc("GET /v1/jobs/{job_id}", s.handleGetJob)
+	s.mux.HandleFunc("GET /v1/jobs/{job_id}/output", s.handleGetJobOutput)
+	s.mux.HandleFunc("POST /v1/jobs/{job_id}/cancel", s.handleCancelJob)
+
+	s.mux.HandleFunc("GET /v1/locks", s.handleListLocks)
+	s.mux.HandleFunc("POST /v1/locks/{lock_id}/force-unlock", s.handleForceUnlock)
+
+	s.mux.HandleFunc("GET /v1/state-versions", s.handleListStateVersions)
+	s.mux.HandleFunc("GET /v1/state-versions/diff", s.handleDiffStateVersions)
+	s.mux.HandleFunc("POST /v1/state-versions/rollback", s.handleRollbackState)
+
+	s.mux.HandleFunc("POST /v1/drift/detect", s.handleDetectDrift)
+	s.mux.HandleFunc("GET /v1/drift/reports", s.handleListDriftReports)
+	s.mux.HandleFunc("GET /v1/drift/reports/{report_id}", s.handleGetDriftReport)
+
+	s.mux.HandleFunc("GET /v1/workspaces", s.handleListWorkspaces)
+	s.mux.HandleFunc("GET /v1/workspaces/current", s.handleShowWorkspace)
+	s.mux.HandleFunc("POST /v1/workspaces", s.handleNewWorkspace)
+	s.mux.HandleFunc("POST /v1/workspaces/{name}/select", s.handleSelectWorkspace)
+	s.mux.HandleFunc("DELETE /v1/workspaces/{name}", s.handleDeleteWorkspace)
+
+	s.mux.HandleFunc("GET /v1/secrets", s.handleListSecrets)
+	s.mux.HandleFunc("PUT /v1/secrets/{name}", s.handlePutSecret)
+	s.mux.HandleFunc("DELETE /v1/secrets/{name}", s.handleDeleteSecret)
+
+	s.backendRoutes()
+}
+
+// Handler returns the root HTTP handler including middleware
+func (s *HTTPServer) Handler() http.Handler {
+	var handler http.Handler = s.mux
+	if s.cfg.EnableCORS {
+		handler = corsMiddleware(handler, s.cfg.Security.AllowedOrigins)
+	}
+	return handler
+}
+
+// ListenAndServe serves the API on the configured host and port until ctx is cancelled
+func (s *HTTPServer) ListenAndServe(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              net.JoinHostPort(s.cfg.Host, s.cfg.Port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+func (s *HTTPServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *HTTPServer) handleCommand(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeInput(w, r)
+	if !ok {
+		return
+	}
+	result, err := s.service.TFCommand(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handlePlan(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeInput(w, r)
+	if !ok {
+		return
+	}
+	result, err := s.service.TFPlan(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleApply(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeInput(w, r)
+	if !ok {
+		return
+	}
+	result, err := s.service.TFApply(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleInit(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeInput(w, r)
+	if !ok {
+		return
+	}
+	result, err := s.service.TFInit(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleValidate(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeInput(w, r)
+	if !ok {
+		return
+	}
+	result, err := s.service.TFValidate(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleState(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeInput(w, r)
+	if !ok {
+		return
+	}
+	result, err := s.service.TFState(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleCommandStream(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeInput(w, r)
+	if !ok {
+		return
+	}
+	sse, ok := newSSEWriter(w)
+	if !ok {
+		writeError(w, errors.New("streaming is not supported by this connection"))
+		return
+	}
+
+	_, err := s.service.TFCommandStream(r.Context(), input, func(chunk *TerraformStation.TFOutputChunk) error {
+		if chunk.Result != nil {
+			return sse.send("result", 0, chunk)
+		}
+		return sse.send("output", chunk.Sequence, chunk)
+	})
+	finishStream(w, sse, err)
+}
+
+func (s *HTTPServer) handleSubscribeOutput(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeSubscribeInput(w, r)
+	if !ok {
+		return
+	}
+
+	sse, ok := newSSEWriter(w)
+	if !ok {
+		writeError(w, errors.New("streaming is not supported by this connection"))
+		return
+	}
+
+	err := s.service.TFSubscribeOutput(r.Context(), input, func(chunk *TerraformStation.TFOutputChunk) error {
+		return sse.send("output", chunk.Sequence, chunk)
+	})
+	finishStream(w, sse, err)
+}
+
+func (s *HTTPServer) handleSubscribeProgress(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeSubscribeInput(w, r)
+	if !ok {
+		return
+	}
+
+	sse, ok := newSSEWriter(w)
+	if !ok {
+		writeError(w, errors.New("streaming is not supported by this connection"))
+		return
+	}
+
+	err := s.service.TFSubscribeProgress(r.Context(), input, func(event *TerraformStation.TFProgressEvent) error {
+		return sse.send("progress", event.Sequence, event)
+	})
+	finishStream(w, sse, err)
+}
+
+func (s *HTTPServer) handleCancelOperation(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFOperationInput{CommandId: r.PathValue("command_id")}
+	result, err := s.service.TFCancelOperation(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+// decodeSubscribeInput reads a subscription from the path, resuming after the sequence number given
+// by the query parameter or by the SSE reconnection header
+func decodeSubscribeInput(w http.ResponseWriter, r *http.Request) (*TerraformStation.TFSubscribeInput, bool) {
+	input := &TerraformStation.TFSubscribeInput{CommandId: r.PathValue("command_id")}
+
+	after := r.URL.Query().Get("after_sequence")
+	if after == "" {
+		after = r.Header.Get("Last-Event-ID")
+	}
+	if after != "" {
+		sequence, err := strconv.ParseInt(after, 10, 64)
+		if err != nil {
+			writeError(w, TerraformStation.NewInvalidInputError("invalid after_sequence", after))
+			return nil, false
+		}
+		input.AfterSequence = sequence
+	}
+	return input, true
+}
+
+func (s *HTTPServer) handleApprovePlan(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeReviewInput(w, r)
+	if !ok {
+		return
+	}
+	result, err := s.service.TFApprovePlan(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleRejectPlan(w http.ResponseWriter, r *http.Request) {
+	input, ok := decodeReviewInput(w, r)
+	if !ok {
+		return
+	}
+	result, err := s.service.TFRejectPlan(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleGetPlanApproval(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFPlanApprovalInput{PlanId: r.PathValue("plan_id")}
+	result, err := s.service.TFGetPlanApproval(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleSubmitJob(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFSubmitJobInput{}
+	if !decodeMessage(w, r, input) {
+		return
+	}
+	result, err := s.service.TFSubmitJob(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeProto(w, http.StatusAccepted, result)
+}
+
+func (s *HTTPServer) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := &TerraformStation.TFListJobsInput{Status: query.Get("status")}
+
+	var ok bool
+	if input.Limit, ok = queryInt32(w, query.Get("limit"), "limit"); !ok {
+		return
+	}
+	if input.Offset, ok = queryInt32(w, query.Get("offset"), "offset"); !ok {
+		return
+	}
+
+	result, err := s.service.TFListJobs(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFJobInput{JobId: r.PathValue("job_id")}
+	result, err := s.service.TFGetJob(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleGetJobOutput(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFJobOutputInput{JobId: r.PathValue("job_id")}
+	if after := r.URL.Query().Get("after_sequence"); after != "" {
+		sequence, err := strconv.ParseInt(after, 10, 64)
+		if err != nil {
+			writeError(w, TerraformStation.NewInvalidInputError("invalid after_sequence", after))
+			return
+		}
+		input.AfterSequence = sequence
+	}
+	result, err := s.service.TFGetJobOutput(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleCancelJob(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFJobInput{JobId: r.PathValue("job_id")}
+	result, err := s.service.TFCancelJob(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleListLocks(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFListLocksInput{WorkingDirectory: r.URL.Query().Get("working_directory")}
+	result, err := s.service.TFListLocks(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleForceUnlock(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFForceUnlockInput{LockId: r.PathValue("lock_id")}
+	result, err := s.service.TFForceUnlock(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleListStateVersions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := &TerraformStation.TFStateVersionsInput{
+		Name:             query.Get("name"),
+		WorkingDirectory: query.Get("working_directory"),
+		Workspace:        query.Get("workspace"),
+	}
+
+	var ok bool
+	if input.Limit, ok = queryInt32(w, query.Get("limit"), "limit"); !ok {
+		return
+	}
+	if input.Offset, ok = queryInt32(w, query.Get("offset"), "offset"); !ok {
+		return
+	}
+
+	result, err := s.service.TFListStateVersions(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleDiffStateVersions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := &TerraformStation.TFStateDiffInput{
+		Name:             query.Get("name"),
+		WorkingDirectory: query.Get("working_directory"),
+		Workspace:        query.Get("workspace"),
+	}
+
+	var ok bool
+	if input.FromVersion, ok = queryInt32(w, query.Get("from_version"), "from_version"); !ok {
+		return
+	}
+	if input.ToVersion, ok = queryInt32(w, query.Get("to_version"), "to_version"); !ok {
+		return
+	}
+
+	result, err := s.service.TFDiffStateVersions(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleRollbackState(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFStateRollbackInput{}
+	if !decodeMessage(w, r, input) {
+		return
+	}
+	result, err := s.service.TFRollbackState(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleDetectDrift(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFDriftInput{}
+	if !decodeMessage(w, r, input) {
+		return
+	}
+	result, err := s.service.TFDetectDrift(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleListDriftReports(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := &TerraformStation.TFListDriftReportsInput{
+		WorkingDirectory: query.Get("working_directory"),
+		Workspace:        query.Get("workspace"),
+		Status:           query.Get("status"),
+	}
+
+	var ok bool
+	if input.Limit, ok = queryInt32(w, query.Get("limit"), "limit"); !ok {
+		return
+	}
+	if input.Offset, ok = queryInt32(w, query.Get("offset"), "offset"); !ok {
+		return
+	}
+
+	result, err := s.service.TFListDriftReports(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleGetDriftReport(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFDriftReportInput{ReportId: r.PathValue("report_id")}
+	result, err := s.service.TFGetDriftReport(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleListWorkspaces(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFWorkspaceInput{WorkingDirectory: r.URL.Query().Get("working_directory")}
+	result, err := s.service.TFListWorkspaces(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleShowWorkspace(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFWorkspaceInput{WorkingDirectory: r.URL.Query().Get("working_directory")}
+	result, err := s.service.TFShowWorkspace(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleNewWorkspace(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFWorkspaceInput{}
+	if !decodeMessage(w, r, input) {
+		return
+	}
+	result, err := s.service.TFNewWorkspace(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeProto(w, http.StatusCreated, result)
+}
+
+func (s *HTTPServer) handleSelectWorkspace(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFWorkspaceInput{}
+	if !decodeMessage(w, r, input) {
+		return
+	}
+	input.Name = r.PathValue("name")
+	result, err := s.service.TFSelectWorkspace(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleDeleteWorkspace(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	input := &TerraformStation.TFWorkspaceInput{
+		WorkingDirectory: query.Get("working_directory"),
+		Name:             r.PathValue("name"),
+	}
+	if force := query.Get("force"); force != "" {
+		value, err := strconv.ParseBool(force)
+		if err != nil {
+			writeError(w, TerraformStation.NewInvalidInputError("invalid force", force))
+			return
+		}
+		input.Force = value
+	}
+	result, err := s.service.TFDeleteWorkspace(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleListSecrets(w http.ResponseWriter, r *http.Request) {
+	result, err := s.service.TFListSecrets(r.Context(), &TerraformStation.TFListSecretsInput{})
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handlePutSecret(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFPutSecretInput{}
+	if !decodeMessage(w, r, input) {
+		return
+	}
+	input.Name = r.PathValue("name")
+	result, err := s.service.TFPutSecret(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+func (s *HTTPServer) handleDeleteSecret(w http.ResponseWriter, r *http.Request) {
+	input := &TerraformStation.TFSecretInput{Name: r.PathValue("name")}
+	result, err := s.service.TFDeleteSecret(r.Context(), input)
+	writeResult(w, result, err)
+}
+
+// queryInt32 parses an optional integer query parameter, writing an error response on failure
+func queryInt32(w http.ResponseWriter, value, name string) (int32, bool) {
+	if value == "" {
+		return 0, true
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		writeError(w, TerraformStation.NewInvalidInputError("invalid "+name, value))
+		return 0, false
+	}
+	return int32(n), true
+}
+
+// finishStream reports the outcome of a stream, as a JSON error if nothing was sent yet or as an error event otherwise
+func finishStream(w http.ResponseWriter, sse *sseWriter, err error) {
+	if err == nil {
+		sse.start()
+		return
+	}
+	if !sse.started {
+		writeError(w, err)
+		return
+	}
+
+	data, marshalErr := json.Marshal(toTerraformError(err))
+	if marshalErr != nil {
+		log.Printf("failed to encode stream error: %v", marshalErr)
+		return
+	}
+	fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+	sse.flusher.Flush()
+}
+
+// decodeInput reads a TFCommandInput from the request body, writing an error response on failure
+func decodeInput(w http.ResponseWriter, r *http.Request) (*TerraformStation.TFCommandInput, bool) {
+	input := &TerraformStation.TFCommandInput{}
+	if !decodeMessage(w, r, input) {
+		return nil, false
+	}
+	return input, true
+}
+
+// decodeReviewInput reads a TFPlanReviewInput from the request body, taking the plan ID from the path
+func decodeReviewInput(w http.ResponseWriter, r *http.Request) (*TerraformStation.TFPlanReviewInput, bool) {
+	input := &TerraformStation.TFPlanReviewInput{}
+	if !decodeMessage(w, r, input) {
+		return nil, false
+	}
+	input.PlanId = r.PathValue("plan_id")
+	return input, true
+}
+
+// decodeMessage reads a protobuf message encoded as JSON from the request body
+func decodeMessage(w http.ResponseWriter, r *http.Request, msg proto.Message) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		writeError(w, TerraformStation.NewInvalidInputError("failed to read request body", err.Error()))
+		return false
+	}
+
+	if len(body) == 0 {
+		return true
+	}
+
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		writeError(w, TerraformStation.NewInvalidInputError("invalid request body", err.Error()))
+		return false
+	}
+	return true
+}
+
+// writeResult writes either the service result or the error returned alongside it
+func writeResult(w http.ResponseWriter, result proto.Message, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeProto(w, http.StatusOK, result)
+}
+
+// writeProto writes a protobuf message as JSON using the proto field names
+func writeProto(w http.ResponseWriter, status int, msg proto.Message) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// writeJSON writes an arbitrary value as JSON
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to encode response: %v", err)
+	}
+}
+
+// writeError writes an error response, mapping TerraformError codes to HTTP statuses
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, httpStatus(err), map[string]*TerraformStation.TerraformError{"error": toTerraformError(err)})
+}
+
+// toTerraformError returns err as a TerraformError, wrapping unknown errors as internal ones
+func toTerraformError(err error) *TerraformStation.TerraformError {
+	var tfErr *TerraformStation.TerraformError
+	if errors.As(err, &tfErr) {
+		return tfErr
+	}
+	return &TerraformStation.TerraformError{
+		Code:    "INTERNAL",
+		Message: err.Error(),
+	}
+}
+
+// corsMiddleware adds CORS headers for requests from allowed origins and answers preflight requests.
+// An allowed origin of "*" allows every origin.
+func corsMiddleware(next http.Handler, allowedOrigins []string) http.Handler {
+	allowAll := slices.Contains(allowedOrigins, "*")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		switch {
+		case allowAll:
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		case origin != "" && slices.Contains(allowedOrigins, origin):
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		w.Header().Add("Vary", "Origin")
+		if w.Header().Get("Access-Control-Allow-Origin") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		}
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
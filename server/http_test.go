@@ -0,0 +1,291 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubService is a minimal TerraformStationService used to exercise the transports
+type stubService struct {
+	TerraformStation.TerraformStationService
+	cfg  *TerraformStation.Config
+	err  error
+	last *TerraformStation.TFCommandInput
+
+	lastList *TerraformStation.TFListJobsInput
+
+	states     map[string][]byte
+	lastLockID string
+	holder     *TerraformStation.StateLockInfo
+}
+
+func (s *stubService) TFCommand(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFCommandResult, error) {
+	s.last = input
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &TerraformStation.TFCommandResult{CommandId: "tofu_1", Result: "ok", Success: true}, nil
+}
+
+func (s *stubService) TFPlan(ctx context.Context, input *TerraformStation.TFCommandInput) (*TerraformStation.TFPlanResult, error) {
+	s.last = input
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &TerraformStation.TFPlanResult{PlanId: "plan_1", HasChanges: true, Status: "completed"}, nil
+}
+
+func (s *stubService) TFSubmitJob(ctx context.Context, input *TerraformStation.TFSubmitJobInput) (*TerraformStation.TFJob, error) {
+	s.last = input.Input
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &TerraformStation.TFJob{JobId: "job_1", Type: input.Type, Status: TerraformStation.JobStatusQueued}, nil
+}
+
+func (s *stubService) TFListJobs(ctx context.Context, input *TerraformStation.TFListJobsInput) (*TerraformStation.TFJobList, error) {
+	s.lastList = input
+	return &TerraformStation.TFJobList{}, nil
+}
+
+func (s *stubService) GetBackendState(ctx context.Context, name string) ([]byte, error) {
+	return s.states[name], nil
+}
+
+func (s *stubService) PutBackendState(ctx context.Context, name, lockID string, data []byte) error {
+	s.lastLockID = lockID
+	if s.states == nil {
+		s.states = map[string][]byte{}
+	}
+	s.states[name] = data
+	return nil
+}
+
+func (s *stubService) LockBackendState(ctx context.Context, name string, info *TerraformStation.StateLockInfo) (*TerraformStation.StateLockInfo, error) {
+	if s.holder != nil {
+		return s.holder, TerraformStation.NewLockedError("state is locked")
+	}
+	s.holder = info
+	return info, nil
+}
+
+func (s *stubService) GetConfig() *TerraformStation.Config {
+	return s.cfg
+}
+
+func newTestHTTPServer(svc *stubService) http.Handler {
+	svc.cfg = TerraformStation.DefaultConfig()
+	return NewHTTPServer(svc, svc.cfg).Handler()
+}
+
+func TestHTTPCommand(t *testing.T) {
+	svc := &stubService{}
+	handler := newTestHTTPServer(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/command", strings.NewReader(`{"command":"version","variables":{"region":"us-west-2"}}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "version", svc.last.Command)
+	assert.Equal(t, "us-west-2", svc.last.Variables["region"])
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "tofu_1", body["command_id"])
+	assert.Equal(t, true, body["success"])
+}
+
+func TestHTTPPlan(t *testing.T) {
+	svc := &stubService{}
+	handler := newTestHTTPServer(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/plan", strings.NewReader(`{"working_directory":"/tmp"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"plan_id":"plan_1"`)
+	assert.Equal(t, "/tmp", svc.last.WorkingDirectory)
+}
+
+func TestHTTPSubmitJob(t *testing.T) {
+	svc := &stubService{}
+	handler := newTestHTTPServer(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/jobs", strings.NewReader(`{"type":"apply","input":{"plan_id":"plan_1"}}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"job_id":"job_1"`)
+	assert.Equal(t, "plan_1", svc.last.PlanId)
+}
+
+func TestHTTPListJobs(t *testing.T) {
+	svc := &stubService{}
+	handler := newTestHTTPServer(svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/jobs?status=running&limit=10&offset=20", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "running", svc.lastList.Status)
+	assert.Equal(t, int32(10), svc.lastList.Limit)
+	assert.Equal(t, int32(20), svc.lastList.Offset)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/jobs?limit=many", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHTTPBackendState(t *testing.T) {
+	svc := &stubService{}
+	handler := newTestHTTPServer(svc)
+
+	// A state that does not exist yet is an empty response
+	req := httptest.NewRequest(http.MethodGet, "/v1/tfstate/network/production", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	state := `{"version":4,"serial":1,"lineage":"3f1c2a"}`
+	req = httptest.NewRequest(http.MethodPost, "/v1/tfstate/network/production?ID=a1", strings.NewReader(state))
+	req.Header.Set("Content-MD5", "not-the-checksum")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/tfstate/network/production?ID=a1", strings.NewReader(state))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "a1", svc.lastLockID)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/tfstate/network/production", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, state, rec.Body.String())
+}
+
+func TestHTTPBackendLockConflict(t *testing.T) {
+	svc := &stubService{}
+	handler := newTestHTTPServer(svc)
+
+	req := httptest.NewRequest("LOCK", "/v1/tfstate/production", strings.NewReader(`{"ID":"a1","Operation":"OperationTypeApply","Who":"alice@laptop"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// The current holder is returned so OpenTofu can report who holds the lock
+	req = httptest.NewRequest("LOCK", "/v1/tfstate/production", strings.NewReader(`{"ID":"b2"}`))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusLocked, rec.Code)
+
+	var holder TerraformStation.StateLockInfo
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &holder))
+	assert.Equal(t, "a1", holder.ID)
+	assert.Equal(t, "alice@laptop", holder.Who)
+}
+
+func TestHTTPErrorMapping(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+	}{
+		{TerraformStation.NewInvalidInputError("bad"), http.StatusBadRequest},
+		{TerraformStation.NewWorkingDirError("missing"), http.StatusBadRequest},
+		{TerraformStation.NewPermissionDeniedError("nope"), http.StatusForbidden},
+		{TerraformStation.NewTimeoutError("slow"), http.StatusGatewayTimeout},
+		{TerraformStation.NewTerraformNotFoundError("no tofu"), http.StatusServiceUnavailable},
+		{TerraformStation.NewExecutionFailedError("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		svc := &stubService{err: tc.err}
+		handler := newTestHTTPServer(svc)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/command", strings.NewReader(`{"command":"plan"}`))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.status, rec.Code, tc.err.Error())
+		assert.Contains(t, rec.Body.String(), `"code"`)
+	}
+}
+
+func TestHTTPInvalidBody(t *testing.T) {
+	handler := newTestHTTPServer(&stubService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/command", strings.NewReader(`{"command":`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), TerraformStation.ErrCodeInvalidInput)
+}
+
+func TestHTTPCORS(t *testing.T) {
+	svc := &stubService{}
+	handler := newTestHTTPServer(svc)
+
+	// No origin is allowed until one is configured
+	req := httptest.NewRequest(http.MethodOptions, "/v1/plan", nil)
+	req.Header.Set("Origin", "https://console.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+
+	svc.cfg.Security.AllowedOrigins = []string{"*"}
+	handler = NewHTTPServer(svc, svc.cfg).Handler()
+	req = httptest.NewRequest(http.MethodOptions, "/v1/plan", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST, PUT, DELETE, OPTIONS", rec.Header().Get("Access-Control-Allow-Methods"))
+
+	// Only the configured origins are allowed
+	svc.cfg.Security.AllowedOrigins = []string{"https://console.example.com"}
+	handler = NewHTTPServer(svc, svc.cfg).Handler()
+	req = httptest.NewRequest(http.MethodOptions, "/v1/plan", nil)
+	req.Header.Set("Origin", "https://console.example.com")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "https://console.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+
+	req = httptest.NewRequest(http.MethodOptions, "/v1/plan", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+
+	// CORS headers are omitted when disabled
+	svc.cfg.EnableCORS = false
+	handler = NewHTTPServer(svc, svc.cfg).Handler()
+	req = httptest.NewRequest(http.MethodGet, "/health", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -9,6 +9,7 @@ package TerraformStation
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -31,8 +32,16 @@ type TFCommandInput struct {
 	Arguments        []string               `protobuf:"bytes,4,rep,name=arguments,proto3" json:"arguments,omitempty"`
 	PlanFile         string                 `protobuf:"bytes,5,opt,name=plan_file,json=planFile,proto3" json:"plan_file,omitempty"`
 	StateFile        string                 `protobuf:"bytes,6,opt,name=state_file,json=stateFile,proto3" json:"state_file,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	PlanId           string                 `protobuf:"bytes,7,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	// Workspace the command runs in, passed to OpenTofu as TF_WORKSPACE. Empty uses the selected workspace.
+	Workspace string `protobuf:"bytes,8,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	// Input variables set from stored secrets, mapping variable names to secret names. The values are
+	// passed in a temporary var-file and redacted from the output.
+	SecretVariables map[string]string `protobuf:"bytes,9,rep,name=secret_variables,json=secretVariables,proto3" json:"secret_variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Environment variables set from stored secrets, mapping variable names to secret names
+	SecretEnv     map[string]string `protobuf:"bytes,10,rep,name=secret_env,json=secretEnv,proto3" json:"secret_env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TFCommandInput) Reset() {
@@ -107,15 +116,55 @@ func (x *TFCommandInput) GetStateFile() string {
 	return ""
 }
 
+func (x *TFCommandInput) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *TFCommandInput) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+func (x *TFCommandInput) GetSecretVariables() map[string]string {
+	if x != nil {
+		return x.SecretVariables
+	}
+	return nil
+}
+
+func (x *TFCommandInput) GetSecretEnv() map[string]string {
+	if x != nil {
+		return x.SecretEnv
+	}
+	return nil
+}
+
 // Terraform command result
 type TFCommandResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Result        string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	ExitCode      int32                  `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
-	ExecutedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
-	CommandId     string                 `protobuf:"bytes,6,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Result       string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Success      bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	ExitCode     int32                  `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	ExecutedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	CommandId    string                 `protobuf:"bytes,6,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Stdout       string                 `protobuf:"bytes,7,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr       string                 `protobuf:"bytes,8,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	DurationMs   int64                  `protobuf:"varint,9,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Signal that terminated the process, if any
+	Signal   string `protobuf:"bytes,10,opt,name=signal,proto3" json:"signal,omitempty"`
+	TimedOut bool   `protobuf:"varint,11,opt,name=timed_out,json=timedOut,proto3" json:"timed_out,omitempty"`
+	// Errors and warnings reported by commands run with -json
+	Diagnostics  []*TFDiagnostic `protobuf:"bytes,12,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	ErrorCount   int32           `protobuf:"varint,13,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	WarningCount int32           `protobuf:"varint,14,opt,name=warning_count,json=warningCount,proto3" json:"warning_count,omitempty"`
+	// Set if the command was cancelled while it ran
+	Cancelled     bool `protobuf:"varint,15,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -192,119 +241,146 @@ func (x *TFCommandResult) GetCommandId() string {
 	return ""
 }
 
-// Terraform plan result
-type TFPlanResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlanId        string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
-	PlanOutput    string                 `protobuf:"bytes,2,opt,name=plan_output,json=planOutput,proto3" json:"plan_output,omitempty"`
-	HasChanges    bool                   `protobuf:"varint,3,opt,name=has_changes,json=hasChanges,proto3" json:"has_changes,omitempty"`
-	ResourceCount int32                  `protobuf:"varint,4,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *TFCommandResult) GetStdout() string {
+	if x != nil {
+		return x.Stdout
+	}
+	return ""
 }
 
-func (x *TFPlanResult) Reset() {
-	*x = TFPlanResult{}
-	mi := &file_spec_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *TFCommandResult) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
 }
 
-func (x *TFPlanResult) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TFCommandResult) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
 }
 
-func (*TFPlanResult) ProtoMessage() {}
-
-func (x *TFPlanResult) ProtoReflect() protoreflect.Message {
-	mi := &file_spec_proto_msgTypes[2]
+func (x *TFCommandResult) GetSignal() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Signal
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use TFPlanResult.ProtoReflect.Descriptor instead.
-func (*TFPlanResult) Descriptor() ([]byte, []int) {
-	return file_spec_proto_rawDescGZIP(), []int{2}
+func (x *TFCommandResult) GetTimedOut() bool {
+	if x != nil {
+		return x.TimedOut
+	}
+	return false
 }
 
-func (x *TFPlanResult) GetPlanId() string {
+func (x *TFCommandResult) GetDiagnostics() []*TFDiagnostic {
 	if x != nil {
-		return x.PlanId
+		return x.Diagnostics
 	}
-	return ""
+	return nil
 }
 
-func (x *TFPlanResult) GetPlanOutput() string {
+func (x *TFCommandResult) GetErrorCount() int32 {
 	if x != nil {
-		return x.PlanOutput
+		return x.ErrorCount
 	}
-	return ""
+	return 0
 }
 
-func (x *TFPlanResult) GetHasChanges() bool {
+func (x *TFCommandResult) GetWarningCount() int32 {
 	if x != nil {
-		return x.HasChanges
+		return x.WarningCount
 	}
-	return false
+	return 0
 }
 
-func (x *TFPlanResult) GetResourceCount() int32 {
+func (x *TFCommandResult) GetCancelled() bool {
 	if x != nil {
-		return x.ResourceCount
+		return x.Cancelled
 	}
-	return 0
+	return false
 }
 
-func (x *TFPlanResult) GetCreatedAt() *timestamppb.Timestamp {
+// Identifies an operation by the command ID of its run
+type TFOperationInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommandId     string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFOperationInput) Reset() {
+	*x = TFOperationInput{}
+	mi := &file_spec_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFOperationInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFOperationInput) ProtoMessage() {}
+
+func (x *TFOperationInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[2]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *TFPlanResult) GetStatus() string {
+// Deprecated: Use TFOperationInput.ProtoReflect.Descriptor instead.
+func (*TFOperationInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TFOperationInput) GetCommandId() string {
 	if x != nil {
-		return x.Status
+		return x.CommandId
 	}
 	return ""
 }
 
-// Terraform apply result
-type TFApplyResult struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	ApplyId            string                 `protobuf:"bytes,1,opt,name=apply_id,json=applyId,proto3" json:"apply_id,omitempty"`
-	ApplyOutput        string                 `protobuf:"bytes,2,opt,name=apply_output,json=applyOutput,proto3" json:"apply_output,omitempty"`
-	Success            bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
-	ResourcesAdded     int32                  `protobuf:"varint,4,opt,name=resources_added,json=resourcesAdded,proto3" json:"resources_added,omitempty"`
-	ResourcesChanged   int32                  `protobuf:"varint,5,opt,name=resources_changed,json=resourcesChanged,proto3" json:"resources_changed,omitempty"`
-	ResourcesDestroyed int32                  `protobuf:"varint,6,opt,name=resources_destroyed,json=resourcesDestroyed,proto3" json:"resources_destroyed,omitempty"`
-	ExecutedAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+// A recorded run of an OpenTofu command
+type TFOperation struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CommandId        string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Command          string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	WorkingDirectory string                 `protobuf:"bytes,3,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	Workspace        string                 `protobuf:"bytes,4,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	// One of pending, running, succeeded, failed or cancelled
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Signal        string                 `protobuf:"bytes,7,opt,name=signal,proto3" json:"signal,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TFApplyResult) Reset() {
-	*x = TFApplyResult{}
+func (x *TFOperation) Reset() {
+	*x = TFOperation{}
 	mi := &file_spec_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TFApplyResult) String() string {
+func (x *TFOperation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TFApplyResult) ProtoMessage() {}
+func (*TFOperation) ProtoMessage() {}
 
-func (x *TFApplyResult) ProtoReflect() protoreflect.Message {
+func (x *TFOperation) ProtoReflect() protoreflect.Message {
 	mi := &file_spec_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -316,86 +392,105 @@ func (x *TFApplyResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TFApplyResult.ProtoReflect.Descriptor instead.
-func (*TFApplyResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use TFOperation.ProtoReflect.Descriptor instead.
+func (*TFOperation) Descriptor() ([]byte, []int) {
 	return file_spec_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *TFApplyResult) GetApplyId() string {
+func (x *TFOperation) GetCommandId() string {
 	if x != nil {
-		return x.ApplyId
+		return x.CommandId
 	}
 	return ""
 }
 
-func (x *TFApplyResult) GetApplyOutput() string {
+func (x *TFOperation) GetCommand() string {
 	if x != nil {
-		return x.ApplyOutput
+		return x.Command
 	}
 	return ""
 }
 
-func (x *TFApplyResult) GetSuccess() bool {
+func (x *TFOperation) GetWorkingDirectory() string {
 	if x != nil {
-		return x.Success
+		return x.WorkingDirectory
 	}
-	return false
+	return ""
 }
 
-func (x *TFApplyResult) GetResourcesAdded() int32 {
+func (x *TFOperation) GetWorkspace() string {
 	if x != nil {
-		return x.ResourcesAdded
+		return x.Workspace
 	}
-	return 0
+	return ""
 }
 
-func (x *TFApplyResult) GetResourcesChanged() int32 {
+func (x *TFOperation) GetStatus() string {
 	if x != nil {
-		return x.ResourcesChanged
+		return x.Status
 	}
-	return 0
+	return ""
 }
 
-func (x *TFApplyResult) GetResourcesDestroyed() int32 {
+func (x *TFOperation) GetExitCode() int32 {
 	if x != nil {
-		return x.ResourcesDestroyed
+		return x.ExitCode
 	}
 	return 0
 }
 
-func (x *TFApplyResult) GetExecutedAt() *timestamppb.Timestamp {
+func (x *TFOperation) GetSignal() string {
 	if x != nil {
-		return x.ExecutedAt
+		return x.Signal
+	}
+	return ""
+}
+
+func (x *TFOperation) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *TFOperation) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
 	}
 	return nil
 }
 
-// Terraform state information
-type TFStateInfo struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	StateId          string                 `protobuf:"bytes,1,opt,name=state_id,json=stateId,proto3" json:"state_id,omitempty"`
-	StateFile        string                 `protobuf:"bytes,2,opt,name=state_file,json=stateFile,proto3" json:"state_file,omitempty"`
-	ResourceCount    int32                  `protobuf:"varint,3,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
-	LastUpdated      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
-	TerraformVersion string                 `protobuf:"bytes,5,opt,name=terraform_version,json=terraformVersion,proto3" json:"terraform_version,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *TFOperation) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
 }
 
-func (x *TFStateInfo) Reset() {
-	*x = TFStateInfo{}
+// A position in a configuration file
+type TFSourcePos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Column        int32                  `protobuf:"varint,2,opt,name=column,proto3" json:"column,omitempty"`
+	Byte          int32                  `protobuf:"varint,3,opt,name=byte,proto3" json:"byte,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFSourcePos) Reset() {
+	*x = TFSourcePos{}
 	mi := &file_spec_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TFStateInfo) String() string {
+func (x *TFSourcePos) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TFStateInfo) ProtoMessage() {}
+func (*TFSourcePos) ProtoMessage() {}
 
-func (x *TFStateInfo) ProtoReflect() protoreflect.Message {
+func (x *TFSourcePos) ProtoReflect() protoreflect.Message {
 	mi := &file_spec_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -407,74 +502,4105 @@ func (x *TFStateInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TFStateInfo.ProtoReflect.Descriptor instead.
-func (*TFStateInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use TFSourcePos.ProtoReflect.Descriptor instead.
+func (*TFSourcePos) Descriptor() ([]byte, []int) {
 	return file_spec_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *TFStateInfo) GetStateId() string {
+func (x *TFSourcePos) GetLine() int32 {
 	if x != nil {
-		return x.StateId
+		return x.Line
 	}
-	return ""
+	return 0
 }
 
-func (x *TFStateInfo) GetStateFile() string {
+func (x *TFSourcePos) GetColumn() int32 {
 	if x != nil {
-		return x.StateFile
+		return x.Column
 	}
-	return ""
+	return 0
 }
 
-func (x *TFStateInfo) GetResourceCount() int32 {
+func (x *TFSourcePos) GetByte() int32 {
 	if x != nil {
-		return x.ResourceCount
+		return x.Byte
 	}
 	return 0
 }
 
-func (x *TFStateInfo) GetLastUpdated() *timestamppb.Timestamp {
+// The part of a configuration file a diagnostic refers to
+type TFSourceRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Start         *TFSourcePos           `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           *TFSourcePos           `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFSourceRange) Reset() {
+	*x = TFSourceRange{}
+	mi := &file_spec_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFSourceRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFSourceRange) ProtoMessage() {}
+
+func (x *TFSourceRange) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[5]
 	if x != nil {
-		return x.LastUpdated
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *TFStateInfo) GetTerraformVersion() string {
+// Deprecated: Use TFSourceRange.ProtoReflect.Descriptor instead.
+func (*TFSourceRange) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TFSourceRange) GetFilename() string {
 	if x != nil {
-		return x.TerraformVersion
+		return x.Filename
 	}
 	return ""
 }
 
-var File_spec_proto protoreflect.FileDescriptor
+func (x *TFSourceRange) GetStart() *TFSourcePos {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
 
-const file_spec_proto_rawDesc = "" +
-	"\n" +
-	"\n" +
-	"spec.proto\x12\x10TerraformStation\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbe\x02\n" +
-	"\x0eTFCommandInput\x12\x18\n" +
-	"\acommand\x18\x01 \x01(\tR\acommand\x12+\n" +
-	"\x11working_directory\x18\x02 \x01(\tR\x10workingDirectory\x12M\n" +
-	"\tvariables\x18\x03 \x03(\v2/.TerraformStation.TFCommandInput.VariablesEntryR\tvariables\x12\x1c\n" +
-	"\targuments\x18\x04 \x03(\tR\targuments\x12\x1b\n" +
-	"\tplan_file\x18\x05 \x01(\tR\bplanFile\x12\x1d\n" +
-	"\n" +
-	"state_file\x18\x06 \x01(\tR\tstateFile\x1a<\n" +
-	"\x0eVariablesEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe1\x01\n" +
-	"\x0fTFCommandResult\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\tR\x06result\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x12\x1b\n" +
-	"\texit_code\x18\x04 \x01(\x05R\bexitCode\x12;\n" +
-	"\vexecuted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"executedAt\x12\x1d\n" +
-	"\n" +
-	"command_id\x18\x06 \x01(\tR\tcommandId\"\xe3\x01\n" +
-	"\fTFPlanResult\x12\x17\n" +
-	"\aplan_id\x18\x01 \x01(\tR\x06planId\x12\x1f\n" +
+func (x *TFSourceRange) GetEnd() *TFSourcePos {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+// The value of an expression involved in a diagnostic
+type TFExpressionValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Traversal     string                 `protobuf:"bytes,1,opt,name=traversal,proto3" json:"traversal,omitempty"`
+	Statement     string                 `protobuf:"bytes,2,opt,name=statement,proto3" json:"statement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFExpressionValue) Reset() {
+	*x = TFExpressionValue{}
+	mi := &file_spec_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFExpressionValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFExpressionValue) ProtoMessage() {}
+
+func (x *TFExpressionValue) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFExpressionValue.ProtoReflect.Descriptor instead.
+func (*TFExpressionValue) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TFExpressionValue) GetTraversal() string {
+	if x != nil {
+		return x.Traversal
+	}
+	return ""
+}
+
+func (x *TFExpressionValue) GetStatement() string {
+	if x != nil {
+		return x.Statement
+	}
+	return ""
+}
+
+// The source code a diagnostic refers to
+type TFDiagnosticSnippet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the block containing the code, such as resource "aws_instance" "web"
+	Context   string `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	Code      string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	StartLine int32  `protobuf:"varint,3,opt,name=start_line,json=startLine,proto3" json:"start_line,omitempty"`
+	// Byte offsets of the highlighted part of code
+	HighlightStartOffset int32                `protobuf:"varint,4,opt,name=highlight_start_offset,json=highlightStartOffset,proto3" json:"highlight_start_offset,omitempty"`
+	HighlightEndOffset   int32                `protobuf:"varint,5,opt,name=highlight_end_offset,json=highlightEndOffset,proto3" json:"highlight_end_offset,omitempty"`
+	Values               []*TFExpressionValue `protobuf:"bytes,6,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TFDiagnosticSnippet) Reset() {
+	*x = TFDiagnosticSnippet{}
+	mi := &file_spec_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFDiagnosticSnippet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFDiagnosticSnippet) ProtoMessage() {}
+
+func (x *TFDiagnosticSnippet) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFDiagnosticSnippet.ProtoReflect.Descriptor instead.
+func (*TFDiagnosticSnippet) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TFDiagnosticSnippet) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+func (x *TFDiagnosticSnippet) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *TFDiagnosticSnippet) GetStartLine() int32 {
+	if x != nil {
+		return x.StartLine
+	}
+	return 0
+}
+
+func (x *TFDiagnosticSnippet) GetHighlightStartOffset() int32 {
+	if x != nil {
+		return x.HighlightStartOffset
+	}
+	return 0
+}
+
+func (x *TFDiagnosticSnippet) GetHighlightEndOffset() int32 {
+	if x != nil {
+		return x.HighlightEndOffset
+	}
+	return 0
+}
+
+func (x *TFDiagnosticSnippet) GetValues() []*TFExpressionValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// An error or warning reported by OpenTofu
+type TFDiagnostic struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// error or warning
+	Severity string `protobuf:"bytes,1,opt,name=severity,proto3" json:"severity,omitempty"`
+	Summary  string `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	Detail   string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	// Address of the resource the diagnostic concerns, if any
+	Address       string               `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Range         *TFSourceRange       `protobuf:"bytes,5,opt,name=range,proto3" json:"range,omitempty"`
+	Snippet       *TFDiagnosticSnippet `protobuf:"bytes,6,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFDiagnostic) Reset() {
+	*x = TFDiagnostic{}
+	mi := &file_spec_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFDiagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFDiagnostic) ProtoMessage() {}
+
+func (x *TFDiagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFDiagnostic.ProtoReflect.Descriptor instead.
+func (*TFDiagnostic) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TFDiagnostic) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *TFDiagnostic) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *TFDiagnostic) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *TFDiagnostic) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TFDiagnostic) GetRange() *TFSourceRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *TFDiagnostic) GetSnippet() *TFDiagnosticSnippet {
+	if x != nil {
+		return x.Snippet
+	}
+	return nil
+}
+
+// A planned change to a single resource
+type TFResourceChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	ModuleAddress string                 `protobuf:"bytes,2,opt,name=module_address,json=moduleAddress,proto3" json:"module_address,omitempty"`
+	Mode          string                 `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	ProviderName  string                 `protobuf:"bytes,6,opt,name=provider_name,json=providerName,proto3" json:"provider_name,omitempty"`
+	// One of create, update, delete, replace, read or no-op
+	Action string          `protobuf:"bytes,7,opt,name=action,proto3" json:"action,omitempty"`
+	Before *structpb.Value `protobuf:"bytes,8,opt,name=before,proto3" json:"before,omitempty"`
+	After  *structpb.Value `protobuf:"bytes,9,opt,name=after,proto3" json:"after,omitempty"`
+	// Attribute paths that force the resource to be replaced
+	ReplacePaths  []*structpb.ListValue `protobuf:"bytes,10,rep,name=replace_paths,json=replacePaths,proto3" json:"replace_paths,omitempty"`
+	ActionReason  string                `protobuf:"bytes,11,opt,name=action_reason,json=actionReason,proto3" json:"action_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFResourceChange) Reset() {
+	*x = TFResourceChange{}
+	mi := &file_spec_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFResourceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFResourceChange) ProtoMessage() {}
+
+func (x *TFResourceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFResourceChange.ProtoReflect.Descriptor instead.
+func (*TFResourceChange) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TFResourceChange) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TFResourceChange) GetModuleAddress() string {
+	if x != nil {
+		return x.ModuleAddress
+	}
+	return ""
+}
+
+func (x *TFResourceChange) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *TFResourceChange) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TFResourceChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFResourceChange) GetProviderName() string {
+	if x != nil {
+		return x.ProviderName
+	}
+	return ""
+}
+
+func (x *TFResourceChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TFResourceChange) GetBefore() *structpb.Value {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *TFResourceChange) GetAfter() *structpb.Value {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *TFResourceChange) GetReplacePaths() []*structpb.ListValue {
+	if x != nil {
+		return x.ReplacePaths
+	}
+	return nil
+}
+
+func (x *TFResourceChange) GetActionReason() string {
+	if x != nil {
+		return x.ActionReason
+	}
+	return ""
+}
+
+// Terraform plan result
+type TFPlanResult struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PlanId            string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	PlanOutput        string                 `protobuf:"bytes,2,opt,name=plan_output,json=planOutput,proto3" json:"plan_output,omitempty"`
+	HasChanges        bool                   `protobuf:"varint,3,opt,name=has_changes,json=hasChanges,proto3" json:"has_changes,omitempty"`
+	ResourceCount     int32                  `protobuf:"varint,4,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Status            string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	ResourceChanges   []*TFResourceChange    `protobuf:"bytes,7,rep,name=resource_changes,json=resourceChanges,proto3" json:"resource_changes,omitempty"`
+	ToAdd             int32                  `protobuf:"varint,8,opt,name=to_add,json=toAdd,proto3" json:"to_add,omitempty"`
+	ToChange          int32                  `protobuf:"varint,9,opt,name=to_change,json=toChange,proto3" json:"to_change,omitempty"`
+	ToDestroy         int32                  `protobuf:"varint,10,opt,name=to_destroy,json=toDestroy,proto3" json:"to_destroy,omitempty"`
+	ToReplace         int32                  `protobuf:"varint,11,opt,name=to_replace,json=toReplace,proto3" json:"to_replace,omitempty"`
+	ErrorMessage      string                 `protobuf:"bytes,12,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	RequiredApprovals int32                  `protobuf:"varint,13,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`
+	ApprovalExpiresAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=approval_expires_at,json=approvalExpiresAt,proto3" json:"approval_expires_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TFPlanResult) Reset() {
+	*x = TFPlanResult{}
+	mi := &file_spec_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFPlanResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFPlanResult) ProtoMessage() {}
+
+func (x *TFPlanResult) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFPlanResult.ProtoReflect.Descriptor instead.
+func (*TFPlanResult) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TFPlanResult) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *TFPlanResult) GetPlanOutput() string {
+	if x != nil {
+		return x.PlanOutput
+	}
+	return ""
+}
+
+func (x *TFPlanResult) GetHasChanges() bool {
+	if x != nil {
+		return x.HasChanges
+	}
+	return false
+}
+
+func (x *TFPlanResult) GetResourceCount() int32 {
+	if x != nil {
+		return x.ResourceCount
+	}
+	return 0
+}
+
+func (x *TFPlanResult) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TFPlanResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TFPlanResult) GetResourceChanges() []*TFResourceChange {
+	if x != nil {
+		return x.ResourceChanges
+	}
+	return nil
+}
+
+func (x *TFPlanResult) GetToAdd() int32 {
+	if x != nil {
+		return x.ToAdd
+	}
+	return 0
+}
+
+func (x *TFPlanResult) GetToChange() int32 {
+	if x != nil {
+		return x.ToChange
+	}
+	return 0
+}
+
+func (x *TFPlanResult) GetToDestroy() int32 {
+	if x != nil {
+		return x.ToDestroy
+	}
+	return 0
+}
+
+func (x *TFPlanResult) GetToReplace() int32 {
+	if x != nil {
+		return x.ToReplace
+	}
+	return 0
+}
+
+func (x *TFPlanResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *TFPlanResult) GetRequiredApprovals() int32 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+func (x *TFPlanResult) GetApprovalExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ApprovalExpiresAt
+	}
+	return nil
+}
+
+// Outcome of applying a change to a single resource
+type TFResourceApply struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Address        string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Action         string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Success        bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	ElapsedSeconds float64                `protobuf:"fixed64,4,opt,name=elapsed_seconds,json=elapsedSeconds,proto3" json:"elapsed_seconds,omitempty"`
+	IdValue        string                 `protobuf:"bytes,5,opt,name=id_value,json=idValue,proto3" json:"id_value,omitempty"`
+	ErrorMessage   string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TFResourceApply) Reset() {
+	*x = TFResourceApply{}
+	mi := &file_spec_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFResourceApply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFResourceApply) ProtoMessage() {}
+
+func (x *TFResourceApply) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFResourceApply.ProtoReflect.Descriptor instead.
+func (*TFResourceApply) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TFResourceApply) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TFResourceApply) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TFResourceApply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TFResourceApply) GetElapsedSeconds() float64 {
+	if x != nil {
+		return x.ElapsedSeconds
+	}
+	return 0
+}
+
+func (x *TFResourceApply) GetIdValue() string {
+	if x != nil {
+		return x.IdValue
+	}
+	return ""
+}
+
+func (x *TFResourceApply) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// Terraform apply result
+type TFApplyResult struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ApplyId            string                 `protobuf:"bytes,1,opt,name=apply_id,json=applyId,proto3" json:"apply_id,omitempty"`
+	ApplyOutput        string                 `protobuf:"bytes,2,opt,name=apply_output,json=applyOutput,proto3" json:"apply_output,omitempty"`
+	Success            bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	ResourcesAdded     int32                  `protobuf:"varint,4,opt,name=resources_added,json=resourcesAdded,proto3" json:"resources_added,omitempty"`
+	ResourcesChanged   int32                  `protobuf:"varint,5,opt,name=resources_changed,json=resourcesChanged,proto3" json:"resources_changed,omitempty"`
+	ResourcesDestroyed int32                  `protobuf:"varint,6,opt,name=resources_destroyed,json=resourcesDestroyed,proto3" json:"resources_destroyed,omitempty"`
+	ExecutedAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	ResourcesFailed    int32                  `protobuf:"varint,8,opt,name=resources_failed,json=resourcesFailed,proto3" json:"resources_failed,omitempty"`
+	ResourceApplies    []*TFResourceApply     `protobuf:"bytes,9,rep,name=resource_applies,json=resourceApplies,proto3" json:"resource_applies,omitempty"`
+	PlanId             string                 `protobuf:"bytes,10,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *TFApplyResult) Reset() {
+	*x = TFApplyResult{}
+	mi := &file_spec_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFApplyResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFApplyResult) ProtoMessage() {}
+
+func (x *TFApplyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFApplyResult.ProtoReflect.Descriptor instead.
+func (*TFApplyResult) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TFApplyResult) GetApplyId() string {
+	if x != nil {
+		return x.ApplyId
+	}
+	return ""
+}
+
+func (x *TFApplyResult) GetApplyOutput() string {
+	if x != nil {
+		return x.ApplyOutput
+	}
+	return ""
+}
+
+func (x *TFApplyResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TFApplyResult) GetResourcesAdded() int32 {
+	if x != nil {
+		return x.ResourcesAdded
+	}
+	return 0
+}
+
+func (x *TFApplyResult) GetResourcesChanged() int32 {
+	if x != nil {
+		return x.ResourcesChanged
+	}
+	return 0
+}
+
+func (x *TFApplyResult) GetResourcesDestroyed() int32 {
+	if x != nil {
+		return x.ResourcesDestroyed
+	}
+	return 0
+}
+
+func (x *TFApplyResult) GetExecutedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return nil
+}
+
+func (x *TFApplyResult) GetResourcesFailed() int32 {
+	if x != nil {
+		return x.ResourcesFailed
+	}
+	return 0
+}
+
+func (x *TFApplyResult) GetResourceApplies() []*TFResourceApply {
+	if x != nil {
+		return x.ResourceApplies
+	}
+	return nil
+}
+
+func (x *TFApplyResult) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+// A resource instance recorded in state
+type TFStateResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	ModuleAddress string                 `protobuf:"bytes,2,opt,name=module_address,json=moduleAddress,proto3" json:"module_address,omitempty"`
+	Mode          string                 `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// Index of the instance for resources using count or for_each
+	Index         *structpb.Value `protobuf:"bytes,6,opt,name=index,proto3" json:"index,omitempty"`
+	ProviderName  string          `protobuf:"bytes,7,opt,name=provider_name,json=providerName,proto3" json:"provider_name,omitempty"`
+	SchemaVersion int32           `protobuf:"varint,8,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// Attribute values, with sensitive ones masked
+	Values *structpb.Value `protobuf:"bytes,9,opt,name=values,proto3" json:"values,omitempty"`
+	// Mirrors values, with true marking sensitive attributes
+	SensitiveValues *structpb.Value `protobuf:"bytes,10,opt,name=sensitive_values,json=sensitiveValues,proto3" json:"sensitive_values,omitempty"`
+	DependsOn       []string        `protobuf:"bytes,11,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	Tainted         bool            `protobuf:"varint,12,opt,name=tainted,proto3" json:"tainted,omitempty"`
+	DeposedKey      string          `protobuf:"bytes,13,opt,name=deposed_key,json=deposedKey,proto3" json:"deposed_key,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TFStateResource) Reset() {
+	*x = TFStateResource{}
+	mi := &file_spec_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFStateResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFStateResource) ProtoMessage() {}
+
+func (x *TFStateResource) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFStateResource.ProtoReflect.Descriptor instead.
+func (*TFStateResource) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TFStateResource) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TFStateResource) GetModuleAddress() string {
+	if x != nil {
+		return x.ModuleAddress
+	}
+	return ""
+}
+
+func (x *TFStateResource) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *TFStateResource) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TFStateResource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFStateResource) GetIndex() *structpb.Value {
+	if x != nil {
+		return x.Index
+	}
+	return nil
+}
+
+func (x *TFStateResource) GetProviderName() string {
+	if x != nil {
+		return x.ProviderName
+	}
+	return ""
+}
+
+func (x *TFStateResource) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *TFStateResource) GetValues() *structpb.Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *TFStateResource) GetSensitiveValues() *structpb.Value {
+	if x != nil {
+		return x.SensitiveValues
+	}
+	return nil
+}
+
+func (x *TFStateResource) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *TFStateResource) GetTainted() bool {
+	if x != nil {
+		return x.Tainted
+	}
+	return false
+}
+
+func (x *TFStateResource) GetDeposedKey() string {
+	if x != nil {
+		return x.DeposedKey
+	}
+	return ""
+}
+
+// A root module output recorded in state
+type TFStateOutput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The value, masked if the output is sensitive
+	Value         *structpb.Value `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Type          *structpb.Value `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Sensitive     bool            `protobuf:"varint,4,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFStateOutput) Reset() {
+	*x = TFStateOutput{}
+	mi := &file_spec_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFStateOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFStateOutput) ProtoMessage() {}
+
+func (x *TFStateOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFStateOutput.ProtoReflect.Descriptor instead.
+func (*TFStateOutput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TFStateOutput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFStateOutput) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *TFStateOutput) GetType() *structpb.Value {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *TFStateOutput) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+// Terraform state information
+type TFStateInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StateId   string                 `protobuf:"bytes,1,opt,name=state_id,json=stateId,proto3" json:"state_id,omitempty"`
+	StateFile string                 `protobuf:"bytes,2,opt,name=state_file,json=stateFile,proto3" json:"state_file,omitempty"`
+	// Number of resource instances across all modules
+	ResourceCount    int32                  `protobuf:"varint,3,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
+	LastUpdated      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	TerraformVersion string                 `protobuf:"bytes,5,opt,name=terraform_version,json=terraformVersion,proto3" json:"terraform_version,omitempty"`
+	Serial           int64                  `protobuf:"varint,6,opt,name=serial,proto3" json:"serial,omitempty"`
+	Lineage          string                 `protobuf:"bytes,7,opt,name=lineage,proto3" json:"lineage,omitempty"`
+	FormatVersion    string                 `protobuf:"bytes,8,opt,name=format_version,json=formatVersion,proto3" json:"format_version,omitempty"`
+	Resources        []*TFStateResource     `protobuf:"bytes,9,rep,name=resources,proto3" json:"resources,omitempty"`
+	Outputs          []*TFStateOutput       `protobuf:"bytes,10,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	CommandId        string                 `protobuf:"bytes,11,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TFStateInfo) Reset() {
+	*x = TFStateInfo{}
+	mi := &file_spec_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFStateInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFStateInfo) ProtoMessage() {}
+
+func (x *TFStateInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFStateInfo.ProtoReflect.Descriptor instead.
+func (*TFStateInfo) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TFStateInfo) GetStateId() string {
+	if x != nil {
+		return x.StateId
+	}
+	return ""
+}
+
+func (x *TFStateInfo) GetStateFile() string {
+	if x != nil {
+		return x.StateFile
+	}
+	return ""
+}
+
+func (x *TFStateInfo) GetResourceCount() int32 {
+	if x != nil {
+		return x.ResourceCount
+	}
+	return 0
+}
+
+func (x *TFStateInfo) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *TFStateInfo) GetTerraformVersion() string {
+	if x != nil {
+		return x.TerraformVersion
+	}
+	return ""
+}
+
+func (x *TFStateInfo) GetSerial() int64 {
+	if x != nil {
+		return x.Serial
+	}
+	return 0
+}
+
+func (x *TFStateInfo) GetLineage() string {
+	if x != nil {
+		return x.Lineage
+	}
+	return ""
+}
+
+func (x *TFStateInfo) GetFormatVersion() string {
+	if x != nil {
+		return x.FormatVersion
+	}
+	return ""
+}
+
+func (x *TFStateInfo) GetResources() []*TFStateResource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *TFStateInfo) GetOutputs() []*TFStateOutput {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *TFStateInfo) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+// A single line of output produced by a running command
+type TFOutputChunk struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CommandId string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Sequence  int64                  `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Stream    string                 `protobuf:"bytes,3,opt,name=stream,proto3" json:"stream,omitempty"`
+	Line      string                 `protobuf:"bytes,4,opt,name=line,proto3" json:"line,omitempty"`
+	EmittedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=emitted_at,json=emittedAt,proto3" json:"emitted_at,omitempty"`
+	// Set on the final message of a stream once the command has finished
+	Result        *TFCommandResult `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFOutputChunk) Reset() {
+	*x = TFOutputChunk{}
+	mi := &file_spec_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFOutputChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFOutputChunk) ProtoMessage() {}
+
+func (x *TFOutputChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFOutputChunk.ProtoReflect.Descriptor instead.
+func (*TFOutputChunk) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TFOutputChunk) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *TFOutputChunk) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *TFOutputChunk) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *TFOutputChunk) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *TFOutputChunk) GetEmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EmittedAt
+	}
+	return nil
+}
+
+func (x *TFOutputChunk) GetResult() *TFCommandResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Resource counts reported at the end of a plan or apply
+type TFChangeSummary struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Add    int32                  `protobuf:"varint,1,opt,name=add,proto3" json:"add,omitempty"`
+	Change int32                  `protobuf:"varint,2,opt,name=change,proto3" json:"change,omitempty"`
+	Import int32                  `protobuf:"varint,3,opt,name=import,proto3" json:"import,omitempty"`
+	Remove int32                  `protobuf:"varint,4,opt,name=remove,proto3" json:"remove,omitempty"`
+	// plan or apply
+	Operation     string `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFChangeSummary) Reset() {
+	*x = TFChangeSummary{}
+	mi := &file_spec_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFChangeSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFChangeSummary) ProtoMessage() {}
+
+func (x *TFChangeSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFChangeSummary.ProtoReflect.Descriptor instead.
+func (*TFChangeSummary) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TFChangeSummary) GetAdd() int32 {
+	if x != nil {
+		return x.Add
+	}
+	return 0
+}
+
+func (x *TFChangeSummary) GetChange() int32 {
+	if x != nil {
+		return x.Change
+	}
+	return 0
+}
+
+func (x *TFChangeSummary) GetImport() int32 {
+	if x != nil {
+		return x.Import
+	}
+	return 0
+}
+
+func (x *TFChangeSummary) GetRemove() int32 {
+	if x != nil {
+		return x.Remove
+	}
+	return 0
+}
+
+func (x *TFChangeSummary) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+// A message of the machine-readable UI of a command run with -json, with the progress it represents
+type TFProgressEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CommandId string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Sequence  int64                  `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// planned_change, apply_start, apply_progress, apply_complete, apply_errored, diagnostic or change_summary
+	Type    string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Level   string `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// Resource the event refers to and the action taken on it
+	Address string `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
+	Action  string `protobuf:"bytes,7,opt,name=action,proto3" json:"action,omitempty"`
+	// Seconds the resource has been applying for
+	ElapsedSeconds float64 `protobuf:"fixed64,8,opt,name=elapsed_seconds,json=elapsedSeconds,proto3" json:"elapsed_seconds,omitempty"`
+	// The resource is number resource_index of the resource_total planned changes. The total is 0 while unknown.
+	ResourceIndex int32                  `protobuf:"varint,9,opt,name=resource_index,json=resourceIndex,proto3" json:"resource_index,omitempty"`
+	ResourceTotal int32                  `protobuf:"varint,10,opt,name=resource_total,json=resourceTotal,proto3" json:"resource_total,omitempty"`
+	Changes       *TFChangeSummary       `protobuf:"bytes,11,opt,name=changes,proto3" json:"changes,omitempty"`
+	Diagnostic    *TFDiagnostic          `protobuf:"bytes,12,opt,name=diagnostic,proto3" json:"diagnostic,omitempty"`
+	EmittedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=emitted_at,json=emittedAt,proto3" json:"emitted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFProgressEvent) Reset() {
+	*x = TFProgressEvent{}
+	mi := &file_spec_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFProgressEvent) ProtoMessage() {}
+
+func (x *TFProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFProgressEvent.ProtoReflect.Descriptor instead.
+func (*TFProgressEvent) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TFProgressEvent) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *TFProgressEvent) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *TFProgressEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TFProgressEvent) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *TFProgressEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TFProgressEvent) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TFProgressEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TFProgressEvent) GetElapsedSeconds() float64 {
+	if x != nil {
+		return x.ElapsedSeconds
+	}
+	return 0
+}
+
+func (x *TFProgressEvent) GetResourceIndex() int32 {
+	if x != nil {
+		return x.ResourceIndex
+	}
+	return 0
+}
+
+func (x *TFProgressEvent) GetResourceTotal() int32 {
+	if x != nil {
+		return x.ResourceTotal
+	}
+	return 0
+}
+
+func (x *TFProgressEvent) GetChanges() *TFChangeSummary {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *TFProgressEvent) GetDiagnostic() *TFDiagnostic {
+	if x != nil {
+		return x.Diagnostic
+	}
+	return nil
+}
+
+func (x *TFProgressEvent) GetEmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EmittedAt
+	}
+	return nil
+}
+
+// Subscription to the output of a command
+type TFSubscribeInput struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CommandId string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	// Only chunks with a greater sequence number are replayed
+	AfterSequence int64 `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFSubscribeInput) Reset() {
+	*x = TFSubscribeInput{}
+	mi := &file_spec_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFSubscribeInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFSubscribeInput) ProtoMessage() {}
+
+func (x *TFSubscribeInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFSubscribeInput.ProtoReflect.Descriptor instead.
+func (*TFSubscribeInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TFSubscribeInput) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *TFSubscribeInput) GetAfterSequence() int64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+// Approval or rejection of a plan by a reviewer
+type TFPlanReviewInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlanId        string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	Approver      string                 `protobuf:"bytes,2,opt,name=approver,proto3" json:"approver,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFPlanReviewInput) Reset() {
+	*x = TFPlanReviewInput{}
+	mi := &file_spec_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFPlanReviewInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFPlanReviewInput) ProtoMessage() {}
+
+func (x *TFPlanReviewInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFPlanReviewInput.ProtoReflect.Descriptor instead.
+func (*TFPlanReviewInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TFPlanReviewInput) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *TFPlanReviewInput) GetApprover() string {
+	if x != nil {
+		return x.Approver
+	}
+	return ""
+}
+
+func (x *TFPlanReviewInput) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// Selects the plan whose approval status is requested
+type TFPlanApprovalInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlanId        string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFPlanApprovalInput) Reset() {
+	*x = TFPlanApprovalInput{}
+	mi := &file_spec_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFPlanApprovalInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFPlanApprovalInput) ProtoMessage() {}
+
+func (x *TFPlanApprovalInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFPlanApprovalInput.ProtoReflect.Descriptor instead.
+func (*TFPlanApprovalInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TFPlanApprovalInput) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+// A single review decision on a plan
+type TFPlanApproval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approver      string                 `protobuf:"bytes,1,opt,name=approver,proto3" json:"approver,omitempty"`
+	Decision      string                 `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFPlanApproval) Reset() {
+	*x = TFPlanApproval{}
+	mi := &file_spec_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFPlanApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFPlanApproval) ProtoMessage() {}
+
+func (x *TFPlanApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFPlanApproval.ProtoReflect.Descriptor instead.
+func (*TFPlanApproval) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TFPlanApproval) GetApprover() string {
+	if x != nil {
+		return x.Approver
+	}
+	return ""
+}
+
+func (x *TFPlanApproval) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *TFPlanApproval) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *TFPlanApproval) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Approval status of a plan
+type TFPlanApprovalStatus struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PlanId            string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	Status            string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	RequiredApprovals int32                  `protobuf:"varint,3,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`
+	ApprovalCount     int32                  `protobuf:"varint,4,opt,name=approval_count,json=approvalCount,proto3" json:"approval_count,omitempty"`
+	Approvals         []*TFPlanApproval      `protobuf:"bytes,5,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TFPlanApprovalStatus) Reset() {
+	*x = TFPlanApprovalStatus{}
+	mi := &file_spec_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFPlanApprovalStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFPlanApprovalStatus) ProtoMessage() {}
+
+func (x *TFPlanApprovalStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFPlanApprovalStatus.ProtoReflect.Descriptor instead.
+func (*TFPlanApprovalStatus) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TFPlanApprovalStatus) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *TFPlanApprovalStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TFPlanApprovalStatus) GetRequiredApprovals() int32 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+func (x *TFPlanApprovalStatus) GetApprovalCount() int32 {
+	if x != nil {
+		return x.ApprovalCount
+	}
+	return 0
+}
+
+func (x *TFPlanApprovalStatus) GetApprovals() []*TFPlanApproval {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+func (x *TFPlanApprovalStatus) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// Submission of a command to run asynchronously
+type TFSubmitJobInput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of command, plan, apply, init, validate or state
+	Type          string          `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Input         *TFCommandInput `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFSubmitJobInput) Reset() {
+	*x = TFSubmitJobInput{}
+	mi := &file_spec_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFSubmitJobInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFSubmitJobInput) ProtoMessage() {}
+
+func (x *TFSubmitJobInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFSubmitJobInput.ProtoReflect.Descriptor instead.
+func (*TFSubmitJobInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TFSubmitJobInput) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TFSubmitJobInput) GetInput() *TFCommandInput {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+// Selects a job
+type TFJobInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFJobInput) Reset() {
+	*x = TFJobInput{}
+	mi := &file_spec_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFJobInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFJobInput) ProtoMessage() {}
+
+func (x *TFJobInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFJobInput.ProtoReflect.Descriptor instead.
+func (*TFJobInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TFJobInput) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// Filters for listing jobs
+type TFListJobsInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFListJobsInput) Reset() {
+	*x = TFListJobsInput{}
+	mi := &file_spec_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFListJobsInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFListJobsInput) ProtoMessage() {}
+
+func (x *TFListJobsInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFListJobsInput.ProtoReflect.Descriptor instead.
+func (*TFListJobsInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TFListJobsInput) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TFListJobsInput) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *TFListJobsInput) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// An asynchronous job and, once finished, its result
+type TFJob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Type  string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// One of queued, running, succeeded, failed or cancelled
+	Status string          `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Input  *TFCommandInput `protobuf:"bytes,4,opt,name=input,proto3" json:"input,omitempty"`
+	// Command ID of the operation run by the job, set once it starts
+	CommandId    string                 `protobuf:"bytes,5,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*TFJob_CommandResult
+	//	*TFJob_PlanResult
+	//	*TFJob_ApplyResult
+	//	*TFJob_StateInfo
+	Result        isTFJob_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFJob) Reset() {
+	*x = TFJob{}
+	mi := &file_spec_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFJob) ProtoMessage() {}
+
+func (x *TFJob) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFJob.ProtoReflect.Descriptor instead.
+func (*TFJob) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TFJob) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *TFJob) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TFJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TFJob) GetInput() *TFCommandInput {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *TFJob) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *TFJob) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *TFJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TFJob) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *TFJob) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *TFJob) GetResult() isTFJob_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *TFJob) GetCommandResult() *TFCommandResult {
+	if x != nil {
+		if x, ok := x.Result.(*TFJob_CommandResult); ok {
+			return x.CommandResult
+		}
+	}
+	return nil
+}
+
+func (x *TFJob) GetPlanResult() *TFPlanResult {
+	if x != nil {
+		if x, ok := x.Result.(*TFJob_PlanResult); ok {
+			return x.PlanResult
+		}
+	}
+	return nil
+}
+
+func (x *TFJob) GetApplyResult() *TFApplyResult {
+	if x != nil {
+		if x, ok := x.Result.(*TFJob_ApplyResult); ok {
+			return x.ApplyResult
+		}
+	}
+	return nil
+}
+
+func (x *TFJob) GetStateInfo() *TFStateInfo {
+	if x != nil {
+		if x, ok := x.Result.(*TFJob_StateInfo); ok {
+			return x.StateInfo
+		}
+	}
+	return nil
+}
+
+type isTFJob_Result interface {
+	isTFJob_Result()
+}
+
+type TFJob_CommandResult struct {
+	CommandResult *TFCommandResult `protobuf:"bytes,10,opt,name=command_result,json=commandResult,proto3,oneof"`
+}
+
+type TFJob_PlanResult struct {
+	PlanResult *TFPlanResult `protobuf:"bytes,11,opt,name=plan_result,json=planResult,proto3,oneof"`
+}
+
+type TFJob_ApplyResult struct {
+	ApplyResult *TFApplyResult `protobuf:"bytes,12,opt,name=apply_result,json=applyResult,proto3,oneof"`
+}
+
+type TFJob_StateInfo struct {
+	StateInfo *TFStateInfo `protobuf:"bytes,13,opt,name=state_info,json=stateInfo,proto3,oneof"`
+}
+
+func (*TFJob_CommandResult) isTFJob_Result() {}
+
+func (*TFJob_PlanResult) isTFJob_Result() {}
+
+func (*TFJob_ApplyResult) isTFJob_Result() {}
+
+func (*TFJob_StateInfo) isTFJob_Result() {}
+
+// A page of jobs, newest first
+type TFJobList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*TFJob               `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFJobList) Reset() {
+	*x = TFJobList{}
+	mi := &file_spec_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFJobList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFJobList) ProtoMessage() {}
+
+func (x *TFJobList) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFJobList.ProtoReflect.Descriptor instead.
+func (*TFJobList) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TFJobList) GetJobs() []*TFJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// Selects the output of a job produced after a sequence number
+type TFJobOutputInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	AfterSequence int64                  `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFJobOutputInput) Reset() {
+	*x = TFJobOutputInput{}
+	mi := &file_spec_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFJobOutputInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFJobOutputInput) ProtoMessage() {}
+
+func (x *TFJobOutputInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFJobOutputInput.ProtoReflect.Descriptor instead.
+func (*TFJobOutputInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TFJobOutputInput) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *TFJobOutputInput) GetAfterSequence() int64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+// Output produced by a job so far
+type TFJobOutput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	CommandId     string                 `protobuf:"bytes,3,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Chunks        []*TFOutputChunk       `protobuf:"bytes,4,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFJobOutput) Reset() {
+	*x = TFJobOutput{}
+	mi := &file_spec_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFJobOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFJobOutput) ProtoMessage() {}
+
+func (x *TFJobOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFJobOutput.ProtoReflect.Descriptor instead.
+func (*TFJobOutput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TFJobOutput) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *TFJobOutput) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TFJobOutput) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *TFJobOutput) GetChunks() []*TFOutputChunk {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+// A lock held on a working directory and workspace by a running operation
+type TFLock struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	LockId           string                 `protobuf:"bytes,1,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
+	WorkingDirectory string                 `protobuf:"bytes,2,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	Workspace        string                 `protobuf:"bytes,3,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	// Station process holding the lock
+	Owner         string                 `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	CommandId     string                 `protobuf:"bytes,5,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Command       string                 `protobuf:"bytes,6,opt,name=command,proto3" json:"command,omitempty"`
+	AcquiredAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=acquired_at,json=acquiredAt,proto3" json:"acquired_at,omitempty"`
+	HeartbeatAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=heartbeat_at,json=heartbeatAt,proto3" json:"heartbeat_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFLock) Reset() {
+	*x = TFLock{}
+	mi := &file_spec_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFLock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFLock) ProtoMessage() {}
+
+func (x *TFLock) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFLock.ProtoReflect.Descriptor instead.
+func (*TFLock) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TFLock) GetLockId() string {
+	if x != nil {
+		return x.LockId
+	}
+	return ""
+}
+
+func (x *TFLock) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *TFLock) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+func (x *TFLock) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *TFLock) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *TFLock) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *TFLock) GetAcquiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcquiredAt
+	}
+	return nil
+}
+
+func (x *TFLock) GetHeartbeatAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HeartbeatAt
+	}
+	return nil
+}
+
+// Filters for listing locks
+type TFListLocksInput struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WorkingDirectory string                 `protobuf:"bytes,1,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TFListLocksInput) Reset() {
+	*x = TFListLocksInput{}
+	mi := &file_spec_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFListLocksInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFListLocksInput) ProtoMessage() {}
+
+func (x *TFListLocksInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFListLocksInput.ProtoReflect.Descriptor instead.
+func (*TFListLocksInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *TFListLocksInput) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+// Locks currently held
+type TFLockList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locks         []*TFLock              `protobuf:"bytes,1,rep,name=locks,proto3" json:"locks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFLockList) Reset() {
+	*x = TFLockList{}
+	mi := &file_spec_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFLockList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFLockList) ProtoMessage() {}
+
+func (x *TFLockList) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFLockList.ProtoReflect.Descriptor instead.
+func (*TFLockList) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TFLockList) GetLocks() []*TFLock {
+	if x != nil {
+		return x.Locks
+	}
+	return nil
+}
+
+// Selects a lock to release regardless of its holder
+type TFForceUnlockInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LockId        string                 `protobuf:"bytes,1,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFForceUnlockInput) Reset() {
+	*x = TFForceUnlockInput{}
+	mi := &file_spec_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFForceUnlockInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFForceUnlockInput) ProtoMessage() {}
+
+func (x *TFForceUnlockInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFForceUnlockInput.ProtoReflect.Descriptor instead.
+func (*TFForceUnlockInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TFForceUnlockInput) GetLockId() string {
+	if x != nil {
+		return x.LockId
+	}
+	return ""
+}
+
+// Selects the state whose versions are read: a state stored through the http backend by name,
+// or the local state of a working directory
+type TFStateVersionsInput struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	WorkingDirectory string                 `protobuf:"bytes,2,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	Limit            int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset           int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Workspace of local state, defaulting to the default workspace
+	Workspace     string `protobuf:"bytes,5,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFStateVersionsInput) Reset() {
+	*x = TFStateVersionsInput{}
+	mi := &file_spec_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFStateVersionsInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFStateVersionsInput) ProtoMessage() {}
+
+func (x *TFStateVersionsInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFStateVersionsInput.ProtoReflect.Descriptor instead.
+func (*TFStateVersionsInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TFStateVersionsInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFStateVersionsInput) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *TFStateVersionsInput) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *TFStateVersionsInput) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *TFStateVersionsInput) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+// An immutable version of a state
+type TFStateVersion struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Name    string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Serial  int64                  `protobuf:"varint,3,opt,name=serial,proto3" json:"serial,omitempty"`
+	Lineage string                 `protobuf:"bytes,4,opt,name=lineage,proto3" json:"lineage,omitempty"`
+	// Hex encoded SHA-256 of the state data
+	Checksum string `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// What wrote the version, such as apply, state rm, rollback or the operation of an http backend lock
+	Operation        string                 `protobuf:"bytes,6,opt,name=operation,proto3" json:"operation,omitempty"`
+	CommandId        string                 `protobuf:"bytes,7,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	LockId           string                 `protobuf:"bytes,8,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
+	TerraformVersion string                 `protobuf:"bytes,9,opt,name=terraform_version,json=terraformVersion,proto3" json:"terraform_version,omitempty"`
+	ResourceCount    int32                  `protobuf:"varint,10,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TFStateVersion) Reset() {
+	*x = TFStateVersion{}
+	mi := &file_spec_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFStateVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFStateVersion) ProtoMessage() {}
+
+func (x *TFStateVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFStateVersion.ProtoReflect.Descriptor instead.
+func (*TFStateVersion) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TFStateVersion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFStateVersion) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TFStateVersion) GetSerial() int64 {
+	if x != nil {
+		return x.Serial
+	}
+	return 0
+}
+
+func (x *TFStateVersion) GetLineage() string {
+	if x != nil {
+		return x.Lineage
+	}
+	return ""
+}
+
+func (x *TFStateVersion) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *TFStateVersion) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *TFStateVersion) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *TFStateVersion) GetLockId() string {
+	if x != nil {
+		return x.LockId
+	}
+	return ""
+}
+
+func (x *TFStateVersion) GetTerraformVersion() string {
+	if x != nil {
+		return x.TerraformVersion
+	}
+	return ""
+}
+
+func (x *TFStateVersion) GetResourceCount() int32 {
+	if x != nil {
+		return x.ResourceCount
+	}
+	return 0
+}
+
+func (x *TFStateVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Versions of a state, newest first
+type TFStateVersionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*TFStateVersion      `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFStateVersionList) Reset() {
+	*x = TFStateVersionList{}
+	mi := &file_spec_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFStateVersionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFStateVersionList) ProtoMessage() {}
+
+func (x *TFStateVersionList) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFStateVersionList.ProtoReflect.Descriptor instead.
+func (*TFStateVersionList) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *TFStateVersionList) GetVersions() []*TFStateVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// Selects two versions of a state to compare
+type TFStateDiffInput struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	WorkingDirectory string                 `protobuf:"bytes,2,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	FromVersion      int32                  `protobuf:"varint,3,opt,name=from_version,json=fromVersion,proto3" json:"from_version,omitempty"`
+	ToVersion        int32                  `protobuf:"varint,4,opt,name=to_version,json=toVersion,proto3" json:"to_version,omitempty"`
+	Workspace        string                 `protobuf:"bytes,5,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TFStateDiffInput) Reset() {
+	*x = TFStateDiffInput{}
+	mi := &file_spec_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFStateDiffInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFStateDiffInput) ProtoMessage() {}
+
+func (x *TFStateDiffInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFStateDiffInput.ProtoReflect.Descriptor instead.
+func (*TFStateDiffInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *TFStateDiffInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFStateDiffInput) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *TFStateDiffInput) GetFromVersion() int32 {
+	if x != nil {
+		return x.FromVersion
+	}
+	return 0
+}
+
+func (x *TFStateDiffInput) GetToVersion() int32 {
+	if x != nil {
+		return x.ToVersion
+	}
+	return 0
+}
+
+func (x *TFStateDiffInput) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+// Change of a single attribute between two state versions. before or after is unset when the attribute is absent.
+type TFAttributeDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Before        *structpb.Value        `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         *structpb.Value        `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFAttributeDiff) Reset() {
+	*x = TFAttributeDiff{}
+	mi := &file_spec_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFAttributeDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFAttributeDiff) ProtoMessage() {}
+
+func (x *TFAttributeDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFAttributeDiff.ProtoReflect.Descriptor instead.
+func (*TFAttributeDiff) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *TFAttributeDiff) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *TFAttributeDiff) GetBefore() *structpb.Value {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *TFAttributeDiff) GetAfter() *structpb.Value {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// Change of a single resource instance between two state versions
+type TFResourceStateDiff struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// One of create, update or delete
+	Action        string             `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Attributes    []*TFAttributeDiff `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFResourceStateDiff) Reset() {
+	*x = TFResourceStateDiff{}
+	mi := &file_spec_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFResourceStateDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFResourceStateDiff) ProtoMessage() {}
+
+func (x *TFResourceStateDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFResourceStateDiff.ProtoReflect.Descriptor instead.
+func (*TFResourceStateDiff) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *TFResourceStateDiff) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TFResourceStateDiff) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TFResourceStateDiff) GetAttributes() []*TFAttributeDiff {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// Resource by resource differences between two state versions
+type TFStateDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	FromVersion   int32                  `protobuf:"varint,2,opt,name=from_version,json=fromVersion,proto3" json:"from_version,omitempty"`
+	ToVersion     int32                  `protobuf:"varint,3,opt,name=to_version,json=toVersion,proto3" json:"to_version,omitempty"`
+	Resources     []*TFResourceStateDiff `protobuf:"bytes,4,rep,name=resources,proto3" json:"resources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFStateDiff) Reset() {
+	*x = TFStateDiff{}
+	mi := &file_spec_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFStateDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFStateDiff) ProtoMessage() {}
+
+func (x *TFStateDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFStateDiff.ProtoReflect.Descriptor instead.
+func (*TFStateDiff) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TFStateDiff) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFStateDiff) GetFromVersion() int32 {
+	if x != nil {
+		return x.FromVersion
+	}
+	return 0
+}
+
+func (x *TFStateDiff) GetToVersion() int32 {
+	if x != nil {
+		return x.ToVersion
+	}
+	return 0
+}
+
+func (x *TFStateDiff) GetResources() []*TFResourceStateDiff {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+// Selects an older state version to restore as the newest one
+type TFStateRollbackInput struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	WorkingDirectory string                 `protobuf:"bytes,2,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	Version          int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Workspace        string                 `protobuf:"bytes,4,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TFStateRollbackInput) Reset() {
+	*x = TFStateRollbackInput{}
+	mi := &file_spec_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFStateRollbackInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFStateRollbackInput) ProtoMessage() {}
+
+func (x *TFStateRollbackInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFStateRollbackInput.ProtoReflect.Descriptor instead.
+func (*TFStateRollbackInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TFStateRollbackInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFStateRollbackInput) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *TFStateRollbackInput) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TFStateRollbackInput) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+// Selects a working directory to check for drift
+type TFDriftInput struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WorkingDirectory string                 `protobuf:"bytes,1,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	// Workspace to check, defaulting to the selected workspace
+	Workspace     string `protobuf:"bytes,2,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFDriftInput) Reset() {
+	*x = TFDriftInput{}
+	mi := &file_spec_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFDriftInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFDriftInput) ProtoMessage() {}
+
+func (x *TFDriftInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFDriftInput.ProtoReflect.Descriptor instead.
+func (*TFDriftInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *TFDriftInput) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *TFDriftInput) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+// A resource whose real infrastructure no longer matches its state
+type TFDriftedResource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	ModuleAddress string                 `protobuf:"bytes,2,opt,name=module_address,json=moduleAddress,proto3" json:"module_address,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	ProviderName  string                 `protobuf:"bytes,5,opt,name=provider_name,json=providerName,proto3" json:"provider_name,omitempty"`
+	// How the resource changed outside OpenTofu: update or delete
+	Action        string             `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	Attributes    []*TFAttributeDiff `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFDriftedResource) Reset() {
+	*x = TFDriftedResource{}
+	mi := &file_spec_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFDriftedResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFDriftedResource) ProtoMessage() {}
+
+func (x *TFDriftedResource) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFDriftedResource.ProtoReflect.Descriptor instead.
+func (*TFDriftedResource) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *TFDriftedResource) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TFDriftedResource) GetModuleAddress() string {
+	if x != nil {
+		return x.ModuleAddress
+	}
+	return ""
+}
+
+func (x *TFDriftedResource) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TFDriftedResource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFDriftedResource) GetProviderName() string {
+	if x != nil {
+		return x.ProviderName
+	}
+	return ""
+}
+
+func (x *TFDriftedResource) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TFDriftedResource) GetAttributes() []*TFAttributeDiff {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// Outcome of checking a working directory for drift with a refresh-only plan
+type TFDriftReport struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ReportId         string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	WorkingDirectory string                 `protobuf:"bytes,2,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	// One of clean, drifted or failed
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// schedule or manual
+	Trigger string `protobuf:"bytes,4,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	// drift_detected or drift_resolved when this check changed the drift status of the working directory
+	Event         string                 `protobuf:"bytes,5,opt,name=event,proto3" json:"event,omitempty"`
+	CommandId     string                 `protobuf:"bytes,6,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	ResourceCount int32                  `protobuf:"varint,7,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
+	Resources     []*TFDriftedResource   `protobuf:"bytes,8,rep,name=resources,proto3" json:"resources,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,9,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Workspace     string                 `protobuf:"bytes,12,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFDriftReport) Reset() {
+	*x = TFDriftReport{}
+	mi := &file_spec_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFDriftReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFDriftReport) ProtoMessage() {}
+
+func (x *TFDriftReport) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFDriftReport.ProtoReflect.Descriptor instead.
+func (*TFDriftReport) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *TFDriftReport) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *TFDriftReport) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *TFDriftReport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TFDriftReport) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *TFDriftReport) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *TFDriftReport) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *TFDriftReport) GetResourceCount() int32 {
+	if x != nil {
+		return x.ResourceCount
+	}
+	return 0
+}
+
+func (x *TFDriftReport) GetResources() []*TFDriftedResource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *TFDriftReport) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *TFDriftReport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TFDriftReport) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *TFDriftReport) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+// Filters for listing drift reports
+type TFListDriftReportsInput struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WorkingDirectory string                 `protobuf:"bytes,1,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	Status           string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Limit            int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset           int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Workspace        string                 `protobuf:"bytes,5,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TFListDriftReportsInput) Reset() {
+	*x = TFListDriftReportsInput{}
+	mi := &file_spec_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFListDriftReportsInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFListDriftReportsInput) ProtoMessage() {}
+
+func (x *TFListDriftReportsInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFListDriftReportsInput.ProtoReflect.Descriptor instead.
+func (*TFListDriftReportsInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *TFListDriftReportsInput) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *TFListDriftReportsInput) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TFListDriftReportsInput) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *TFListDriftReportsInput) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *TFListDriftReportsInput) GetWorkspace() string {
+	if x != nil {
+		return x.Workspace
+	}
+	return ""
+}
+
+// Drift reports, newest first
+type TFDriftReportList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reports       []*TFDriftReport       `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFDriftReportList) Reset() {
+	*x = TFDriftReportList{}
+	mi := &file_spec_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFDriftReportList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFDriftReportList) ProtoMessage() {}
+
+func (x *TFDriftReportList) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFDriftReportList.ProtoReflect.Descriptor instead.
+func (*TFDriftReportList) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TFDriftReportList) GetReports() []*TFDriftReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+// Selects a single drift report
+type TFDriftReportInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFDriftReportInput) Reset() {
+	*x = TFDriftReportInput{}
+	mi := &file_spec_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFDriftReportInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFDriftReportInput) ProtoMessage() {}
+
+func (x *TFDriftReportInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFDriftReportInput.ProtoReflect.Descriptor instead.
+func (*TFDriftReportInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *TFDriftReportInput) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+// Selects a workspace of a working directory. name is not used when listing or showing workspaces.
+type TFWorkspaceInput struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WorkingDirectory string                 `protobuf:"bytes,1,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Delete a workspace even if its state still tracks resources
+	Force         bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFWorkspaceInput) Reset() {
+	*x = TFWorkspaceInput{}
+	mi := &file_spec_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFWorkspaceInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFWorkspaceInput) ProtoMessage() {}
+
+func (x *TFWorkspaceInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFWorkspaceInput.ProtoReflect.Descriptor instead.
+func (*TFWorkspaceInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TFWorkspaceInput) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *TFWorkspaceInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFWorkspaceInput) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// A workspace of a working directory
+type TFWorkspace struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	WorkingDirectory string                 `protobuf:"bytes,2,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	// Command that listed, created, selected or deleted the workspace
+	CommandId     string `protobuf:"bytes,3,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFWorkspace) Reset() {
+	*x = TFWorkspace{}
+	mi := &file_spec_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFWorkspace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFWorkspace) ProtoMessage() {}
+
+func (x *TFWorkspace) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFWorkspace.ProtoReflect.Descriptor instead.
+func (*TFWorkspace) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *TFWorkspace) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFWorkspace) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *TFWorkspace) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+// Workspaces of a working directory
+type TFWorkspaceList struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WorkingDirectory string                 `protobuf:"bytes,1,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	Workspaces       []string               `protobuf:"bytes,2,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	// Workspace currently selected in the working directory
+	Current       string `protobuf:"bytes,3,opt,name=current,proto3" json:"current,omitempty"`
+	CommandId     string `protobuf:"bytes,4,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFWorkspaceList) Reset() {
+	*x = TFWorkspaceList{}
+	mi := &file_spec_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFWorkspaceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFWorkspaceList) ProtoMessage() {}
+
+func (x *TFWorkspaceList) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFWorkspaceList.ProtoReflect.Descriptor instead.
+func (*TFWorkspaceList) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *TFWorkspaceList) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+func (x *TFWorkspaceList) GetWorkspaces() []string {
+	if x != nil {
+		return x.Workspaces
+	}
+	return nil
+}
+
+func (x *TFWorkspaceList) GetCurrent() string {
+	if x != nil {
+		return x.Current
+	}
+	return ""
+}
+
+func (x *TFWorkspaceList) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+// Stores a secret, replacing any secret of the same name
+type TFPutSecretInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFPutSecretInput) Reset() {
+	*x = TFPutSecretInput{}
+	mi := &file_spec_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFPutSecretInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFPutSecretInput) ProtoMessage() {}
+
+func (x *TFPutSecretInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFPutSecretInput.ProtoReflect.Descriptor instead.
+func (*TFPutSecretInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *TFPutSecretInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFPutSecretInput) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Selects a single secret
+type TFSecretInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFSecretInput) Reset() {
+	*x = TFSecretInput{}
+	mi := &file_spec_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFSecretInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFSecretInput) ProtoMessage() {}
+
+func (x *TFSecretInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFSecretInput.ProtoReflect.Descriptor instead.
+func (*TFSecretInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *TFSecretInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// A stored secret. Its value is never returned.
+type TFSecret struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Identifies the master key the value is encrypted with
+	KeyId         string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFSecret) Reset() {
+	*x = TFSecret{}
+	mi := &file_spec_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFSecret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFSecret) ProtoMessage() {}
+
+func (x *TFSecret) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFSecret.ProtoReflect.Descriptor instead.
+func (*TFSecret) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *TFSecret) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TFSecret) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *TFSecret) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TFSecret) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type TFListSecretsInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFListSecretsInput) Reset() {
+	*x = TFListSecretsInput{}
+	mi := &file_spec_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFListSecretsInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFListSecretsInput) ProtoMessage() {}
+
+func (x *TFListSecretsInput) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFListSecretsInput.ProtoReflect.Descriptor instead.
+func (*TFListSecretsInput) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{55}
+}
+
+// Stored secrets, ordered by name
+type TFSecretList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secrets       []*TFSecret            `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TFSecretList) Reset() {
+	*x = TFSecretList{}
+	mi := &file_spec_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TFSecretList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TFSecretList) ProtoMessage() {}
+
+func (x *TFSecretList) ProtoReflect() protoreflect.Message {
+	mi := &file_spec_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TFSecretList.ProtoReflect.Descriptor instead.
+func (*TFSecretList) Descriptor() ([]byte, []int) {
+	return file_spec_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *TFSecretList) GetSecrets() []*TFSecret {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+var File_spec_proto protoreflect.FileDescriptor
+
+const file_spec_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"spec.proto\x12\x10TerraformStation\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xa9\x05\n" +
+	"\x0eTFCommandInput\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12+\n" +
+	"\x11working_directory\x18\x02 \x01(\tR\x10workingDirectory\x12M\n" +
+	"\tvariables\x18\x03 \x03(\v2/.TerraformStation.TFCommandInput.VariablesEntryR\tvariables\x12\x1c\n" +
+	"\targuments\x18\x04 \x03(\tR\targuments\x12\x1b\n" +
+	"\tplan_file\x18\x05 \x01(\tR\bplanFile\x12\x1d\n" +
+	"\n" +
+	"state_file\x18\x06 \x01(\tR\tstateFile\x12\x17\n" +
+	"\aplan_id\x18\a \x01(\tR\x06planId\x12\x1c\n" +
+	"\tworkspace\x18\b \x01(\tR\tworkspace\x12`\n" +
+	"\x10secret_variables\x18\t \x03(\v25.TerraformStation.TFCommandInput.SecretVariablesEntryR\x0fsecretVariables\x12N\n" +
+	"\n" +
+	"secret_env\x18\n" +
+	" \x03(\v2/.TerraformStation.TFCommandInput.SecretEnvEntryR\tsecretEnv\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aB\n" +
+	"\x14SecretVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
+	"\x0eSecretEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8d\x04\n" +
+	"\x0fTFCommandResult\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x12\x1b\n" +
+	"\texit_code\x18\x04 \x01(\x05R\bexitCode\x12;\n" +
+	"\vexecuted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"executedAt\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x06 \x01(\tR\tcommandId\x12\x16\n" +
+	"\x06stdout\x18\a \x01(\tR\x06stdout\x12\x16\n" +
+	"\x06stderr\x18\b \x01(\tR\x06stderr\x12\x1f\n" +
+	"\vduration_ms\x18\t \x01(\x03R\n" +
+	"durationMs\x12\x16\n" +
+	"\x06signal\x18\n" +
+	" \x01(\tR\x06signal\x12\x1b\n" +
+	"\ttimed_out\x18\v \x01(\bR\btimedOut\x12@\n" +
+	"\vdiagnostics\x18\f \x03(\v2\x1e.TerraformStation.TFDiagnosticR\vdiagnostics\x12\x1f\n" +
+	"\verror_count\x18\r \x01(\x05R\n" +
+	"errorCount\x12#\n" +
+	"\rwarning_count\x18\x0e \x01(\x05R\fwarningCount\x12\x1c\n" +
+	"\tcancelled\x18\x0f \x01(\bR\tcancelled\"1\n" +
+	"\x10TFOperationInput\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\tR\tcommandId\"\xfd\x02\n" +
+	"\vTFOperation\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\tR\tcommandId\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\x12+\n" +
+	"\x11working_directory\x18\x03 \x01(\tR\x10workingDirectory\x12\x1c\n" +
+	"\tworkspace\x18\x04 \x01(\tR\tworkspace\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1b\n" +
+	"\texit_code\x18\x06 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06signal\x18\a \x01(\tR\x06signal\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x129\n" +
+	"\n" +
+	"started_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"M\n" +
+	"\vTFSourcePos\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\x02 \x01(\x05R\x06column\x12\x12\n" +
+	"\x04byte\x18\x03 \x01(\x05R\x04byte\"\x91\x01\n" +
+	"\rTFSourceRange\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x123\n" +
+	"\x05start\x18\x02 \x01(\v2\x1d.TerraformStation.TFSourcePosR\x05start\x12/\n" +
+	"\x03end\x18\x03 \x01(\v2\x1d.TerraformStation.TFSourcePosR\x03end\"O\n" +
+	"\x11TFExpressionValue\x12\x1c\n" +
+	"\ttraversal\x18\x01 \x01(\tR\ttraversal\x12\x1c\n" +
+	"\tstatement\x18\x02 \x01(\tR\tstatement\"\x87\x02\n" +
+	"\x13TFDiagnosticSnippet\x12\x18\n" +
+	"\acontext\x18\x01 \x01(\tR\acontext\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"start_line\x18\x03 \x01(\x05R\tstartLine\x124\n" +
+	"\x16highlight_start_offset\x18\x04 \x01(\x05R\x14highlightStartOffset\x120\n" +
+	"\x14highlight_end_offset\x18\x05 \x01(\x05R\x12highlightEndOffset\x12;\n" +
+	"\x06values\x18\x06 \x03(\v2#.TerraformStation.TFExpressionValueR\x06values\"\xee\x01\n" +
+	"\fTFDiagnostic\x12\x1a\n" +
+	"\bseverity\x18\x01 \x01(\tR\bseverity\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x125\n" +
+	"\x05range\x18\x05 \x01(\v2\x1f.TerraformStation.TFSourceRangeR\x05range\x12?\n" +
+	"\asnippet\x18\x06 \x01(\v2%.TerraformStation.TFDiagnosticSnippetR\asnippet\"\x90\x03\n" +
+	"\x10TFResourceChange\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12%\n" +
+	"\x0emodule_address\x18\x02 \x01(\tR\rmoduleAddress\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12#\n" +
+	"\rprovider_name\x18\x06 \x01(\tR\fproviderName\x12\x16\n" +
+	"\x06action\x18\a \x01(\tR\x06action\x12.\n" +
+	"\x06before\x18\b \x01(\v2\x16.google.protobuf.ValueR\x06before\x12,\n" +
+	"\x05after\x18\t \x01(\v2\x16.google.protobuf.ValueR\x05after\x12?\n" +
+	"\rreplace_paths\x18\n" +
+	" \x03(\v2\x1a.google.protobuf.ListValueR\freplacePaths\x12#\n" +
+	"\raction_reason\x18\v \x01(\tR\factionReason\"\xc4\x04\n" +
+	"\fTFPlanResult\x12\x17\n" +
+	"\aplan_id\x18\x01 \x01(\tR\x06planId\x12\x1f\n" +
 	"\vplan_output\x18\x02 \x01(\tR\n" +
 	"planOutput\x12\x1f\n" +
 	"\vhas_changes\x18\x03 \x01(\bR\n" +
@@ -482,7 +4608,25 @@ const file_spec_proto_rawDesc = "" +
 	"\x0eresource_count\x18\x04 \x01(\x05R\rresourceCount\x129\n" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\"\xab\x02\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12M\n" +
+	"\x10resource_changes\x18\a \x03(\v2\".TerraformStation.TFResourceChangeR\x0fresourceChanges\x12\x15\n" +
+	"\x06to_add\x18\b \x01(\x05R\x05toAdd\x12\x1b\n" +
+	"\tto_change\x18\t \x01(\x05R\btoChange\x12\x1d\n" +
+	"\n" +
+	"to_destroy\x18\n" +
+	" \x01(\x05R\ttoDestroy\x12\x1d\n" +
+	"\n" +
+	"to_replace\x18\v \x01(\x05R\ttoReplace\x12#\n" +
+	"\rerror_message\x18\f \x01(\tR\ferrorMessage\x12-\n" +
+	"\x12required_approvals\x18\r \x01(\x05R\x11requiredApprovals\x12J\n" +
+	"\x13approval_expires_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x11approvalExpiresAt\"\xc6\x01\n" +
+	"\x0fTFResourceApply\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12'\n" +
+	"\x0felapsed_seconds\x18\x04 \x01(\x01R\x0eelapsedSeconds\x12\x19\n" +
+	"\bid_value\x18\x05 \x01(\tR\aidValue\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\"\xbd\x03\n" +
 	"\rTFApplyResult\x12\x19\n" +
 	"\bapply_id\x18\x01 \x01(\tR\aapplyId\x12!\n" +
 	"\fapply_output\x18\x02 \x01(\tR\vapplyOutput\x12\x18\n" +
@@ -491,14 +4635,288 @@ const file_spec_proto_rawDesc = "" +
 	"\x11resources_changed\x18\x05 \x01(\x05R\x10resourcesChanged\x12/\n" +
 	"\x13resources_destroyed\x18\x06 \x01(\x05R\x12resourcesDestroyed\x12;\n" +
 	"\vexecuted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"executedAt\"\xda\x01\n" +
+	"executedAt\x12)\n" +
+	"\x10resources_failed\x18\b \x01(\x05R\x0fresourcesFailed\x12L\n" +
+	"\x10resource_applies\x18\t \x03(\v2!.TerraformStation.TFResourceApplyR\x0fresourceApplies\x12\x17\n" +
+	"\aplan_id\x18\n" +
+	" \x01(\tR\x06planId\"\xd5\x03\n" +
+	"\x0fTFStateResource\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12%\n" +
+	"\x0emodule_address\x18\x02 \x01(\tR\rmoduleAddress\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12,\n" +
+	"\x05index\x18\x06 \x01(\v2\x16.google.protobuf.ValueR\x05index\x12#\n" +
+	"\rprovider_name\x18\a \x01(\tR\fproviderName\x12%\n" +
+	"\x0eschema_version\x18\b \x01(\x05R\rschemaVersion\x12.\n" +
+	"\x06values\x18\t \x01(\v2\x16.google.protobuf.ValueR\x06values\x12A\n" +
+	"\x10sensitive_values\x18\n" +
+	" \x01(\v2\x16.google.protobuf.ValueR\x0fsensitiveValues\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\v \x03(\tR\tdependsOn\x12\x18\n" +
+	"\atainted\x18\f \x01(\bR\atainted\x12\x1f\n" +
+	"\vdeposed_key\x18\r \x01(\tR\n" +
+	"deposedKey\"\x9b\x01\n" +
+	"\rTFStateOutput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05value\x12*\n" +
+	"\x04type\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x04type\x12\x1c\n" +
+	"\tsensitive\x18\x04 \x01(\bR\tsensitive\"\xce\x03\n" +
 	"\vTFStateInfo\x12\x19\n" +
 	"\bstate_id\x18\x01 \x01(\tR\astateId\x12\x1d\n" +
 	"\n" +
 	"state_file\x18\x02 \x01(\tR\tstateFile\x12%\n" +
 	"\x0eresource_count\x18\x03 \x01(\x05R\rresourceCount\x12=\n" +
 	"\flast_updated\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x12+\n" +
-	"\x11terraform_version\x18\x05 \x01(\tR\x10terraformVersion2\xf3\x03\n" +
+	"\x11terraform_version\x18\x05 \x01(\tR\x10terraformVersion\x12\x16\n" +
+	"\x06serial\x18\x06 \x01(\x03R\x06serial\x12\x18\n" +
+	"\alineage\x18\a \x01(\tR\alineage\x12%\n" +
+	"\x0eformat_version\x18\b \x01(\tR\rformatVersion\x12?\n" +
+	"\tresources\x18\t \x03(\v2!.TerraformStation.TFStateResourceR\tresources\x129\n" +
+	"\aoutputs\x18\n" +
+	" \x03(\v2\x1f.TerraformStation.TFStateOutputR\aoutputs\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\v \x01(\tR\tcommandId\"\xec\x01\n" +
+	"\rTFOutputChunk\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\tR\tcommandId\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x03R\bsequence\x12\x16\n" +
+	"\x06stream\x18\x03 \x01(\tR\x06stream\x12\x12\n" +
+	"\x04line\x18\x04 \x01(\tR\x04line\x129\n" +
+	"\n" +
+	"emitted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\temittedAt\x129\n" +
+	"\x06result\x18\x06 \x01(\v2!.TerraformStation.TFCommandResultR\x06result\"\x89\x01\n" +
+	"\x0fTFChangeSummary\x12\x10\n" +
+	"\x03add\x18\x01 \x01(\x05R\x03add\x12\x16\n" +
+	"\x06change\x18\x02 \x01(\x05R\x06change\x12\x16\n" +
+	"\x06import\x18\x03 \x01(\x05R\x06import\x12\x16\n" +
+	"\x06remove\x18\x04 \x01(\x05R\x06remove\x12\x1c\n" +
+	"\toperation\x18\x05 \x01(\tR\toperation\"\xf1\x03\n" +
+	"\x0fTFProgressEvent\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\tR\tcommandId\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x03R\bsequence\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\tR\x05level\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x18\n" +
+	"\aaddress\x18\x06 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06action\x18\a \x01(\tR\x06action\x12'\n" +
+	"\x0felapsed_seconds\x18\b \x01(\x01R\x0eelapsedSeconds\x12%\n" +
+	"\x0eresource_index\x18\t \x01(\x05R\rresourceIndex\x12%\n" +
+	"\x0eresource_total\x18\n" +
+	" \x01(\x05R\rresourceTotal\x12;\n" +
+	"\achanges\x18\v \x01(\v2!.TerraformStation.TFChangeSummaryR\achanges\x12>\n" +
+	"\n" +
+	"diagnostic\x18\f \x01(\v2\x1e.TerraformStation.TFDiagnosticR\n" +
+	"diagnostic\x129\n" +
+	"\n" +
+	"emitted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\temittedAt\"X\n" +
+	"\x10TFSubscribeInput\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\tR\tcommandId\x12%\n" +
+	"\x0eafter_sequence\x18\x02 \x01(\x03R\rafterSequence\"b\n" +
+	"\x11TFPlanReviewInput\x12\x17\n" +
+	"\aplan_id\x18\x01 \x01(\tR\x06planId\x12\x1a\n" +
+	"\bapprover\x18\x02 \x01(\tR\bapprover\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\".\n" +
+	"\x13TFPlanApprovalInput\x12\x17\n" +
+	"\aplan_id\x18\x01 \x01(\tR\x06planId\"\x9d\x01\n" +
+	"\x0eTFPlanApproval\x12\x1a\n" +
+	"\bapprover\x18\x01 \x01(\tR\bapprover\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x98\x02\n" +
+	"\x14TFPlanApprovalStatus\x12\x17\n" +
+	"\aplan_id\x18\x01 \x01(\tR\x06planId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12-\n" +
+	"\x12required_approvals\x18\x03 \x01(\x05R\x11requiredApprovals\x12%\n" +
+	"\x0eapproval_count\x18\x04 \x01(\x05R\rapprovalCount\x12>\n" +
+	"\tapprovals\x18\x05 \x03(\v2 .TerraformStation.TFPlanApprovalR\tapprovals\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"^\n" +
+	"\x10TFSubmitJobInput\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x126\n" +
+	"\x05input\x18\x02 \x01(\v2 .TerraformStation.TFCommandInputR\x05input\"#\n" +
+	"\n" +
+	"TFJobInput\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"W\n" +
+	"\x0fTFListJobsInput\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\x9a\x05\n" +
+	"\x05TFJob\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x126\n" +
+	"\x05input\x18\x04 \x01(\v2 .TerraformStation.TFCommandInputR\x05input\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x05 \x01(\tR\tcommandId\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12J\n" +
+	"\x0ecommand_result\x18\n" +
+	" \x01(\v2!.TerraformStation.TFCommandResultH\x00R\rcommandResult\x12A\n" +
+	"\vplan_result\x18\v \x01(\v2\x1e.TerraformStation.TFPlanResultH\x00R\n" +
+	"planResult\x12D\n" +
+	"\fapply_result\x18\f \x01(\v2\x1f.TerraformStation.TFApplyResultH\x00R\vapplyResult\x12>\n" +
+	"\n" +
+	"state_info\x18\r \x01(\v2\x1d.TerraformStation.TFStateInfoH\x00R\tstateInfoB\b\n" +
+	"\x06result\"8\n" +
+	"\tTFJobList\x12+\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x17.TerraformStation.TFJobR\x04jobs\"P\n" +
+	"\x10TFJobOutputInput\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12%\n" +
+	"\x0eafter_sequence\x18\x02 \x01(\x03R\rafterSequence\"\x94\x01\n" +
+	"\vTFJobOutput\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x03 \x01(\tR\tcommandId\x127\n" +
+	"\x06chunks\x18\x04 \x03(\v2\x1f.TerraformStation.TFOutputChunkR\x06chunks\"\xb7\x02\n" +
+	"\x06TFLock\x12\x17\n" +
+	"\alock_id\x18\x01 \x01(\tR\x06lockId\x12+\n" +
+	"\x11working_directory\x18\x02 \x01(\tR\x10workingDirectory\x12\x1c\n" +
+	"\tworkspace\x18\x03 \x01(\tR\tworkspace\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x05 \x01(\tR\tcommandId\x12\x18\n" +
+	"\acommand\x18\x06 \x01(\tR\acommand\x12;\n" +
+	"\vacquired_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"acquiredAt\x12=\n" +
+	"\fheartbeat_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vheartbeatAt\"?\n" +
+	"\x10TFListLocksInput\x12+\n" +
+	"\x11working_directory\x18\x01 \x01(\tR\x10workingDirectory\"<\n" +
+	"\n" +
+	"TFLockList\x12.\n" +
+	"\x05locks\x18\x01 \x03(\v2\x18.TerraformStation.TFLockR\x05locks\"-\n" +
+	"\x12TFForceUnlockInput\x12\x17\n" +
+	"\alock_id\x18\x01 \x01(\tR\x06lockId\"\xa3\x01\n" +
+	"\x14TFStateVersionsInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x11working_directory\x18\x02 \x01(\tR\x10workingDirectory\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x1c\n" +
+	"\tworkspace\x18\x05 \x01(\tR\tworkspace\"\xf1\x02\n" +
+	"\x0eTFStateVersion\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x16\n" +
+	"\x06serial\x18\x03 \x01(\x03R\x06serial\x12\x18\n" +
+	"\alineage\x18\x04 \x01(\tR\alineage\x12\x1a\n" +
+	"\bchecksum\x18\x05 \x01(\tR\bchecksum\x12\x1c\n" +
+	"\toperation\x18\x06 \x01(\tR\toperation\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\a \x01(\tR\tcommandId\x12\x17\n" +
+	"\alock_id\x18\b \x01(\tR\x06lockId\x12+\n" +
+	"\x11terraform_version\x18\t \x01(\tR\x10terraformVersion\x12%\n" +
+	"\x0eresource_count\x18\n" +
+	" \x01(\x05R\rresourceCount\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"R\n" +
+	"\x12TFStateVersionList\x12<\n" +
+	"\bversions\x18\x01 \x03(\v2 .TerraformStation.TFStateVersionR\bversions\"\xb3\x01\n" +
+	"\x10TFStateDiffInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x11working_directory\x18\x02 \x01(\tR\x10workingDirectory\x12!\n" +
+	"\ffrom_version\x18\x03 \x01(\x05R\vfromVersion\x12\x1d\n" +
+	"\n" +
+	"to_version\x18\x04 \x01(\x05R\ttoVersion\x12\x1c\n" +
+	"\tworkspace\x18\x05 \x01(\tR\tworkspace\"\x83\x01\n" +
+	"\x0fTFAttributeDiff\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12.\n" +
+	"\x06before\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x06before\x12,\n" +
+	"\x05after\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x05after\"\x8a\x01\n" +
+	"\x13TFResourceStateDiff\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12A\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v2!.TerraformStation.TFAttributeDiffR\n" +
+	"attributes\"\xa8\x01\n" +
+	"\vTFStateDiff\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\ffrom_version\x18\x02 \x01(\x05R\vfromVersion\x12\x1d\n" +
+	"\n" +
+	"to_version\x18\x03 \x01(\x05R\ttoVersion\x12C\n" +
+	"\tresources\x18\x04 \x03(\v2%.TerraformStation.TFResourceStateDiffR\tresources\"\x8f\x01\n" +
+	"\x14TFStateRollbackInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x11working_directory\x18\x02 \x01(\tR\x10workingDirectory\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12\x1c\n" +
+	"\tworkspace\x18\x04 \x01(\tR\tworkspace\"Y\n" +
+	"\fTFDriftInput\x12+\n" +
+	"\x11working_directory\x18\x01 \x01(\tR\x10workingDirectory\x12\x1c\n" +
+	"\tworkspace\x18\x02 \x01(\tR\tworkspace\"\xfc\x01\n" +
+	"\x11TFDriftedResource\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12%\n" +
+	"\x0emodule_address\x18\x02 \x01(\tR\rmoduleAddress\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12#\n" +
+	"\rprovider_name\x18\x05 \x01(\tR\fproviderName\x12\x16\n" +
+	"\x06action\x18\x06 \x01(\tR\x06action\x12A\n" +
+	"\n" +
+	"attributes\x18\a \x03(\v2!.TerraformStation.TFAttributeDiffR\n" +
+	"attributes\"\xe7\x03\n" +
+	"\rTFDriftReport\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12+\n" +
+	"\x11working_directory\x18\x02 \x01(\tR\x10workingDirectory\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
+	"\atrigger\x18\x04 \x01(\tR\atrigger\x12\x14\n" +
+	"\x05event\x18\x05 \x01(\tR\x05event\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x06 \x01(\tR\tcommandId\x12%\n" +
+	"\x0eresource_count\x18\a \x01(\x05R\rresourceCount\x12A\n" +
+	"\tresources\x18\b \x03(\v2#.TerraformStation.TFDriftedResourceR\tresources\x12#\n" +
+	"\rerror_message\x18\t \x01(\tR\ferrorMessage\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12\x1c\n" +
+	"\tworkspace\x18\f \x01(\tR\tworkspace\"\xaa\x01\n" +
+	"\x17TFListDriftReportsInput\x12+\n" +
+	"\x11working_directory\x18\x01 \x01(\tR\x10workingDirectory\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x1c\n" +
+	"\tworkspace\x18\x05 \x01(\tR\tworkspace\"N\n" +
+	"\x11TFDriftReportList\x129\n" +
+	"\areports\x18\x01 \x03(\v2\x1f.TerraformStation.TFDriftReportR\areports\"1\n" +
+	"\x12TFDriftReportInput\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\"i\n" +
+	"\x10TFWorkspaceInput\x12+\n" +
+	"\x11working_directory\x18\x01 \x01(\tR\x10workingDirectory\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\"m\n" +
+	"\vTFWorkspace\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x11working_directory\x18\x02 \x01(\tR\x10workingDirectory\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x03 \x01(\tR\tcommandId\"\x97\x01\n" +
+	"\x0fTFWorkspaceList\x12+\n" +
+	"\x11working_directory\x18\x01 \x01(\tR\x10workingDirectory\x12\x1e\n" +
+	"\n" +
+	"workspaces\x18\x02 \x03(\tR\n" +
+	"workspaces\x12\x18\n" +
+	"\acurrent\x18\x03 \x01(\tR\acurrent\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x04 \x01(\tR\tcommandId\"<\n" +
+	"\x10TFPutSecretInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"#\n" +
+	"\rTFSecretInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xab\x01\n" +
+	"\bTFSecret\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x14\n" +
+	"\x12TFListSecretsInput\"D\n" +
+	"\fTFSecretList\x124\n" +
+	"\asecrets\x18\x01 \x03(\v2\x1a.TerraformStation.TFSecretR\asecrets2\xf2\x16\n" +
 	"\x17TerraformStationService\x12P\n" +
 	"\tTFCommand\x12 .TerraformStation.TFCommandInput\x1a!.TerraformStation.TFCommandResult\x12J\n" +
 	"\x06TFPlan\x12 .TerraformStation.TFCommandInput\x1a\x1e.TerraformStation.TFPlanResult\x12L\n" +
@@ -506,7 +4924,36 @@ const file_spec_proto_rawDesc = "" +
 	"\x06TFInit\x12 .TerraformStation.TFCommandInput\x1a!.TerraformStation.TFCommandResult\x12Q\n" +
 	"\n" +
 	"TFValidate\x12 .TerraformStation.TFCommandInput\x1a!.TerraformStation.TFCommandResult\x12J\n" +
-	"\aTFState\x12 .TerraformStation.TFCommandInput\x1a\x1d.TerraformStation.TFStateInfoB(Z&github.com/ForestMars/TerraformStationb\x06proto3"
+	"\aTFState\x12 .TerraformStation.TFCommandInput\x1a\x1d.TerraformStation.TFStateInfo\x12V\n" +
+	"\x0fTFCommandStream\x12 .TerraformStation.TFCommandInput\x1a\x1f.TerraformStation.TFOutputChunk0\x01\x12Z\n" +
+	"\x11TFSubscribeOutput\x12\".TerraformStation.TFSubscribeInput\x1a\x1f.TerraformStation.TFOutputChunk0\x01\x12^\n" +
+	"\x13TFSubscribeProgress\x12\".TerraformStation.TFSubscribeInput\x1a!.TerraformStation.TFProgressEvent0\x01\x12V\n" +
+	"\x11TFCancelOperation\x12\".TerraformStation.TFOperationInput\x1a\x1d.TerraformStation.TFOperation\x12\\\n" +
+	"\rTFApprovePlan\x12#.TerraformStation.TFPlanReviewInput\x1a&.TerraformStation.TFPlanApprovalStatus\x12[\n" +
+	"\fTFRejectPlan\x12#.TerraformStation.TFPlanReviewInput\x1a&.TerraformStation.TFPlanApprovalStatus\x12b\n" +
+	"\x11TFGetPlanApproval\x12%.TerraformStation.TFPlanApprovalInput\x1a&.TerraformStation.TFPlanApprovalStatus\x12J\n" +
+	"\vTFSubmitJob\x12\".TerraformStation.TFSubmitJobInput\x1a\x17.TerraformStation.TFJob\x12A\n" +
+	"\bTFGetJob\x12\x1c.TerraformStation.TFJobInput\x1a\x17.TerraformStation.TFJob\x12L\n" +
+	"\n" +
+	"TFListJobs\x12!.TerraformStation.TFListJobsInput\x1a\x1b.TerraformStation.TFJobList\x12S\n" +
+	"\x0eTFGetJobOutput\x12\".TerraformStation.TFJobOutputInput\x1a\x1d.TerraformStation.TFJobOutput\x12D\n" +
+	"\vTFCancelJob\x12\x1c.TerraformStation.TFJobInput\x1a\x17.TerraformStation.TFJob\x12O\n" +
+	"\vTFListLocks\x12\".TerraformStation.TFListLocksInput\x1a\x1c.TerraformStation.TFLockList\x12O\n" +
+	"\rTFForceUnlock\x12$.TerraformStation.TFForceUnlockInput\x1a\x18.TerraformStation.TFLock\x12c\n" +
+	"\x13TFListStateVersions\x12&.TerraformStation.TFStateVersionsInput\x1a$.TerraformStation.TFStateVersionList\x12X\n" +
+	"\x13TFDiffStateVersions\x12\".TerraformStation.TFStateDiffInput\x1a\x1d.TerraformStation.TFStateDiff\x12[\n" +
+	"\x0fTFRollbackState\x12&.TerraformStation.TFStateRollbackInput\x1a .TerraformStation.TFStateVersion\x12P\n" +
+	"\rTFDetectDrift\x12\x1e.TerraformStation.TFDriftInput\x1a\x1f.TerraformStation.TFDriftReport\x12d\n" +
+	"\x12TFListDriftReports\x12).TerraformStation.TFListDriftReportsInput\x1a#.TerraformStation.TFDriftReportList\x12Y\n" +
+	"\x10TFGetDriftReport\x12$.TerraformStation.TFDriftReportInput\x1a\x1f.TerraformStation.TFDriftReport\x12Y\n" +
+	"\x10TFListWorkspaces\x12\".TerraformStation.TFWorkspaceInput\x1a!.TerraformStation.TFWorkspaceList\x12T\n" +
+	"\x0fTFShowWorkspace\x12\".TerraformStation.TFWorkspaceInput\x1a\x1d.TerraformStation.TFWorkspace\x12S\n" +
+	"\x0eTFNewWorkspace\x12\".TerraformStation.TFWorkspaceInput\x1a\x1d.TerraformStation.TFWorkspace\x12V\n" +
+	"\x11TFSelectWorkspace\x12\".TerraformStation.TFWorkspaceInput\x1a\x1d.TerraformStation.TFWorkspace\x12V\n" +
+	"\x11TFDeleteWorkspace\x12\".TerraformStation.TFWorkspaceInput\x1a\x1d.TerraformStation.TFWorkspace\x12M\n" +
+	"\vTFPutSecret\x12\".TerraformStation.TFPutSecretInput\x1a\x1a.TerraformStation.TFSecret\x12U\n" +
+	"\rTFListSecrets\x12$.TerraformStation.TFListSecretsInput\x1a\x1e.TerraformStation.TFSecretList\x12M\n" +
+	"\x0eTFDeleteSecret\x12\x1f.TerraformStation.TFSecretInput\x1a\x1a.TerraformStation.TFSecretB(Z&github.com/ForestMars/TerraformStationb\x06proto3"
 
 var (
 	file_spec_proto_rawDescOnce sync.Once
@@ -520,39 +4967,210 @@ func file_spec_proto_rawDescGZIP() []byte {
 	return file_spec_proto_rawDescData
 }
 
-var file_spec_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_spec_proto_msgTypes = make([]protoimpl.MessageInfo, 60)
 var file_spec_proto_goTypes = []any{
-	(*TFCommandInput)(nil),        // 0: TerraformStation.TFCommandInput
-	(*TFCommandResult)(nil),       // 1: TerraformStation.TFCommandResult
-	(*TFPlanResult)(nil),          // 2: TerraformStation.TFPlanResult
-	(*TFApplyResult)(nil),         // 3: TerraformStation.TFApplyResult
-	(*TFStateInfo)(nil),           // 4: TerraformStation.TFStateInfo
-	nil,                           // 5: TerraformStation.TFCommandInput.VariablesEntry
-	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*TFCommandInput)(nil),          // 0: TerraformStation.TFCommandInput
+	(*TFCommandResult)(nil),         // 1: TerraformStation.TFCommandResult
+	(*TFOperationInput)(nil),        // 2: TerraformStation.TFOperationInput
+	(*TFOperation)(nil),             // 3: TerraformStation.TFOperation
+	(*TFSourcePos)(nil),             // 4: TerraformStation.TFSourcePos
+	(*TFSourceRange)(nil),           // 5: TerraformStation.TFSourceRange
+	(*TFExpressionValue)(nil),       // 6: TerraformStation.TFExpressionValue
+	(*TFDiagnosticSnippet)(nil),     // 7: TerraformStation.TFDiagnosticSnippet
+	(*TFDiagnostic)(nil),            // 8: TerraformStation.TFDiagnostic
+	(*TFResourceChange)(nil),        // 9: TerraformStation.TFResourceChange
+	(*TFPlanResult)(nil),            // 10: TerraformStation.TFPlanResult
+	(*TFResourceApply)(nil),         // 11: TerraformStation.TFResourceApply
+	(*TFApplyResult)(nil),           // 12: TerraformStation.TFApplyResult
+	(*TFStateResource)(nil),         // 13: TerraformStation.TFStateResource
+	(*TFStateOutput)(nil),           // 14: TerraformStation.TFStateOutput
+	(*TFStateInfo)(nil),             // 15: TerraformStation.TFStateInfo
+	(*TFOutputChunk)(nil),           // 16: TerraformStation.TFOutputChunk
+	(*TFChangeSummary)(nil),         // 17: TerraformStation.TFChangeSummary
+	(*TFProgressEvent)(nil),         // 18: TerraformStation.TFProgressEvent
+	(*TFSubscribeInput)(nil),        // 19: TerraformStation.TFSubscribeInput
+	(*TFPlanReviewInput)(nil),       // 20: TerraformStation.TFPlanReviewInput
+	(*TFPlanApprovalInput)(nil),     // 21: TerraformStation.TFPlanApprovalInput
+	(*TFPlanApproval)(nil),          // 22: TerraformStation.TFPlanApproval
+	(*TFPlanApprovalStatus)(nil),    // 23: TerraformStation.TFPlanApprovalStatus
+	(*TFSubmitJobInput)(nil),        // 24: TerraformStation.TFSubmitJobInput
+	(*TFJobInput)(nil),              // 25: TerraformStation.TFJobInput
+	(*TFListJobsInput)(nil),         // 26: TerraformStation.TFListJobsInput
+	(*TFJob)(nil),                   // 27: TerraformStation.TFJob
+	(*TFJobList)(nil),               // 28: TerraformStation.TFJobList
+	(*TFJobOutputInput)(nil),        // 29: TerraformStation.TFJobOutputInput
+	(*TFJobOutput)(nil),             // 30: TerraformStation.TFJobOutput
+	(*TFLock)(nil),                  // 31: TerraformStation.TFLock
+	(*TFListLocksInput)(nil),        // 32: TerraformStation.TFListLocksInput
+	(*TFLockList)(nil),              // 33: TerraformStation.TFLockList
+	(*TFForceUnlockInput)(nil),      // 34: TerraformStation.TFForceUnlockInput
+	(*TFStateVersionsInput)(nil),    // 35: TerraformStation.TFStateVersionsInput
+	(*TFStateVersion)(nil),          // 36: TerraformStation.TFStateVersion
+	(*TFStateVersionList)(nil),      // 37: TerraformStation.TFStateVersionList
+	(*TFStateDiffInput)(nil),        // 38: TerraformStation.TFStateDiffInput
+	(*TFAttributeDiff)(nil),         // 39: TerraformStation.TFAttributeDiff
+	(*TFResourceStateDiff)(nil),     // 40: TerraformStation.TFResourceStateDiff
+	(*TFStateDiff)(nil),             // 41: TerraformStation.TFStateDiff
+	(*TFStateRollbackInput)(nil),    // 42: TerraformStation.TFStateRollbackInput
+	(*TFDriftInput)(nil),            // 43: TerraformStation.TFDriftInput
+	(*TFDriftedResource)(nil),       // 44: TerraformStation.TFDriftedResource
+	(*TFDriftReport)(nil),           // 45: TerraformStation.TFDriftReport
+	(*TFListDriftReportsInput)(nil), // 46: TerraformStation.TFListDriftReportsInput
+	(*TFDriftReportList)(nil),       // 47: TerraformStation.TFDriftReportList
+	(*TFDriftReportInput)(nil),      // 48: TerraformStation.TFDriftReportInput
+	(*TFWorkspaceInput)(nil),        // 49: TerraformStation.TFWorkspaceInput
+	(*TFWorkspace)(nil),             // 50: TerraformStation.TFWorkspace
+	(*TFWorkspaceList)(nil),         // 51: TerraformStation.TFWorkspaceList
+	(*TFPutSecretInput)(nil),        // 52: TerraformStation.TFPutSecretInput
+	(*TFSecretInput)(nil),           // 53: TerraformStation.TFSecretInput
+	(*TFSecret)(nil),                // 54: TerraformStation.TFSecret
+	(*TFListSecretsInput)(nil),      // 55: TerraformStation.TFListSecretsInput
+	(*TFSecretList)(nil),            // 56: TerraformStation.TFSecretList
+	nil,                             // 57: TerraformStation.TFCommandInput.VariablesEntry
+	nil,                             // 58: TerraformStation.TFCommandInput.SecretVariablesEntry
+	nil,                             // 59: TerraformStation.TFCommandInput.SecretEnvEntry
+	(*timestamppb.Timestamp)(nil),   // 60: google.protobuf.Timestamp
+	(*structpb.Value)(nil),          // 61: google.protobuf.Value
+	(*structpb.ListValue)(nil),      // 62: google.protobuf.ListValue
 }
 var file_spec_proto_depIdxs = []int32{
-	5,  // 0: TerraformStation.TFCommandInput.variables:type_name -> TerraformStation.TFCommandInput.VariablesEntry
-	6,  // 1: TerraformStation.TFCommandResult.executed_at:type_name -> google.protobuf.Timestamp
-	6,  // 2: TerraformStation.TFPlanResult.created_at:type_name -> google.protobuf.Timestamp
-	6,  // 3: TerraformStation.TFApplyResult.executed_at:type_name -> google.protobuf.Timestamp
-	6,  // 4: TerraformStation.TFStateInfo.last_updated:type_name -> google.protobuf.Timestamp
-	0,  // 5: TerraformStation.TerraformStationService.TFCommand:input_type -> TerraformStation.TFCommandInput
-	0,  // 6: TerraformStation.TerraformStationService.TFPlan:input_type -> TerraformStation.TFCommandInput
-	0,  // 7: TerraformStation.TerraformStationService.TFApply:input_type -> TerraformStation.TFCommandInput
-	0,  // 8: TerraformStation.TerraformStationService.TFInit:input_type -> TerraformStation.TFCommandInput
-	0,  // 9: TerraformStation.TerraformStationService.TFValidate:input_type -> TerraformStation.TFCommandInput
-	0,  // 10: TerraformStation.TerraformStationService.TFState:input_type -> TerraformStation.TFCommandInput
-	1,  // 11: TerraformStation.TerraformStationService.TFCommand:output_type -> TerraformStation.TFCommandResult
-	2,  // 12: TerraformStation.TerraformStationService.TFPlan:output_type -> TerraformStation.TFPlanResult
-	3,  // 13: TerraformStation.TerraformStationService.TFApply:output_type -> TerraformStation.TFApplyResult
-	1,  // 14: TerraformStation.TerraformStationService.TFInit:output_type -> TerraformStation.TFCommandResult
-	1,  // 15: TerraformStation.TerraformStationService.TFValidate:output_type -> TerraformStation.TFCommandResult
-	4,  // 16: TerraformStation.TerraformStationService.TFState:output_type -> TerraformStation.TFStateInfo
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	57, // 0: TerraformStation.TFCommandInput.variables:type_name -> TerraformStation.TFCommandInput.VariablesEntry
+	58, // 1: TerraformStation.TFCommandInput.secret_variables:type_name -> TerraformStation.TFCommandInput.SecretVariablesEntry
+	59, // 2: TerraformStation.TFCommandInput.secret_env:type_name -> TerraformStation.TFCommandInput.SecretEnvEntry
+	60, // 3: TerraformStation.TFCommandResult.executed_at:type_name -> google.protobuf.Timestamp
+	8,  // 4: TerraformStation.TFCommandResult.diagnostics:type_name -> TerraformStation.TFDiagnostic
+	60, // 5: TerraformStation.TFOperation.started_at:type_name -> google.protobuf.Timestamp
+	60, // 6: TerraformStation.TFOperation.completed_at:type_name -> google.protobuf.Timestamp
+	4,  // 7: TerraformStation.TFSourceRange.start:type_name -> TerraformStation.TFSourcePos
+	4,  // 8: TerraformStation.TFSourceRange.end:type_name -> TerraformStation.TFSourcePos
+	6,  // 9: TerraformStation.TFDiagnosticSnippet.values:type_name -> TerraformStation.TFExpressionValue
+	5,  // 10: TerraformStation.TFDiagnostic.range:type_name -> TerraformStation.TFSourceRange
+	7,  // 11: TerraformStation.TFDiagnostic.snippet:type_name -> TerraformStation.TFDiagnosticSnippet
+	61, // 12: TerraformStation.TFResourceChange.before:type_name -> google.protobuf.Value
+	61, // 13: TerraformStation.TFResourceChange.after:type_name -> google.protobuf.Value
+	62, // 14: TerraformStation.TFResourceChange.replace_paths:type_name -> google.protobuf.ListValue
+	60, // 15: TerraformStation.TFPlanResult.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 16: TerraformStation.TFPlanResult.resource_changes:type_name -> TerraformStation.TFResourceChange
+	60, // 17: TerraformStation.TFPlanResult.approval_expires_at:type_name -> google.protobuf.Timestamp
+	60, // 18: TerraformStation.TFApplyResult.executed_at:type_name -> google.protobuf.Timestamp
+	11, // 19: TerraformStation.TFApplyResult.resource_applies:type_name -> TerraformStation.TFResourceApply
+	61, // 20: TerraformStation.TFStateResource.index:type_name -> google.protobuf.Value
+	61, // 21: TerraformStation.TFStateResource.values:type_name -> google.protobuf.Value
+	61, // 22: TerraformStation.TFStateResource.sensitive_values:type_name -> google.protobuf.Value
+	61, // 23: TerraformStation.TFStateOutput.value:type_name -> google.protobuf.Value
+	61, // 24: TerraformStation.TFStateOutput.type:type_name -> google.protobuf.Value
+	60, // 25: TerraformStation.TFStateInfo.last_updated:type_name -> google.protobuf.Timestamp
+	13, // 26: TerraformStation.TFStateInfo.resources:type_name -> TerraformStation.TFStateResource
+	14, // 27: TerraformStation.TFStateInfo.outputs:type_name -> TerraformStation.TFStateOutput
+	60, // 28: TerraformStation.TFOutputChunk.emitted_at:type_name -> google.protobuf.Timestamp
+	1,  // 29: TerraformStation.TFOutputChunk.result:type_name -> TerraformStation.TFCommandResult
+	17, // 30: TerraformStation.TFProgressEvent.changes:type_name -> TerraformStation.TFChangeSummary
+	8,  // 31: TerraformStation.TFProgressEvent.diagnostic:type_name -> TerraformStation.TFDiagnostic
+	60, // 32: TerraformStation.TFProgressEvent.emitted_at:type_name -> google.protobuf.Timestamp
+	60, // 33: TerraformStation.TFPlanApproval.created_at:type_name -> google.protobuf.Timestamp
+	22, // 34: TerraformStation.TFPlanApprovalStatus.approvals:type_name -> TerraformStation.TFPlanApproval
+	60, // 35: TerraformStation.TFPlanApprovalStatus.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 36: TerraformStation.TFSubmitJobInput.input:type_name -> TerraformStation.TFCommandInput
+	0,  // 37: TerraformStation.TFJob.input:type_name -> TerraformStation.TFCommandInput
+	60, // 38: TerraformStation.TFJob.created_at:type_name -> google.protobuf.Timestamp
+	60, // 39: TerraformStation.TFJob.started_at:type_name -> google.protobuf.Timestamp
+	60, // 40: TerraformStation.TFJob.completed_at:type_name -> google.protobuf.Timestamp
+	1,  // 41: TerraformStation.TFJob.command_result:type_name -> TerraformStation.TFCommandResult
+	10, // 42: TerraformStation.TFJob.plan_result:type_name -> TerraformStation.TFPlanResult
+	12, // 43: TerraformStation.TFJob.apply_result:type_name -> TerraformStation.TFApplyResult
+	15, // 44: TerraformStation.TFJob.state_info:type_name -> TerraformStation.TFStateInfo
+	27, // 45: TerraformStation.TFJobList.jobs:type_name -> TerraformStation.TFJob
+	16, // 46: TerraformStation.TFJobOutput.chunks:type_name -> TerraformStation.TFOutputChunk
+	60, // 47: TerraformStation.TFLock.acquired_at:type_name -> google.protobuf.Timestamp
+	60, // 48: TerraformStation.TFLock.heartbeat_at:type_name -> google.protobuf.Timestamp
+	31, // 49: TerraformStation.TFLockList.locks:type_name -> TerraformStation.TFLock
+	60, // 50: TerraformStation.TFStateVersion.created_at:type_name -> google.protobuf.Timestamp
+	36, // 51: TerraformStation.TFStateVersionList.versions:type_name -> TerraformStation.TFStateVersion
+	61, // 52: TerraformStation.TFAttributeDiff.before:type_name -> google.protobuf.Value
+	61, // 53: TerraformStation.TFAttributeDiff.after:type_name -> google.protobuf.Value
+	39, // 54: TerraformStation.TFResourceStateDiff.attributes:type_name -> TerraformStation.TFAttributeDiff
+	40, // 55: TerraformStation.TFStateDiff.resources:type_name -> TerraformStation.TFResourceStateDiff
+	39, // 56: TerraformStation.TFDriftedResource.attributes:type_name -> TerraformStation.TFAttributeDiff
+	44, // 57: TerraformStation.TFDriftReport.resources:type_name -> TerraformStation.TFDriftedResource
+	60, // 58: TerraformStation.TFDriftReport.created_at:type_name -> google.protobuf.Timestamp
+	60, // 59: TerraformStation.TFDriftReport.completed_at:type_name -> google.protobuf.Timestamp
+	45, // 60: TerraformStation.TFDriftReportList.reports:type_name -> TerraformStation.TFDriftReport
+	60, // 61: TerraformStation.TFSecret.created_at:type_name -> google.protobuf.Timestamp
+	60, // 62: TerraformStation.TFSecret.updated_at:type_name -> google.protobuf.Timestamp
+	54, // 63: TerraformStation.TFSecretList.secrets:type_name -> TerraformStation.TFSecret
+	0,  // 64: TerraformStation.TerraformStationService.TFCommand:input_type -> TerraformStation.TFCommandInput
+	0,  // 65: TerraformStation.TerraformStationService.TFPlan:input_type -> TerraformStation.TFCommandInput
+	0,  // 66: TerraformStation.TerraformStationService.TFApply:input_type -> TerraformStation.TFCommandInput
+	0,  // 67: TerraformStation.TerraformStationService.TFInit:input_type -> TerraformStation.TFCommandInput
+	0,  // 68: TerraformStation.TerraformStationService.TFValidate:input_type -> TerraformStation.TFCommandInput
+	0,  // 69: TerraformStation.TerraformStationService.TFState:input_type -> TerraformStation.TFCommandInput
+	0,  // 70: TerraformStation.TerraformStationService.TFCommandStream:input_type -> TerraformStation.TFCommandInput
+	19, // 71: TerraformStation.TerraformStationService.TFSubscribeOutput:input_type -> TerraformStation.TFSubscribeInput
+	19, // 72: TerraformStation.TerraformStationService.TFSubscribeProgress:input_type -> TerraformStation.TFSubscribeInput
+	2,  // 73: TerraformStation.TerraformStationService.TFCancelOperation:input_type -> TerraformStation.TFOperationInput
+	20, // 74: TerraformStation.TerraformStationService.TFApprovePlan:input_type -> TerraformStation.TFPlanReviewInput
+	20, // 75: TerraformStation.TerraformStationService.TFRejectPlan:input_type -> TerraformStation.TFPlanReviewInput
+	21, // 76: TerraformStation.TerraformStationService.TFGetPlanApproval:input_type -> TerraformStation.TFPlanApprovalInput
+	24, // 77: TerraformStation.TerraformStationService.TFSubmitJob:input_type -> TerraformStation.TFSubmitJobInput
+	25, // 78: TerraformStation.TerraformStationService.TFGetJob:input_type -> TerraformStation.TFJobInput
+	26, // 79: TerraformStation.TerraformStationService.TFListJobs:input_type -> TerraformStation.TFListJobsInput
+	29, // 80: TerraformStation.TerraformStationService.TFGetJobOutput:input_type -> TerraformStation.TFJobOutputInput
+	25, // 81: TerraformStation.TerraformStationService.TFCancelJob:input_type -> TerraformStation.TFJobInput
+	32, // 82: TerraformStation.TerraformStationService.TFListLocks:input_type -> TerraformStation.TFListLocksInput
+	34, // 83: TerraformStation.TerraformStationService.TFForceUnlock:input_type -> TerraformStation.TFForceUnlockInput
+	35, // 84: TerraformStation.TerraformStationService.TFListStateVersions:input_type -> TerraformStation.TFStateVersionsInput
+	38, // 85: TerraformStation.TerraformStationService.TFDiffStateVersions:input_type -> TerraformStation.TFStateDiffInput
+	42, // 86: TerraformStation.TerraformStationService.TFRollbackState:input_type -> TerraformStation.TFStateRollbackInput
+	43, // 87: TerraformStation.TerraformStationService.TFDetectDrift:input_type -> TerraformStation.TFDriftInput
+	46, // 88: TerraformStation.TerraformStationService.TFListDriftReports:input_type -> TerraformStation.TFListDriftReportsInput
+	48, // 89: TerraformStation.TerraformStationService.TFGetDriftReport:input_type -> TerraformStation.TFDriftReportInput
+	49, // 90: TerraformStation.TerraformStationService.TFListWorkspaces:input_type -> TerraformStation.TFWorkspaceInput
+	49, // 91: TerraformStation.TerraformStationService.TFShowWorkspace:input_type -> TerraformStation.TFWorkspaceInput
+	49, // 92: TerraformStation.TerraformStationService.TFNewWorkspace:input_type -> TerraformStation.TFWorkspaceInput
+	49, // 93: TerraformStation.TerraformStationService.TFSelectWorkspace:input_type -> TerraformStation.TFWorkspaceInput
+	49, // 94: TerraformStation.TerraformStationService.TFDeleteWorkspace:input_type -> TerraformStation.TFWorkspaceInput
+	52, // 95: TerraformStation.TerraformStationService.TFPutSecret:input_type -> TerraformStation.TFPutSecretInput
+	55, // 96: TerraformStation.TerraformStationService.TFListSecrets:input_type -> TerraformStation.TFListSecretsInput
+	53, // 97: TerraformStation.TerraformStationService.TFDeleteSecret:input_type -> TerraformStation.TFSecretInput
+	1,  // 98: TerraformStation.TerraformStationService.TFCommand:output_type -> TerraformStation.TFCommandResult
+	10, // 99: TerraformStation.TerraformStationService.TFPlan:output_type -> TerraformStation.TFPlanResult
+	12, // 100: TerraformStation.TerraformStationService.TFApply:output_type -> TerraformStation.TFApplyResult
+	1,  // 101: TerraformStation.TerraformStationService.TFInit:output_type -> TerraformStation.TFCommandResult
+	1,  // 102: TerraformStation.TerraformStationService.TFValidate:output_type -> TerraformStation.TFCommandResult
+	15, // 103: TerraformStation.TerraformStationService.TFState:output_type -> TerraformStation.TFStateInfo
+	16, // 104: TerraformStation.TerraformStationService.TFCommandStream:output_type -> TerraformStation.TFOutputChunk
+	16, // 105: TerraformStation.TerraformStationService.TFSubscribeOutput:output_type -> TerraformStation.TFOutputChunk
+	18, // 106: TerraformStation.TerraformStationService.TFSubscribeProgress:output_type -> TerraformStation.TFProgressEvent
+	3,  // 107: TerraformStation.TerraformStationService.TFCancelOperation:output_type -> TerraformStation.TFOperation
+	23, // 108: TerraformStation.TerraformStationService.TFApprovePlan:output_type -> TerraformStation.TFPlanApprovalStatus
+	23, // 109: TerraformStation.TerraformStationService.TFRejectPlan:output_type -> TerraformStation.TFPlanApprovalStatus
+	23, // 110: TerraformStation.TerraformStationService.TFGetPlanApproval:output_type -> TerraformStation.TFPlanApprovalStatus
+	27, // 111: TerraformStation.TerraformStationService.TFSubmitJob:output_type -> TerraformStation.TFJob
+	27, // 112: TerraformStation.TerraformStationService.TFGetJob:output_type -> TerraformStation.TFJob
+	28, // 113: TerraformStation.TerraformStationService.TFListJobs:output_type -> TerraformStation.TFJobList
+	30, // 114: TerraformStation.TerraformStationService.TFGetJobOutput:output_type -> TerraformStation.TFJobOutput
+	27, // 115: TerraformStation.TerraformStationService.TFCancelJob:output_type -> TerraformStation.TFJob
+	33, // 116: TerraformStation.TerraformStationService.TFListLocks:output_type -> TerraformStation.TFLockList
+	31, // 117: TerraformStation.TerraformStationService.TFForceUnlock:output_type -> TerraformStation.TFLock
+	37, // 118: TerraformStation.TerraformStationService.TFListStateVersions:output_type -> TerraformStation.TFStateVersionList
+	41, // 119: TerraformStation.TerraformStationService.TFDiffStateVersions:output_type -> TerraformStation.TFStateDiff
+	36, // 120: TerraformStation.TerraformStationService.TFRollbackState:output_type -> TerraformStation.TFStateVersion
+	45, // 121: TerraformStation.TerraformStationService.TFDetectDrift:output_type -> TerraformStation.TFDriftReport
+	47, // 122: TerraformStation.TerraformStationService.TFListDriftReports:output_type -> TerraformStation.TFDriftReportList
+	45, // 123: TerraformStation.TerraformStationService.TFGetDriftReport:output_type -> TerraformStation.TFDriftReport
+	51, // 124: TerraformStation.TerraformStationService.TFListWorkspaces:output_type -> TerraformStation.TFWorkspaceList
+	50, // 125: TerraformStation.TerraformStationService.TFShowWorkspace:output_type -> TerraformStation.TFWorkspace
+	50, // 126: TerraformStation.TerraformStationService.TFNewWorkspace:output_type -> TerraformStation.TFWorkspace
+	50, // 127: TerraformStation.TerraformStationService.TFSelectWorkspace:output_type -> TerraformStation.TFWorkspace
+	50, // 128: TerraformStation.TerraformStationService.TFDeleteWorkspace:output_type -> TerraformStation.TFWorkspace
+	54, // 129: TerraformStation.TerraformStationService.TFPutSecret:output_type -> TerraformStation.TFSecret
+	56, // 130: TerraformStation.TerraformStationService.TFListSecrets:output_type -> TerraformStation.TFSecretList
+	54, // 131: TerraformStation.TerraformStationService.TFDeleteSecret:output_type -> TerraformStation.TFSecret
+	98, // [98:132] is the sub-list for method output_type
+	64, // [64:98] is the sub-list for method input_type
+	64, // [64:64] is the sub-list for extension type_name
+	64, // [64:64] is the sub-list for extension extendee
+	0,  // [0:64] is the sub-list for field type_name
 }
 
 func init() { file_spec_proto_init() }
@@ -560,13 +5178,19 @@ func file_spec_proto_init() {
 	if File_spec_proto != nil {
 		return
 	}
+	file_spec_proto_msgTypes[27].OneofWrappers = []any{
+		(*TFJob_CommandResult)(nil),
+		(*TFJob_PlanResult)(nil),
+		(*TFJob_ApplyResult)(nil),
+		(*TFJob_StateInfo)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spec_proto_rawDesc), len(file_spec_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   60,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
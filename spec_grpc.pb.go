@@ -0,0 +1,1390 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: spec.proto
+
+package TerraformStation
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TerraformStationService_TFCommand_FullMethodName           = "/TerraformStation.TerraformStationService/TFCommand"
+	TerraformStationService_TFPlan_FullMethodName              = "/TerraformStation.TerraformStationService/TFPlan"
+	TerraformStationService_TFApply_FullMethodName             = "/TerraformStation.TerraformStationService/TFApply"
+	TerraformStationService_TFInit_FullMethodName              = "/TerraformStation.TerraformStationService/TFInit"
+	TerraformStationService_TFValidate_FullMethodName          = "/TerraformStation.TerraformStationService/TFValidate"
+	TerraformStationService_TFState_FullMethodName             = "/TerraformStation.TerraformStationService/TFState"
+	TerraformStationService_TFCommandStream_FullMethodName     = "/TerraformStation.TerraformStationService/TFCommandStream"
+	TerraformStationService_TFSubscribeOutput_FullMethodName   = "/TerraformStation.TerraformStationService/TFSubscribeOutput"
+	TerraformStationService_TFSubscribeProgress_FullMethodName = "/TerraformStation.TerraformStationService/TFSubscribeProgress"
+	TerraformStationService_TFCancelOperation_FullMethodName   = "/TerraformStation.TerraformStationService/TFCancelOperation"
+	TerraformStationService_TFApprovePlan_FullMethodName       = "/TerraformStation.TerraformStationService/TFApprovePlan"
+	TerraformStationService_TFRejectPlan_FullMethodName        = "/TerraformStation.TerraformStationService/TFRejectPlan"
+	TerraformStationService_TFGetPlanApproval_FullMethodName   = "/TerraformStation.TerraformStationService/TFGetPlanApproval"
+	TerraformStationService_TFSubmitJob_FullMethodName         = "/TerraformStation.TerraformStationService/TFSubmitJob"
+	TerraformStationService_TFGetJob_FullMethodName            = "/TerraformStation.TerraformStationService/TFGetJob"
+	TerraformStationService_TFListJobs_FullMethodName          = "/TerraformStation.TerraformStationService/TFListJobs"
+	TerraformStationService_TFGetJobOutput_FullMethodName      = "/TerraformStation.TerraformStationService/TFGetJobOutput"
+	TerraformStationService_TFCancelJob_FullMethodName         = "/TerraformStation.TerraformStationService/TFCancelJob"
+	TerraformStationService_TFListLocks_FullMethodName         = "/TerraformStation.TerraformStationService/TFListLocks"
+	TerraformStationService_TFForceUnlock_FullMethodName       = "/TerraformStation.TerraformStationService/TFForceUnlock"
+	TerraformStationService_TFListStateVersions_FullMethodName = "/TerraformStation.TerraformStationService/TFListStateVersions"
+	TerraformStationService_TFDiffStateVersions_FullMethodName = "/TerraformStation.TerraformStationService/TFDiffStateVersions"
+	TerraformStationService_TFRollbackState_FullMethodName     = "/TerraformStation.TerraformStationService/TFRollbackState"
+	TerraformStationService_TFDetectDrift_FullMethodName       = "/TerraformStation.TerraformStationService/TFDetectDrift"
+	TerraformStationService_TFListDriftReports_FullMethodName  = "/TerraformStation.TerraformStationService/TFListDriftReports"
+	TerraformStationService_TFGetDriftReport_FullMethodName    = "/TerraformStation.TerraformStationService/TFGetDriftReport"
+	TerraformStationService_TFListWorkspaces_FullMethodName    = "/TerraformStation.TerraformStationService/TFListWorkspaces"
+	TerraformStationService_TFShowWorkspace_FullMethodName     = "/TerraformStation.TerraformStationService/TFShowWorkspace"
+	TerraformStationService_TFNewWorkspace_FullMethodName      = "/TerraformStation.TerraformStationService/TFNewWorkspace"
+	TerraformStationService_TFSelectWorkspace_FullMethodName   = "/TerraformStation.TerraformStationService/TFSelectWorkspace"
+	TerraformStationService_TFDeleteWorkspace_FullMethodName   = "/TerraformStation.TerraformStationService/TFDeleteWorkspace"
+	TerraformStationService_TFPutSecret_FullMethodName         = "/TerraformStation.TerraformStationService/TFPutSecret"
+	TerraformStationService_TFListSecrets_FullMethodName       = "/TerraformStation.TerraformStationService/TFListSecrets"
+	TerraformStationService_TFDeleteSecret_FullMethodName      = "/TerraformStation.TerraformStationService/TFDeleteSecret"
+)
+
+// TerraformStationServiceClient is the client API for TerraformStationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Service definition
+type TerraformStationServiceClient interface {
+	TFCommand(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFCommandResult, error)
+	TFPlan(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFPlanResult, error)
+	TFApply(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFApplyResult, error)
+	TFInit(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFCommandResult, error)
+	TFValidate(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFCommandResult, error)
+	TFState(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFStateInfo, error)
+	TFCommandStream(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TFOutputChunk], error)
+	TFSubscribeOutput(ctx context.Context, in *TFSubscribeInput, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TFOutputChunk], error)
+	TFSubscribeProgress(ctx context.Context, in *TFSubscribeInput, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TFProgressEvent], error)
+	TFCancelOperation(ctx context.Context, in *TFOperationInput, opts ...grpc.CallOption) (*TFOperation, error)
+	TFApprovePlan(ctx context.Context, in *TFPlanReviewInput, opts ...grpc.CallOption) (*TFPlanApprovalStatus, error)
+	TFRejectPlan(ctx context.Context, in *TFPlanReviewInput, opts ...grpc.CallOption) (*TFPlanApprovalStatus, error)
+	TFGetPlanApproval(ctx context.Context, in *TFPlanApprovalInput, opts ...grpc.CallOption) (*TFPlanApprovalStatus, error)
+	TFSubmitJob(ctx context.Context, in *TFSubmitJobInput, opts ...grpc.CallOption) (*TFJob, error)
+	TFGetJob(ctx context.Context, in *TFJobInput, opts ...grpc.CallOption) (*TFJob, error)
+	TFListJobs(ctx context.Context, in *TFListJobsInput, opts ...grpc.CallOption) (*TFJobList, error)
+	TFGetJobOutput(ctx context.Context, in *TFJobOutputInput, opts ...grpc.CallOption) (*TFJobOutput, error)
+	TFCancelJob(ctx context.Context, in *TFJobInput, opts ...grpc.CallOption) (*TFJob, error)
+	TFListLocks(ctx context.Context, in *TFListLocksInput, opts ...grpc.CallOption) (*TFLockList, error)
+	TFForceUnlock(ctx context.Context, in *TFForceUnlockInput, opts ...grpc.CallOption) (*TFLock, error)
+	TFListStateVersions(ctx context.Context, in *TFStateVersionsInput, opts ...grpc.CallOption) (*TFStateVersionList, error)
+	TFDiffStateVersions(ctx context.Context, in *TFStateDiffInput, opts ...grpc.CallOption) (*TFStateDiff, error)
+	TFRollbackState(ctx context.Context, in *TFStateRollbackInput, opts ...grpc.CallOption) (*TFStateVersion, error)
+	TFDetectDrift(ctx context.Context, in *TFDriftInput, opts ...grpc.CallOption) (*TFDriftReport, error)
+	TFListDriftReports(ctx context.Context, in *TFListDriftReportsInput, opts ...grpc.CallOption) (*TFDriftReportList, error)
+	TFGetDriftReport(ctx context.Context, in *TFDriftReportInput, opts ...grpc.CallOption) (*TFDriftReport, error)
+	TFListWorkspaces(ctx context.Context, in *TFWorkspaceInput, opts ...grpc.CallOption) (*TFWorkspaceList, error)
+	TFShowWorkspace(ctx context.Context, in *TFWorkspaceInput, opts ...grpc.CallOption) (*TFWorkspace, error)
+	TFNewWorkspace(ctx context.Context, in *TFWorkspaceInput, opts ...grpc.CallOption) (*TFWorkspace, error)
+	TFSelectWorkspace(ctx context.Context, in *TFWorkspaceInput, opts ...grpc.CallOption) (*TFWorkspace, error)
+	TFDeleteWorkspace(ctx context.Context, in *TFWorkspaceInput, opts ...grpc.CallOption) (*TFWorkspace, error)
+	TFPutSecret(ctx context.Context, in *TFPutSecretInput, opts ...grpc.CallOption) (*TFSecret, error)
+	TFListSecrets(ctx context.Context, in *TFListSecretsInput, opts ...grpc.CallOption) (*TFSecretList, error)
+	TFDeleteSecret(ctx context.Context, in *TFSecretInput, opts ...grpc.CallOption) (*TFSecret, error)
+}
+
+type terraformStationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTerraformStationServiceClient(cc grpc.ClientConnInterface) TerraformStationServiceClient {
+	return &terraformStationServiceClient{cc}
+}
+
+func (c *terraformStationServiceClient) TFCommand(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFCommandResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFCommandResult)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFPlan(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFPlanResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFPlanResult)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFApply(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFApplyResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFApplyResult)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFApply_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFInit(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFCommandResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFCommandResult)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFInit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFValidate(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFCommandResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFCommandResult)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFValidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFState(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (*TFStateInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFStateInfo)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFCommandStream(ctx context.Context, in *TFCommandInput, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TFOutputChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TerraformStationService_ServiceDesc.Streams[0], TerraformStationService_TFCommandStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TFCommandInput, TFOutputChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TerraformStationService_TFCommandStreamClient = grpc.ServerStreamingClient[TFOutputChunk]
+
+func (c *terraformStationServiceClient) TFSubscribeOutput(ctx context.Context, in *TFSubscribeInput, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TFOutputChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TerraformStationService_ServiceDesc.Streams[1], TerraformStationService_TFSubscribeOutput_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TFSubscribeInput, TFOutputChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TerraformStationService_TFSubscribeOutputClient = grpc.ServerStreamingClient[TFOutputChunk]
+
+func (c *terraformStationServiceClient) TFSubscribeProgress(ctx context.Context, in *TFSubscribeInput, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TFProgressEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TerraformStationService_ServiceDesc.Streams[2], TerraformStationService_TFSubscribeProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TFSubscribeInput, TFProgressEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TerraformStationService_TFSubscribeProgressClient = grpc.ServerStreamingClient[TFProgressEvent]
+
+func (c *terraformStationServiceClient) TFCancelOperation(ctx context.Context, in *TFOperationInput, opts ...grpc.CallOption) (*TFOperation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFOperation)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFCancelOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFApprovePlan(ctx context.Context, in *TFPlanReviewInput, opts ...grpc.CallOption) (*TFPlanApprovalStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFPlanApprovalStatus)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFApprovePlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFRejectPlan(ctx context.Context, in *TFPlanReviewInput, opts ...grpc.CallOption) (*TFPlanApprovalStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFPlanApprovalStatus)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFRejectPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFGetPlanApproval(ctx context.Context, in *TFPlanApprovalInput, opts ...grpc.CallOption) (*TFPlanApprovalStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFPlanApprovalStatus)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFGetPlanApproval_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFSubmitJob(ctx context.Context, in *TFSubmitJobInput, opts ...grpc.CallOption) (*TFJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFJob)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFSubmitJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFGetJob(ctx context.Context, in *TFJobInput, opts ...grpc.CallOption) (*TFJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFJob)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFGetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFListJobs(ctx context.Context, in *TFListJobsInput, opts ...grpc.CallOption) (*TFJobList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFJobList)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFListJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFGetJobOutput(ctx context.Context, in *TFJobOutputInput, opts ...grpc.CallOption) (*TFJobOutput, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFJobOutput)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFGetJobOutput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFCancelJob(ctx context.Context, in *TFJobInput, opts ...grpc.CallOption) (*TFJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFJob)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFCancelJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFListLocks(ctx context.Context, in *TFListLocksInput, opts ...grpc.CallOption) (*TFLockList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFLockList)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFListLocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFForceUnlock(ctx context.Context, in *TFForceUnlockInput, opts ...grpc.CallOption) (*TFLock, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFLock)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFForceUnlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFListStateVersions(ctx context.Context, in *TFStateVersionsInput, opts ...grpc.CallOption) (*TFStateVersionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFStateVersionList)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFListStateVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFDiffStateVersions(ctx context.Context, in *TFStateDiffInput, opts ...grpc.CallOption) (*TFStateDiff, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFStateDiff)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFDiffStateVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFRollbackState(ctx context.Context, in *TFStateRollbackInput, opts ...grpc.CallOption) (*TFStateVersion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFStateVersion)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFRollbackState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFDetectDrift(ctx context.Context, in *TFDriftInput, opts ...grpc.CallOption) (*TFDriftReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFDriftReport)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFDetectDrift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFListDriftReports(ctx context.Context, in *TFListDriftReportsInput, opts ...grpc.CallOption) (*TFDriftReportList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFDriftReportList)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFListDriftReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFGetDriftReport(ctx context.Context, in *TFDriftReportInput, opts ...grpc.CallOption) (*TFDriftReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFDriftReport)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFGetDriftReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFListWorkspaces(ctx context.Context, in *TFWorkspaceInput, opts ...grpc.CallOption) (*TFWorkspaceList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFWorkspaceList)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFListWorkspaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFShowWorkspace(ctx context.Context, in *TFWorkspaceInput, opts ...grpc.CallOption) (*TFWorkspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFWorkspace)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFShowWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFNewWorkspace(ctx context.Context, in *TFWorkspaceInput, opts ...grpc.CallOption) (*TFWorkspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFWorkspace)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFNewWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFSelectWorkspace(ctx context.Context, in *TFWorkspaceInput, opts ...grpc.CallOption) (*TFWorkspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFWorkspace)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFSelectWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFDeleteWorkspace(ctx context.Context, in *TFWorkspaceInput, opts ...grpc.CallOption) (*TFWorkspace, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFWorkspace)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFDeleteWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFPutSecret(ctx context.Context, in *TFPutSecretInput, opts ...grpc.CallOption) (*TFSecret, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFSecret)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFPutSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFListSecrets(ctx context.Context, in *TFListSecretsInput, opts ...grpc.CallOption) (*TFSecretList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFSecretList)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFListSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *terraformStationServiceClient) TFDeleteSecret(ctx context.Context, in *TFSecretInput, opts ...grpc.CallOption) (*TFSecret, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TFSecret)
+	err := c.cc.Invoke(ctx, TerraformStationService_TFDeleteSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TerraformStationServiceServer is the server API for TerraformStationService service.
+// All implementations must embed UnimplementedTerraformStationServiceServer
+// for forward compatibility.
+//
+// Service definition
+type TerraformStationServiceServer interface {
+	TFCommand(context.Context, *TFCommandInput) (*TFCommandResult, error)
+	TFPlan(context.Context, *TFCommandInput) (*TFPlanResult, error)
+	TFApply(context.Context, *TFCommandInput) (*TFApplyResult, error)
+	TFInit(context.Context, *TFCommandInput) (*TFCommandResult, error)
+	TFValidate(context.Context, *TFCommandInput) (*TFCommandResult, error)
+	TFState(context.Context, *TFCommandInput) (*TFStateInfo, error)
+	TFCommandStream(*TFCommandInput, grpc.ServerStreamingServer[TFOutputChunk]) error
+	TFSubscribeOutput(*TFSubscribeInput, grpc.ServerStreamingServer[TFOutputChunk]) error
+	TFSubscribeProgress(*TFSubscribeInput, grpc.ServerStreamingServer[TFProgressEvent]) error
+	TFCancelOperation(context.Context, *TFOperationInput) (*TFOperation, error)
+	TFApprovePlan(context.Context, *TFPlanReviewInput) (*TFPlanApprovalStatus, error)
+	TFRejectPlan(context.Context, *TFPlanReviewInput) (*TFPlanApprovalStatus, error)
+	TFGetPlanApproval(context.Context, *TFPlanApprovalInput) (*TFPlanApprovalStatus, error)
+	TFSubmitJob(context.Context, *TFSubmitJobInput) (*TFJob, error)
+	TFGetJob(context.Context, *TFJobInput) (*TFJob, error)
+	TFListJobs(context.Context, *TFListJobsInput) (*TFJobList, error)
+	TFGetJobOutput(context.Context, *TFJobOutputInput) (*TFJobOutput, error)
+	TFCancelJob(context.Context, *TFJobInput) (*TFJob, error)
+	TFListLocks(context.Context, *TFListLocksInput) (*TFLockList, error)
+	TFForceUnlock(context.Context, *TFForceUnlockInput) (*TFLock, error)
+	TFListStateVersions(context.Context, *TFStateVersionsInput) (*TFStateVersionList, error)
+	TFDiffStateVersions(context.Context, *TFStateDiffInput) (*TFStateDiff, error)
+	TFRollbackState(context.Context, *TFStateRollbackInput) (*TFStateVersion, error)
+	TFDetectDrift(context.Context, *TFDriftInput) (*TFDriftReport, error)
+	TFListDriftReports(context.Context, *TFListDriftReportsInput) (*TFDriftReportList, error)
+	TFGetDriftReport(context.Context, *TFDriftReportInput) (*TFDriftReport, error)
+	TFListWorkspaces(context.Context, *TFWorkspaceInput) (*TFWorkspaceList, error)
+	TFShowWorkspace(context.Context, *TFWorkspaceInput) (*TFWorkspace, error)
+	TFNewWorkspace(context.Context, *TFWorkspaceInput) (*TFWorkspace, error)
+	TFSelectWorkspace(context.Context, *TFWorkspaceInput) (*TFWorkspace, error)
+	TFDeleteWorkspace(context.Context, *TFWorkspaceInput) (*TFWorkspace, error)
+	TFPutSecret(context.Context, *TFPutSecretInput) (*TFSecret, error)
+	TFListSecrets(context.Context, *TFListSecretsInput) (*TFSecretList, error)
+	TFDeleteSecret(context.Context, *TFSecretInput) (*TFSecret, error)
+	mustEmbedUnimplementedTerraformStationServiceServer()
+}
+
+// UnimplementedTerraformStationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTerraformStationServiceServer struct{}
+
+func (UnimplementedTerraformStationServiceServer) TFCommand(context.Context, *TFCommandInput) (*TFCommandResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFCommand not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFPlan(context.Context, *TFCommandInput) (*TFPlanResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFPlan not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFApply(context.Context, *TFCommandInput) (*TFApplyResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFApply not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFInit(context.Context, *TFCommandInput) (*TFCommandResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFInit not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFValidate(context.Context, *TFCommandInput) (*TFCommandResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFValidate not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFState(context.Context, *TFCommandInput) (*TFStateInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFState not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFCommandStream(*TFCommandInput, grpc.ServerStreamingServer[TFOutputChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method TFCommandStream not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFSubscribeOutput(*TFSubscribeInput, grpc.ServerStreamingServer[TFOutputChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method TFSubscribeOutput not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFSubscribeProgress(*TFSubscribeInput, grpc.ServerStreamingServer[TFProgressEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method TFSubscribeProgress not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFCancelOperation(context.Context, *TFOperationInput) (*TFOperation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFCancelOperation not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFApprovePlan(context.Context, *TFPlanReviewInput) (*TFPlanApprovalStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFApprovePlan not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFRejectPlan(context.Context, *TFPlanReviewInput) (*TFPlanApprovalStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFRejectPlan not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFGetPlanApproval(context.Context, *TFPlanApprovalInput) (*TFPlanApprovalStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFGetPlanApproval not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFSubmitJob(context.Context, *TFSubmitJobInput) (*TFJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFSubmitJob not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFGetJob(context.Context, *TFJobInput) (*TFJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFGetJob not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFListJobs(context.Context, *TFListJobsInput) (*TFJobList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFListJobs not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFGetJobOutput(context.Context, *TFJobOutputInput) (*TFJobOutput, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFGetJobOutput not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFCancelJob(context.Context, *TFJobInput) (*TFJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFCancelJob not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFListLocks(context.Context, *TFListLocksInput) (*TFLockList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFListLocks not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFForceUnlock(context.Context, *TFForceUnlockInput) (*TFLock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFForceUnlock not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFListStateVersions(context.Context, *TFStateVersionsInput) (*TFStateVersionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFListStateVersions not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFDiffStateVersions(context.Context, *TFStateDiffInput) (*TFStateDiff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFDiffStateVersions not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFRollbackState(context.Context, *TFStateRollbackInput) (*TFStateVersion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFRollbackState not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFDetectDrift(context.Context, *TFDriftInput) (*TFDriftReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFDetectDrift not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFListDriftReports(context.Context, *TFListDriftReportsInput) (*TFDriftReportList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFListDriftReports not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFGetDriftReport(context.Context, *TFDriftReportInput) (*TFDriftReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFGetDriftReport not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFListWorkspaces(context.Context, *TFWorkspaceInput) (*TFWorkspaceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFListWorkspaces not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFShowWorkspace(context.Context, *TFWorkspaceInput) (*TFWorkspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFShowWorkspace not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFNewWorkspace(context.Context, *TFWorkspaceInput) (*TFWorkspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFNewWorkspace not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFSelectWorkspace(context.Context, *TFWorkspaceInput) (*TFWorkspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFSelectWorkspace not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFDeleteWorkspace(context.Context, *TFWorkspaceInput) (*TFWorkspace, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFDeleteWorkspace not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFPutSecret(context.Context, *TFPutSecretInput) (*TFSecret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFPutSecret not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFListSecrets(context.Context, *TFListSecretsInput) (*TFSecretList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFListSecrets not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) TFDeleteSecret(context.Context, *TFSecretInput) (*TFSecret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TFDeleteSecret not implemented")
+}
+func (UnimplementedTerraformStationServiceServer) mustEmbedUnimplementedTerraformStationServiceServer() {
+}
+func (UnimplementedTerraformStationServiceServer) testEmbeddedByValue() {}
+
+// UnsafeTerraformStationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TerraformStationServiceServer will
+// result in compilation errors.
+type UnsafeTerraformStationServiceServer interface {
+	mustEmbedUnimplementedTerraformStationServiceServer()
+}
+
+func RegisterTerraformStationServiceServer(s grpc.ServiceRegistrar, srv TerraformStationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTerraformStationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TerraformStationService_ServiceDesc, srv)
+}
+
+func _TerraformStationService_TFCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFCommandInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFCommand(ctx, req.(*TFCommandInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFCommandInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFPlan(ctx, req.(*TFCommandInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFApply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFCommandInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFApply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFApply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFApply(ctx, req.(*TFCommandInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFInit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFCommandInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFInit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFInit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFInit(ctx, req.(*TFCommandInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFValidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFCommandInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFValidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFValidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFValidate(ctx, req.(*TFCommandInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFCommandInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFState(ctx, req.(*TFCommandInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFCommandStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TFCommandInput)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerraformStationServiceServer).TFCommandStream(m, &grpc.GenericServerStream[TFCommandInput, TFOutputChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TerraformStationService_TFCommandStreamServer = grpc.ServerStreamingServer[TFOutputChunk]
+
+func _TerraformStationService_TFSubscribeOutput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TFSubscribeInput)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerraformStationServiceServer).TFSubscribeOutput(m, &grpc.GenericServerStream[TFSubscribeInput, TFOutputChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TerraformStationService_TFSubscribeOutputServer = grpc.ServerStreamingServer[TFOutputChunk]
+
+func _TerraformStationService_TFSubscribeProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TFSubscribeInput)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TerraformStationServiceServer).TFSubscribeProgress(m, &grpc.GenericServerStream[TFSubscribeInput, TFProgressEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TerraformStationService_TFSubscribeProgressServer = grpc.ServerStreamingServer[TFProgressEvent]
+
+func _TerraformStationService_TFCancelOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFOperationInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFCancelOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFCancelOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFCancelOperation(ctx, req.(*TFOperationInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFApprovePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFPlanReviewInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFApprovePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFApprovePlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFApprovePlan(ctx, req.(*TFPlanReviewInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFRejectPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFPlanReviewInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFRejectPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFRejectPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFRejectPlan(ctx, req.(*TFPlanReviewInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFGetPlanApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFPlanApprovalInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFGetPlanApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFGetPlanApproval_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFGetPlanApproval(ctx, req.(*TFPlanApprovalInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFSubmitJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFSubmitJobInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFSubmitJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFSubmitJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFSubmitJob(ctx, req.(*TFSubmitJobInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFGetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFJobInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFGetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFGetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFGetJob(ctx, req.(*TFJobInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFListJobsInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFListJobs(ctx, req.(*TFListJobsInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFGetJobOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFJobOutputInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFGetJobOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFGetJobOutput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFGetJobOutput(ctx, req.(*TFJobOutputInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFCancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFJobInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFCancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFCancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFCancelJob(ctx, req.(*TFJobInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFListLocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFListLocksInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFListLocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFListLocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFListLocks(ctx, req.(*TFListLocksInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFForceUnlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFForceUnlockInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFForceUnlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFForceUnlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFForceUnlock(ctx, req.(*TFForceUnlockInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFListStateVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFStateVersionsInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFListStateVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFListStateVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFListStateVersions(ctx, req.(*TFStateVersionsInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFDiffStateVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFStateDiffInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFDiffStateVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFDiffStateVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFDiffStateVersions(ctx, req.(*TFStateDiffInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFRollbackState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFStateRollbackInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFRollbackState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFRollbackState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFRollbackState(ctx, req.(*TFStateRollbackInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFDetectDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFDriftInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFDetectDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFDetectDrift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFDetectDrift(ctx, req.(*TFDriftInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFListDriftReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFListDriftReportsInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFListDriftReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFListDriftReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFListDriftReports(ctx, req.(*TFListDriftReportsInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFGetDriftReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFDriftReportInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFGetDriftReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFGetDriftReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFGetDriftReport(ctx, req.(*TFDriftReportInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFListWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFWorkspaceInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFListWorkspaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFListWorkspaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFListWorkspaces(ctx, req.(*TFWorkspaceInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFShowWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFWorkspaceInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFShowWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFShowWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFShowWorkspace(ctx, req.(*TFWorkspaceInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFNewWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFWorkspaceInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFNewWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFNewWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFNewWorkspace(ctx, req.(*TFWorkspaceInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFSelectWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFWorkspaceInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFSelectWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFSelectWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFSelectWorkspace(ctx, req.(*TFWorkspaceInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFDeleteWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFWorkspaceInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFDeleteWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFDeleteWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFDeleteWorkspace(ctx, req.(*TFWorkspaceInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFPutSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFPutSecretInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFPutSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFPutSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFPutSecret(ctx, req.(*TFPutSecretInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFListSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFListSecretsInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFListSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFListSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFListSecrets(ctx, req.(*TFListSecretsInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TerraformStationService_TFDeleteSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TFSecretInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TerraformStationServiceServer).TFDeleteSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TerraformStationService_TFDeleteSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerraformStationServiceServer).TFDeleteSecret(ctx, req.(*TFSecretInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TerraformStationService_ServiceDesc is the grpc.ServiceDesc for TerraformStationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TerraformStationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "TerraformStation.TerraformStationService",
+	HandlerType: (*TerraformStationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TFCommand",
+			Handler:    _TerraformStationService_TFCommand_Handler,
+		},
+		{
+			MethodName: "TFPlan",
+			Handler:    _TerraformStationService_TFPlan_Handler,
+		},
+		{
+			MethodName: "TFApply",
+			Handler:    _TerraformStationService_TFApply_Handler,
+		},
+		{
+			MethodName: "TFInit",
+			Handler:    _TerraformStationService_TFInit_Handler,
+		},
+		{
+			MethodName: "TFValidate",
+			Handler:    _TerraformStationService_TFValidate_Handler,
+		},
+		{
+			MethodName: "TFState",
+			Handler:    _TerraformStationService_TFState_Handler,
+		},
+		{
+			MethodName: "TFCancelOperation",
+			Handler:    _TerraformStationService_TFCancelOperation_Handler,
+		},
+		{
+			MethodName: "TFApprovePlan",
+			Handler:    _TerraformStationService_TFApprovePlan_Handler,
+		},
+		{
+			MethodName: "TFRejectPlan",
+			Handler:    _TerraformStationService_TFRejectPlan_Handler,
+		},
+		{
+			MethodName: "TFGetPlanApproval",
+			Handler:    _TerraformStationService_TFGetPlanApproval_Handler,
+		},
+		{
+			MethodName: "TFSubmitJob",
+			Handler:    _TerraformStationService_TFSubmitJob_Handler,
+		},
+		{
+			MethodName: "TFGetJob",
+			Handler:    _TerraformStationService_TFGetJob_Handler,
+		},
+		{
+			MethodName: "TFListJobs",
+			Handler:    _TerraformStationService_TFListJobs_Handler,
+		},
+		{
+			MethodName: "TFGetJobOutput",
+			Handler:    _TerraformStationService_TFGetJobOutput_Handler,
+		},
+		{
+			MethodName: "TFCancelJob",
+			Handler:    _TerraformStationService_TFCancelJob_Handler,
+		},
+		{
+			MethodName: "TFListLocks",
+			Handler:    _TerraformStationService_TFListLocks_Handler,
+		},
+		{
+			MethodName: "TFForceUnlock",
+			Handler:    _TerraformStationService_TFForceUnlock_Handler,
+		},
+		{
+			MethodName: "TFListStateVersions",
+			Handler:    _TerraformStationService_TFListStateVersions_Handler,
+		},
+		{
+			MethodName: "TFDiffStateVersions",
+			Handler:    _TerraformStationService_TFDiffStateVersions_Handler,
+		},
+		{
+			MethodName: "TFRollbackState",
+			Handler:    _TerraformStationService_TFRollbackState_Handler,
+		},
+		{
+			MethodName: "TFDetectDrift",
+			Handler:    _TerraformStationService_TFDetectDrift_Handler,
+		},
+		{
+			MethodName: "TFListDriftReports",
+			Handler:    _TerraformStationService_TFListDriftReports_Handler,
+		},
+		{
+			MethodName: "TFGetDriftReport",
+			Handler:    _TerraformStationService_TFGetDriftReport_Handler,
+		},
+		{
+			MethodName: "TFListWorkspaces",
+			Handler:    _TerraformStationService_TFListWorkspaces_Handler,
+		},
+		{
+			MethodName: "TFShowWorkspace",
+			Handler:    _TerraformStationService_TFShowWorkspace_Handler,
+		},
+		{
+			MethodName: "TFNewWorkspace",
+			Handler:    _TerraformStationService_TFNewWorkspace_Handler,
+		},
+		{
+			MethodName: "TFSelectWorkspace",
+			Handler:    _TerraformStationService_TFSelectWorkspace_Handler,
+		},
+		{
+			MethodName: "TFDeleteWorkspace",
+			Handler:    _TerraformStationService_TFDeleteWorkspace_Handler,
+		},
+		{
+			MethodName: "TFPutSecret",
+			Handler:    _TerraformStationService_TFPutSecret_Handler,
+		},
+		{
+			MethodName: "TFListSecrets",
+			Handler:    _TerraformStationService_TFListSecrets_Handler,
+		},
+		{
+			MethodName: "TFDeleteSecret",
+			Handler:    _TerraformStationService_TFDeleteSecret_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TFCommandStream",
+			Handler:       _TerraformStationService_TFCommandStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TFSubscribeOutput",
+			Handler:       _TerraformStationService_TFSubscribeOutput_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TFSubscribeProgress",
+			Handler:       _TerraformStationService_TFSubscribeProgress_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "spec.proto",
+}
@@ -0,0 +1,46 @@
+package TerraformStation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// StateLockInfo is the lock description OpenTofu sends to the http backend when it locks state,
+// and the body returned to it when the state is already locked
+type StateLockInfo struct {
+	ID        string    `json:"ID"`
+	Operation string    `json:"Operation"`
+	Info      string    `json:"Info"`
+	Who       string    `json:"Who"`
+	Version   string    `json:"Version"`
+	Created   time.Time `json:"Created"`
+	Path      string    `json:"Path"`
+}
+
+// StateMeta holds the fields of a state file that identify its version
+type StateMeta struct {
+	Version          int    `json:"version"`
+	TerraformVersion string `json:"terraform_version"`
+	Serial           int64  `json:"serial"`
+	Lineage          string `json:"lineage"`
+}
+
+// ParseStateMeta reads the version fields of a state file
+func ParseStateMeta(data []byte) (*StateMeta, error) {
+	var meta StateMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, NewInvalidInputError("invalid state file", err.Error())
+	}
+	if meta.Lineage == "" {
+		return nil, NewInvalidInputError("invalid state file", "lineage is missing")
+	}
+	return &meta, nil
+}
+
+// StateChecksum returns the hex encoded SHA-256 of a state file
+func StateChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
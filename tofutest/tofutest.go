@@ -0,0 +1,349 @@
+// Package tofutest provides a fake OpenTofu binary for tests. Each test scripts the output,
+// exit code and delay of the commands it expects, points the station at Fake.Path and
+// afterwards inspects the invocations the fake received.
+//
+// The fake is the test binary itself, so packages using it must hand control to Run from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(tofutest.Run(m))
+//	}
+package tofutest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// scriptEnv names the variable that tells the test binary to act as the fake, and where its script is
+const scriptEnv = "TOFUTEST_SCRIPT"
+
+// Version is what the fake reports for `version` unless a test scripts something else
+const Version = "OpenTofu v1.8.0"
+
+// Fake is a scriptable stand-in for the tofu binary
+type Fake struct {
+	// Path is the executable to use as the OpenTofu path
+	Path string
+
+	t          testing.TB
+	scriptPath string
+	callsPath  string
+
+	mu        sync.Mutex
+	responses []*Response
+}
+
+// script is the file the fake reads on every invocation
+type script struct {
+	Calls     string      `json:"calls"`
+	Responses []*Response `json:"responses"`
+}
+
+// Response is what the fake does when invoked with matching arguments. Its methods return the
+// response so a test can chain them: fake.On("plan").Stdout("...").ExitCode(2).
+type Response struct {
+	fake *Fake
+
+	Args  []string          `json:"args"`
+	Out   string            `json:"stdout,omitempty"`
+	Err   string            `json:"stderr,omitempty"`
+	Code  int               `json:"exit_code,omitempty"`
+	Wait  time.Duration     `json:"delay,omitempty"`
+	Files map[string]string `json:"files,omitempty"`
+	// Interrupt is what the fake does when it is interrupted during its delay
+	Interrupt *Interrupt `json:"interrupt,omitempty"`
+}
+
+// Interrupt describes how the fake handles SIGINT. Without one it dies from the signal.
+type Interrupt struct {
+	Out    string `json:"stdout,omitempty"`
+	Code   int    `json:"exit_code,omitempty"`
+	Ignore bool   `json:"ignore,omitempty"`
+}
+
+// Call is one invocation of the fake
+type Call struct {
+	Args []string `json:"args"`
+	Dir  string   `json:"dir"`
+	Env  []string `json:"env"`
+}
+
+// Getenv returns the value of an environment variable the call ran with
+func (c Call) Getenv(name string) string {
+	for _, kv := range c.Env {
+		if key, value, ok := strings.Cut(kv, "="); ok && key == name {
+			return value
+		}
+	}
+	return ""
+}
+
+// New creates a fake in a temporary directory of the test. It answers `version` with Version
+// and fails every other command until the test scripts a response for it.
+func New(t testing.TB) *Fake {
+	t.Helper()
+
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatalf("tofutest: locating test binary: %v", err)
+	}
+
+	dir := t.TempDir()
+	f := &Fake{
+		Path:       filepath.Join(dir, "tofu"),
+		t:          t,
+		scriptPath: filepath.Join(dir, "script.json"),
+		callsPath:  filepath.Join(dir, "calls.jsonl"),
+	}
+
+	wrapper := fmt.Sprintf("#!/bin/sh\n%s=%s exec %s \"$@\"\n", scriptEnv, shellQuote(f.scriptPath), shellQuote(executable))
+	if err := os.WriteFile(f.Path, []byte(wrapper), 0755); err != nil {
+		t.Fatalf("tofutest: writing fake binary: %v", err)
+	}
+
+	f.On("version").Stdout(Version + "\n")
+	return f
+}
+
+// On adds a response to invocations whose arguments contain args in the same order, such as
+// On("show", "-json") for `show -json tfplan`. When several responses match, the one added last wins.
+func (f *Fake) On(args ...string) *Response {
+	r := &Response{fake: f, Args: args}
+	f.mu.Lock()
+	f.responses = append(f.responses, r)
+	f.mu.Unlock()
+	f.save()
+	return r
+}
+
+// Stdout sets the text written to stdout
+func (r *Response) Stdout(text string) *Response {
+	return r.set(func() { r.Out = text })
+}
+
+// StdoutFile sets stdout to the contents of a file, such as a canned plan JSON in testdata
+func (r *Response) StdoutFile(path string) *Response {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		r.fake.t.Fatalf("tofutest: reading %s: %v", path, err)
+	}
+	return r.Stdout(string(data))
+}
+
+// Stderr sets the text written to stderr, after stdout
+func (r *Response) Stderr(text string) *Response {
+	return r.set(func() { r.Err = text })
+}
+
+// ExitCode sets the exit code
+func (r *Response) ExitCode(code int) *Response {
+	return r.set(func() { r.Code = code })
+}
+
+// Delay makes the fake wait before writing any output
+func (r *Response) Delay(d time.Duration) *Response {
+	return r.set(func() { r.Wait = d })
+}
+
+// OnInterrupt makes the fake stop gracefully when it is interrupted during its delay, the way
+// OpenTofu does: it writes text to stdout and exits with code instead of the scripted output
+func (r *Response) OnInterrupt(text string, code int) *Response {
+	return r.set(func() { r.Interrupt = &Interrupt{Out: text, Code: code} })
+}
+
+// IgnoreInterrupt makes the fake carry on when it is interrupted, so only killing it stops it early
+func (r *Response) IgnoreInterrupt() *Response {
+	return r.set(func() { r.Interrupt = &Interrupt{Ignore: true} })
+}
+
+// WriteFile makes the fake write a file, relative to the directory it runs in, before exiting
+func (r *Response) WriteFile(name, content string) *Response {
+	return r.set(func() {
+		if r.Files == nil {
+			r.Files = map[string]string{}
+		}
+		r.Files[name] = content
+	})
+}
+
+func (r *Response) set(update func()) *Response {
+	r.fake.mu.Lock()
+	update()
+	r.fake.mu.Unlock()
+	r.fake.save()
+	return r
+}
+
+// save writes the script the fake reads when it is invoked
+func (f *Fake) save() {
+	f.mu.Lock()
+	data, err := json.Marshal(script{Calls: f.callsPath, Responses: f.responses})
+	f.mu.Unlock()
+	if err == nil {
+		// Write and rename so a concurrent invocation never reads a partial script
+		tmp := f.scriptPath + ".tmp"
+		if err = os.WriteFile(tmp, data, 0644); err == nil {
+			err = os.Rename(tmp, f.scriptPath)
+		}
+	}
+	if err != nil {
+		f.t.Fatalf("tofutest: writing script: %v", err)
+	}
+}
+
+// Calls returns the invocations the fake has received, in order
+func (f *Fake) Calls() []Call {
+	f.t.Helper()
+
+	file, err := os.Open(f.callsPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		f.t.Fatalf("tofutest: reading calls: %v", err)
+	}
+	defer file.Close()
+
+	var calls []Call
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var call Call
+		if err := json.Unmarshal(scanner.Bytes(), &call); err != nil {
+			f.t.Fatalf("tofutest: reading calls: %v", err)
+		}
+		calls = append(calls, call)
+	}
+	return calls
+}
+
+// CallsTo returns the invocations of a command, such as CallsTo("plan")
+func (f *Fake) CallsTo(command string) []Call {
+	var calls []Call
+	for _, call := range f.Calls() {
+		if len(call.Args) > 0 && call.Args[0] == command {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Run acts as the fake when the test binary is invoked through a Fake, returning its exit code,
+// and otherwise runs the tests
+func Run(m *testing.M) int {
+	path := os.Getenv(scriptEnv)
+	if path == "" {
+		return m.Run()
+	}
+	code, err := runFake(path, os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tofutest: %v\n", err)
+		return 1
+	}
+	return code
+}
+
+// runFake answers one invocation with the response the script holds for its arguments
+func runFake(path string, args []string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	var s script
+	if err := json.Unmarshal(data, &s); err != nil {
+		return 0, err
+	}
+
+	var response *Response
+	for _, r := range s.Responses {
+		if matches(r.Args, args) {
+			response = r
+		}
+	}
+
+	// Handle interrupts before the call is visible, so a test that waits for it can interrupt right away
+	interrupted := make(chan os.Signal, 1)
+	if response != nil && response.Interrupt != nil {
+		if response.Interrupt.Ignore {
+			signal.Ignore(os.Interrupt)
+		} else {
+			signal.Notify(interrupted, os.Interrupt)
+		}
+	}
+
+	if err := recordCall(s.Calls, args); err != nil {
+		return 0, err
+	}
+	if response == nil {
+		return 0, fmt.Errorf("no response scripted for %q", args)
+	}
+
+	select {
+	case <-time.After(response.Wait):
+	case <-interrupted:
+		fmt.Fprint(os.Stdout, response.Interrupt.Out)
+		return response.Interrupt.Code, nil
+	}
+
+	// Like OpenTofu, a plan saved with -out is written to disk
+	for _, arg := range args {
+		if out, ok := strings.CutPrefix(arg, "-out="); ok {
+			if err := os.WriteFile(out, []byte("tofutest plan\n"), 0644); err != nil {
+				return 0, err
+			}
+		}
+	}
+	for name, content := range response.Files {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			return 0, err
+		}
+	}
+
+	fmt.Fprint(os.Stdout, response.Out)
+	fmt.Fprint(os.Stderr, response.Err)
+	return response.Code, nil
+}
+
+// recordCall appends an invocation to the calls file
+func recordCall(path string, args []string) error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(Call{Args: args, Dir: dir, Env: os.Environ()})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// matches reports whether args contains pattern in the same order
+func matches(pattern, args []string) bool {
+	i := 0
+	for _, arg := range args {
+		if i < len(pattern) && arg == pattern[i] {
+			i++
+		}
+	}
+	return i == len(pattern)
+}
+
+// shellQuote quotes a string for /bin/sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
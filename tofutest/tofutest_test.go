@@ -0,0 +1,111 @@
+package tofutest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ForestMars/TerraformStation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(Run(m))
+}
+
+func TestFake(t *testing.T) {
+	fake := New(t)
+	fake.On("plan").Stdout("Plan: 1 to add\n").Stderr("warning\n").ExitCode(2)
+	fake.On("plan", "-destroy").Stdout("Plan: 1 to destroy\n")
+
+	dir := t.TempDir()
+	executor := TerraformStation.NewOpenTofuExecutor(fake.Path, 10*time.Second).WithEnv("TF_WORKSPACE=staging")
+
+	result, err := executor.Execute(context.Background(), dir, "version")
+	require.NoError(t, err)
+	assert.Equal(t, Version+"\n", result.Stdout)
+
+	result, err = executor.Execute(context.Background(), dir, "plan", "-input=false", "-out=tfplan")
+	require.Error(t, err)
+	assert.Equal(t, 2, result.ExitCode)
+	assert.Equal(t, "Plan: 1 to add\n", result.Stdout)
+	assert.Equal(t, "warning\n", result.Stderr)
+	assert.FileExists(t, filepath.Join(dir, "tfplan"))
+
+	// The response added last wins
+	result, err = executor.Execute(context.Background(), dir, "plan", "-destroy")
+	require.NoError(t, err)
+	assert.Equal(t, "Plan: 1 to destroy\n", result.Stdout)
+
+	// Commands without a response fail
+	result, err = executor.Execute(context.Background(), dir, "apply")
+	require.Error(t, err)
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Contains(t, result.Stderr, "no response scripted")
+
+	calls := fake.Calls()
+	require.Len(t, calls, 4)
+	assert.Equal(t, []string{"plan", "-input=false", "-out=tfplan"}, calls[1].Args)
+	resolved, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, resolved, calls[1].Dir)
+	assert.Equal(t, "staging", calls[1].Getenv("TF_WORKSPACE"))
+	assert.Len(t, fake.CallsTo("plan"), 2)
+}
+
+func TestFakeDelayAndFiles(t *testing.T) {
+	fake := New(t)
+	fake.On("apply").Delay(time.Second).WriteFile("terraform.tfstate", `{"serial": 1}`)
+
+	dir := t.TempDir()
+	executor := TerraformStation.NewOpenTofuExecutor(fake.Path, 100*time.Millisecond)
+	result, err := executor.Execute(context.Background(), dir, "apply")
+	require.Error(t, err)
+	assert.True(t, result.TimedOut)
+
+	executor = TerraformStation.NewOpenTofuExecutor(fake.Path, 10*time.Second)
+	_, err = executor.Execute(context.Background(), dir, "apply")
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, "terraform.tfstate"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"serial": 1}`, string(data))
+}
+
+func TestFakeInterrupt(t *testing.T) {
+	fake := New(t)
+	fake.On("apply").Delay(time.Minute).OnInterrupt("Interrupt received.\n", 1)
+	fake.On("apply", "-lock=false").Delay(time.Minute).IgnoreInterrupt()
+
+	dir := t.TempDir()
+	executor := TerraformStation.NewOpenTofuExecutor(fake.Path, time.Minute).WithGracePeriod(200 * time.Millisecond)
+
+	interrupt := func(args ...string) (*TerraformStation.ExecutionResult, error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		calls := len(fake.CallsTo("apply"))
+		go func() {
+			for len(fake.CallsTo("apply")) == calls {
+				time.Sleep(10 * time.Millisecond)
+			}
+			cancel()
+		}()
+		return executor.Execute(ctx, dir, args...)
+	}
+
+	// The fake stops gracefully, with the output OpenTofu writes when interrupted
+	result, err := interrupt("apply")
+	require.Error(t, err)
+	assert.True(t, result.Cancelled)
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Equal(t, "Interrupt received.\n", result.Stdout)
+	assert.Empty(t, result.Signal)
+
+	// The fake carries on until the grace period ends and it is killed
+	result, err = interrupt("apply", "-lock=false")
+	require.Error(t, err)
+	assert.True(t, result.Cancelled)
+	assert.Equal(t, "killed", result.Signal)
+}
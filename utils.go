@@ -1,56 +1,266 @@
 package TerraformStation
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 )
 
+// Executor runs OpenTofu commands. OpenTofuExecutor runs the OpenTofu binary, and tests can
+// substitute an executor that does not need one.
+type Executor interface {
+	// Execute runs a command in a working directory and returns its result
+	Execute(ctx context.Context, workingDir string, args ...string) (*ExecutionResult, error)
+	// ExecuteStream runs a command, passing each line of output to onLine as it arrives
+	ExecuteStream(ctx context.Context, workingDir string, onLine LineHandler, args ...string) (*ExecutionResult, error)
+	// ValidateWorkingDirectory checks that commands can run in a directory
+	ValidateWorkingDirectory(dir string) error
+	// WithEnv returns an executor that adds KEY=value pairs to the environment of its commands
+	WithEnv(env ...string) Executor
+}
+
+var _ Executor = (*OpenTofuExecutor)(nil)
+
 // OpenTofuExecutor handles the execution of OpenTofu commands
 type OpenTofuExecutor struct {
 	opentofuPath string
 	timeout       time.Duration
+	// gracePeriod is how long an interrupted command may take to stop before it is killed
+	gracePeriod time.Duration
+	// allowlist names the variables of the station's environment that commands inherit
+	allowlist []string
+	// env holds KEY=value pairs added to the environment of every command
+	env []string
 }
 
-// NewOpenTofuExecutor creates a new OpenTofu executor
+// DefaultInterruptGracePeriod is how long an interrupted command may take to stop unless configured otherwise
+const DefaultInterruptGracePeriod = time.Minute
+
+// NewOpenTofuExecutor creates a new OpenTofu executor whose commands inherit the variables of the
+// station's environment named in DefaultEnvironmentAllowlist
 func NewOpenTofuExecutor(opentofuPath string, timeout time.Duration) *OpenTofuExecutor {
 	return &OpenTofuExecutor{
 		opentofuPath: opentofuPath,
 		timeout:       timeout,
+		gracePeriod:   DefaultInterruptGracePeriod,
+		allowlist:     DefaultEnvironmentAllowlist,
+	}
+}
+
+// WithGracePeriod returns a copy of the executor that gives interrupted commands the given time
+// to stop before killing them
+func (e *OpenTofuExecutor) WithGracePeriod(gracePeriod time.Duration) *OpenTofuExecutor {
+	scoped := *e
+	scoped.gracePeriod = gracePeriod
+	return &scoped
+}
+
+// WithAllowedEnv returns a copy of the executor whose commands inherit only the variables of the
+// station's environment named in allowlist, where an entry ending in * matches by prefix
+func (e *OpenTofuExecutor) WithAllowedEnv(allowlist []string) *OpenTofuExecutor {
+	scoped := *e
+	scoped.allowlist = allowlist
+	return &scoped
+}
+
+// WithEnv returns a copy of the executor that adds the given KEY=value pairs to the environment
+// of the commands it runs, overriding variables of the same name inherited from the station
+func (e *OpenTofuExecutor) WithEnv(env ...string) Executor {
+	scoped := *e
+	scoped.env = append(append([]string(nil), e.env...), env...)
+	return &scoped
+}
+
+// Output stream names reported to line handlers
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+// LineHandler receives a single line of command output as soon as it is produced
+type LineHandler func(stream, line string)
+
+// outputWaitDelay bounds how long to wait for output pipes to close after the process exits
+const outputWaitDelay = time.Second
+
+// lineWriter splits written data into lines, passing each complete line to emit
+type lineWriter struct {
+	buf  []byte
+	emit func(line string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(string(w.buf[:i+1]))
+		w.buf = w.buf[i+1:]
 	}
+	return len(p), nil
+}
+
+// flush emits any trailing output that did not end with a newline
+func (w *lineWriter) flush() {
+	if len(w.buf) > 0 {
+		w.emit(string(w.buf))
+		w.buf = nil
+	}
+}
+
+// ExecutionResult describes the outcome of an OpenTofu process
+type ExecutionResult struct {
+	// Output holds stdout and stderr interleaved in the order lines arrived
+	Output string
+	Stdout string
+	Stderr string
+	// ExitCode is the process exit code, or -1 if the process never exited normally
+	ExitCode int
+	// Signal names the signal that terminated the process, if any
+	Signal   string
+	TimedOut bool
+	// Cancelled is set if the context was cancelled while the command ran
+	Cancelled bool
+	Duration  time.Duration
 }
 
 // Execute runs an OpenTofu command with the given arguments
-func (e *OpenTofuExecutor) Execute(ctx context.Context, workingDir string, args ...string) (string, error) {
+func (e *OpenTofuExecutor) Execute(ctx context.Context, workingDir string, args ...string) (*ExecutionResult, error) {
+	return e.ExecuteStream(ctx, workingDir, nil, args...)
+}
+
+// ExecuteStream runs an OpenTofu command, passing each line of stdout and stderr
+// to onLine as it arrives. The result is always returned, together with an error
+// if the command could not be run, timed out, was cancelled or exited with a non-zero code.
+//
+// When ctx is cancelled or the timeout expires, the command is sent SIGINT so OpenTofu can finish
+// the resource operations in flight, write state and release its locks. If it is still running
+// after the grace period, its whole process group, including provider plugins, is killed.
+func (e *OpenTofuExecutor) ExecuteStream(ctx context.Context, workingDir string, onLine LineHandler, args ...string) (*ExecutionResult, error) {
+	result := &ExecutionResult{ExitCode: -1}
+
 	// Create a context with timeout
 	ctx, cancel := context.WithTimeout(ctx, e.timeout)
 	defer cancel()
 
 	// Validate working directory
 	if err := e.ValidateWorkingDirectory(workingDir); err != nil {
-		return "", err
+		return result, err
 	}
 
 	// Check if opentofu binary exists
 	if err := e.checkOpenTofuBinary(); err != nil {
-		return "", err
+		return result, err
 	}
 
-	// Prepare command
-	cmd := exec.CommandContext(ctx, e.opentofuPath, args...)
+	// Prepare command. It is not tied to ctx, which would kill it outright.
+	cmd := exec.Command(e.opentofuPath, args...)
 	cmd.Dir = workingDir
-	cmd.Env = os.Environ()
+	cmd.Env = append(inheritedEnv(os.Environ(), e.allowlist), e.env...)
+	startInProcessGroup(cmd)
+
+	// Capture both streams line by line, serializing delivery so lines are never interleaved
+	var (
+		mu                           sync.Mutex
+		output, stdoutBuf, stderrBuf strings.Builder
+	)
+	emit := func(stream string, buf *strings.Builder) func(string) {
+		return func(line string) {
+			if !strings.HasSuffix(line, "\n") {
+				line += "\n"
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			output.WriteString(line)
+			buf.WriteString(line)
+			if onLine != nil {
+				onLine(stream, strings.TrimRight(line, "\r\n"))
+			}
+		}
+	}
+	stdoutWriter := &lineWriter{emit: emit(StreamStdout, &stdoutBuf)}
+	stderrWriter := &lineWriter{emit: emit(StreamStderr, &stderrBuf)}
+	cmd.Stdout = stdoutWriter
+	cmd.Stderr = stderrWriter
 
-	// Capture output
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return string(output), fmt.Errorf("opentofu command failed: %w", err)
+	// Stop waiting for output once the process has been killed, even if children still hold the pipes
+	cmd.WaitDelay = outputWaitDelay
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return result, fmt.Errorf("failed to start opentofu: %w", err)
+	}
+
+	exited := make(chan struct{})
+	go e.interruptOnDone(ctx, cmd, exited)
+
+	waitErr := cmd.Wait()
+	close(exited)
+	if ctx.Err() != nil {
+		// Make sure no provider plugin outlives an interrupted command
+		killProcessGroup(cmd)
+	}
+	stdoutWriter.flush()
+	stderrWriter.flush()
+
+	result.Duration = time.Since(start)
+	result.Output = output.String()
+	result.Stdout = stdoutBuf.String()
+	result.Stderr = stderrBuf.String()
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+		if status, ok := cmd.ProcessState.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			result.Signal = status.Signal().String()
+		}
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.TimedOut = true
+		return result, NewTimeoutError("opentofu command timed out", e.timeout.String())
+	}
+	if errors.Is(ctx.Err(), context.Canceled) {
+		result.Cancelled = true
+		return result, fmt.Errorf("opentofu command was cancelled: %w", context.Cause(ctx))
+	}
+	if waitErr != nil {
+		return result, fmt.Errorf("opentofu command failed: %w", waitErr)
 	}
 
-	return string(output), nil
+	return result, nil
+}
+
+// interruptOnDone interrupts a started command once ctx is done, and kills its process group
+// if it has not exited when the grace period ends
+func (e *OpenTofuExecutor) interruptOnDone(ctx context.Context, cmd *exec.Cmd, exited <-chan struct{}) {
+	select {
+	case <-exited:
+		return
+	case <-ctx.Done():
+	}
+
+	if err := interruptProcessGroup(cmd); err != nil {
+		killProcessGroup(cmd)
+		return
+	}
+
+	timer := time.NewTimer(e.gracePeriod)
+	defer timer.Stop()
+	select {
+	case <-exited:
+	case <-timer.C:
+		killProcessGroup(cmd)
+	}
 }
 
 // ValidateWorkingDirectory checks if the working directory is valid
@@ -96,36 +306,6 @@ func (e *OpenTofuExecutor) checkOpenTofuBinary() error {
 	return nil
 }
 
-// BuildOpenTofuArgs constructs the arguments for an OpenTofu command
-func BuildOpenTofuArgs(command string, input *TFCommandInput) []string {
-	args := []string{command}
-
-	// Add working directory if specified
-	if input.WorkingDirectory != "" {
-		args = append(args, "-chdir="+input.WorkingDirectory)
-	}
-
-	// Add variables
-	for key, value := range input.Variables {
-		args = append(args, "-var", fmt.Sprintf("%s=%s", key, value))
-	}
-
-	// Add additional arguments
-	args = append(args, input.Arguments...)
-
-	// Add plan file if specified
-	if input.PlanFile != "" {
-		args = append(args, input.PlanFile)
-	}
-
-	// Add state file if specified
-	if input.StateFile != "" {
-		args = append(args, "-state="+input.StateFile)
-	}
-
-	return args
-}
-
 // ValidateTFCommandInput validates the input for OpenTofu commands
 func ValidateTFCommandInput(input *TFCommandInput) error {
 	if input == nil {
@@ -137,22 +317,55 @@ func ValidateTFCommandInput(input *TFCommandInput) error {
 	}
 
 	// Validate command
-	validCommands := map[string]bool{
-		"init":     true,
-		"plan":     true,
-		"apply":    true,
-		"destroy":  true,
-		"validate": true,
-		"state":    true,
-		"output":   true,
-		"show":     true,
-		"version":  true,
-	}
-
-	if !validCommands[input.Command] {
+	if _, ok := commandBuilders[input.Command]; !ok {
 		return NewInvalidInputError("invalid opentofu command", input.Command)
 	}
 
+	for _, name := range sortedKeys(input.SecretVariables) {
+		if !tfVarNamePattern.MatchString(name) {
+			return NewInvalidInputError("invalid secret variable name", name)
+		}
+		if _, ok := input.Variables[name]; ok {
+			return NewInvalidInputError("variable is set both directly and from a secret", name)
+		}
+		if err := ValidateSecretName(input.SecretVariables[name]); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(input.SecretEnv) {
+		// TF_ variables could change the arguments or workspace of the command; input variables belong in secret_variables
+		if !envNamePattern.MatchString(name) || strings.HasPrefix(name, "TF_") {
+			return NewInvalidInputError("invalid secret environment variable name: must be a variable name not starting with TF_", name)
+		}
+		if err := ValidateSecretName(input.SecretEnv[name]); err != nil {
+			return err
+		}
+	}
+
+	if input.Workspace != "" {
+		// TF_WORKSPACE overrides the workspace the workspace subcommands act on, which makes select and delete fail
+		if input.Command == "workspace" {
+			return NewInvalidInputError("workspace cannot be set for workspace commands, pass the workspace name as an argument")
+		}
+		if err := ValidateWorkspaceName(input.Workspace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// workspaceNamePattern matches the workspace names OpenTofu accepts that are also safe as a directory name
+var workspaceNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateWorkspaceName checks that a workspace name is valid
+func ValidateWorkspaceName(name string) error {
+	if name == "" {
+		return NewInvalidInputError("workspace name cannot be empty")
+	}
+	if len(name) > 90 || !workspaceNamePattern.MatchString(name) {
+		return NewInvalidInputError("invalid workspace name", name)
+	}
 	return nil
 }
 
@@ -171,7 +384,25 @@ func SanitizeWorkingDirectory(dir string) string {
 	return cleanPath
 }
 
+// CanonicalWorkingDirectory returns the absolute form of dir with symlinks resolved,
+// so the same directory is always recorded under the same path
+func CanonicalWorkingDirectory(dir string) (string, error) {
+	absPath, err := filepath.Abs(dir)
+	if err != nil {
+		return "", NewWorkingDirError("cannot resolve working directory", err.Error())
+	}
+	if resolved, err := filepath.EvalSymlinks(absPath); err == nil {
+		return resolved, nil
+	}
+	return absPath, nil
+}
+
 // GenerateCommandID creates a unique identifier for a command execution
 func GenerateCommandID() string {
 	return fmt.Sprintf("tofu_%d", time.Now().UnixNano())
 }
+
+// GenerateLockID creates a unique identifier for a working directory lock
+func GenerateLockID() string {
+	return fmt.Sprintf("lock_%d", time.Now().UnixNano())
+}